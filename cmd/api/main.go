@@ -2,13 +2,17 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"tiny-url/internal/config"
+	"tiny-url/internal/database"
 	"tiny-url/internal/server"
 )
 
@@ -36,9 +40,57 @@ func gracefulShutdown(apiServer *http.Server, done chan bool) {
 	done <- true
 }
 
+// openDatabase abre la base de datos del backend de almacenamiento configurado
+func openDatabase(cfg config.Config) (database.Service, error) {
+	switch cfg.Storage {
+	case config.StoragePostgres:
+		return database.New(cfg.Database)
+	case config.StorageSQLite:
+		return database.NewSQLite(cfg.Database)
+	case config.StorageMemory:
+		return nil, fmt.Errorf("storage backend %q has no database", cfg.Storage)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Storage)
+	}
+}
+
 func main() {
+	// Subcomando para gestionar las migraciones del esquema
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(os.Args[2:]))
+	}
+
+	cfg := config.Load()
+
+	// Los flags tienen prioridad sobre las variables de entorno
+	flag.StringVar(&cfg.Storage, "storage", cfg.Storage, "storage backend: postgres, sqlite or memory")
+	flag.Parse()
 
-	server := server.NewServer()
+	// El almacenamiento en memoria no necesita base de datos ni migraciones
+	var db database.Service
+	if cfg.Storage != config.StorageMemory {
+		var err error
+		db, err = openDatabase(cfg)
+		if err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+
+		// Aplicar las migraciones pendientes del esquema
+		if !cfg.Database.SkipMigrations {
+			migrator, err := db.Migrator()
+			if err != nil {
+				log.Fatalf("Failed to load migrations: %v", err)
+			}
+			if _, err := migrator.Up(context.Background()); err != nil {
+				log.Fatalf("Failed to migrate database schema: %v", err)
+			}
+			warnLegacyURLs(context.Background(), migrator)
+		}
+	} else {
+		log.Println("Using in-memory storage, data will be lost on shutdown")
+	}
+
+	server := server.NewServer(cfg, db)
 
 	// Create a done channel to signal when the shutdown is complete
 	done := make(chan bool, 1)
@@ -53,5 +105,12 @@ func main() {
 
 	// Wait for the graceful shutdown to complete
 	<-done
+
+	// Cerrar el pool una vez que no quedan peticiones en curso
+	if db != nil {
+		if err := db.Close(); err != nil {
+			log.Printf("Failed to close database: %v", err)
+		}
+	}
 	log.Println("Graceful shutdown complete.")
 }
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"tiny-url/internal/config"
+	"tiny-url/internal/database/migrations"
+)
+
+const migrateUsage = `Uso: api migrate [-dir DIR] <comando> [argumentos]
+
+Comandos:
+  up              aplica todas las migraciones pendientes
+  down [N]        revierte las últimas N migraciones (por defecto 1)
+  status          muestra el estado de cada migración
+  create <nombre> crea los ficheros up/down de una nueva migración
+                  para cada motor soportado
+  claim-legacy <usuario>
+                  asigna al usuario las URLs creadas antes de que las URLs
+                  tuvieran propietario
+`
+
+// runMigrate ejecuta el subcomando migrate y devuelve el código de salida
+func runMigrate(args []string) int {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	dir := fs.String("dir", migrations.Dir, "directorio de las migraciones (solo para create)")
+	fs.Usage = func() { fmt.Fprint(os.Stderr, migrateUsage) }
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	command, rest := fs.Arg(0), fs.Args()[1:]
+
+	// create no necesita conexión a la base de datos
+	if command == "create" {
+		if len(rest) != 1 {
+			fs.Usage()
+			return 2
+		}
+		paths, err := migrations.Create(*dir, rest[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error al crear la migración: %v\n", err)
+			return 1
+		}
+		for _, path := range paths {
+			fmt.Printf("Creada %s\n", path)
+		}
+		return 0
+	}
+
+	db, err := openDatabase(config.Load())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error al conectar con la base de datos: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	migrator, err := db.Migrator()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error al inicializar el migrador: %v\n", err)
+		return 1
+	}
+
+	ctx := context.Background()
+
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error al migrar: %v\n", err)
+			return 1
+		}
+		fmt.Printf("%d migraciones aplicadas\n", len(applied))
+		warnLegacyURLs(ctx, migrator)
+
+	case "down":
+		steps := 1
+		if len(rest) > 0 {
+			steps, err = strconv.Atoi(rest[0])
+			if err != nil || steps <= 0 {
+				fmt.Fprintf(os.Stderr, "Número de pasos inválido: %s\n", rest[0])
+				return 2
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error al revertir: %v\n", err)
+			return 1
+		}
+		fmt.Printf("%d migraciones revertidas\n", len(reverted))
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error al obtener el estado: %v\n", err)
+			return 1
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSIÓN\tNOMBRE\tESTADO\tAPLICADA")
+		for _, status := range statuses {
+			state, appliedAt := "pendiente", ""
+			if status.Applied {
+				state = "aplicada"
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+		}
+		w.Flush()
+
+	case "claim-legacy":
+		if len(rest) != 1 {
+			fs.Usage()
+			return 2
+		}
+		claimed, err := migrator.ClaimLegacyURLs(ctx, rest[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error al asignar las URLs sin propietario: %v\n", err)
+			return 1
+		}
+		fmt.Printf("%d URLs asignadas a %s\n", claimed, rest[0])
+
+	default:
+		fs.Usage()
+		return 2
+	}
+
+	return 0
+}
+
+// warnLegacyURLs avisa de las URLs sin propietario, que ningún usuario ve
+// hasta que se asignan con claim-legacy
+func warnLegacyURLs(ctx context.Context, migrator *migrations.Migrator) {
+	count, err := migrator.LegacyURLs(ctx)
+	if err != nil {
+		log.Printf("Failed to count URLs without owner: %v", err)
+		return
+	}
+	if count > 0 {
+		log.Printf("%d URLs have no owner and are hidden from every user; assign them with: api migrate claim-legacy <username>", count)
+	}
+}
@@ -0,0 +1,2624 @@
+// Package v1 Code generated by swaggo/swag. DO NOT EDIT
+package v1
+
+import "github.com/swaggo/swag"
+
+const docTemplatev1 = `{
+    "schemes": {{ marshal .Schemes }},
+    "swagger": "2.0",
+    "info": {
+        "description": "{{escape .Description}}",
+        "title": "{{.Title}}",
+        "termsOfService": "http://swagger.io/terms/",
+        "contact": {
+            "name": "API Support",
+            "url": "http://www.swagger.io/support",
+            "email": "support@swagger.io"
+        },
+        "license": {
+            "name": "Apache 2.0",
+            "url": "http://www.apache.org/licenses/LICENSE-2.0.html"
+        },
+        "version": "{{.Version}}"
+    },
+    "host": "{{.Host}}",
+    "basePath": "{{.BasePath}}",
+    "paths": {
+        "/api/v1/auth/login": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Autentica a un usuario y devuelve un token JWT",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Iniciar sesión",
+                "parameters": [
+                    {
+                        "description": "Credenciales de usuario",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UserCredentials"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Inicio de sesión exitoso",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AuthResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Credenciales inválidas",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/register": {
+            "post": {
+                "description": "Crea un nuevo usuario en el sistema y devuelve un token de autenticación",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Registrar un nuevo usuario",
+                "parameters": [
+                    {
+                        "description": "Datos de registro del usuario",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Usuario creado correctamente",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AuthResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Error en la solicitud",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/folders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve las carpetas del usuario ordenadas por nombre",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "folders"
+                ],
+                "summary": "Listar carpetas",
+                "responses": {
+                    "200": {
+                        "description": "Carpetas del usuario",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ListFoldersResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Crea una carpeta del usuario en la que guardar sus URLs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "folders"
+                ],
+                "summary": "Crear una carpeta",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clave para reintentar la petición sin repetir sus efectos",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Nombre de la carpeta",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.FolderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Carpeta creada",
+                        "schema": {
+                            "$ref": "#/definitions/model.Folder"
+                        }
+                    },
+                    "400": {
+                        "description": "Nombre inválido",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Ya existe una carpeta con ese nombre o petición con la misma clave en curso",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Clave de idempotencia reutilizada con otra petición",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/folders/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Elimina una carpeta del usuario. Sus URLs se conservan fuera de cualquier carpeta",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "folders"
+                ],
+                "summary": "Eliminar una carpeta",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clave para reintentar la petición sin repetir sus efectos",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la carpeta",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Carpeta eliminada correctamente",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Carpeta no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Petición con la misma clave en curso",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Clave de idempotencia reutilizada con otra petición",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cambia el nombre de una carpeta del usuario",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "folders"
+                ],
+                "summary": "Renombrar una carpeta",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clave para reintentar la petición sin repetir sus efectos",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la carpeta",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nuevo nombre de la carpeta",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.FolderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Carpeta renombrada",
+                        "schema": {
+                            "$ref": "#/definitions/model.Folder"
+                        }
+                    },
+                    "400": {
+                        "description": "Nombre inválido",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Carpeta no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Ya existe una carpeta con ese nombre o petición con la misma clave en curso",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Clave de idempotencia reutilizada con otra petición",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene el perfil del usuario autenticado",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Obtener perfil de usuario",
+                "responses": {
+                    "200": {
+                        "description": "Perfil del usuario",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "401": {
+                        "description": "No autenticado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cambia el idioma preferido del usuario autenticado. Un idioma vacío vuelve a usar Accept-Language",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Actualizar perfil de usuario",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clave para reintentar la petición sin repetir sus efectos",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Preferencias del usuario",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Perfil actualizado",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "No autenticado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Petición con la misma clave en curso",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Clave de idempotencia reutilizada con otra petición",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tags": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve las etiquetas del usuario ordenadas por nombre",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Listar etiquetas",
+                "responses": {
+                    "200": {
+                        "description": "Etiquetas del usuario",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ListTagsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Crea una etiqueta del usuario. Las etiquetas también se crean al asignarlas a una URL.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Crear una etiqueta",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clave para reintentar la petición sin repetir sus efectos",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Nombre de la etiqueta",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Etiqueta creada",
+                        "schema": {
+                            "$ref": "#/definitions/model.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Nombre inválido",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Ya existe una etiqueta con ese nombre o petición con la misma clave en curso",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Clave de idempotencia reutilizada con otra petición",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tags/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Elimina una etiqueta del usuario y la quita de todas sus URLs",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Eliminar una etiqueta",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clave para reintentar la petición sin repetir sus efectos",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la etiqueta",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Etiqueta eliminada correctamente",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Etiqueta no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Petición con la misma clave en curso",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Clave de idempotencia reutilizada con otra petición",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cambia el nombre de una etiqueta del usuario en todas sus URLs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Renombrar una etiqueta",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clave para reintentar la petición sin repetir sus efectos",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la etiqueta",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nuevo nombre de la etiqueta",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Etiqueta renombrada",
+                        "schema": {
+                            "$ref": "#/definitions/model.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Nombre inválido",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Etiqueta no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Ya existe una etiqueta con ese nombre o petición con la misma clave en curso",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Clave de idempotencia reutilizada con otra petición",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/urls": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene una lista paginada de URLs, con filtros, búsqueda, ordenación y el total de resultados.\nPara recorrer listados grandes se recomiendan los cursores next_cursor y prev_cursor, estables aunque se creen URLs entre páginas.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Listar todas las URLs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Límite de resultados por página (default: 10, máximo: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Desplazamiento para paginación (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "-created_at",
+                            "visits",
+                            "-visits"
+                        ],
+                        "type": "string",
+                        "description": "Orden: created_at, visits; con '-' delante es descendente (default: -created_at)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Texto a buscar en la URL original y en el código corto",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dominio de destino, incluidos sus subdominios",
+                        "name": "domain",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "expired"
+                        ],
+                        "type": "string",
+                        "description": "Estado de caducidad",
+                        "name": "expiry",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "scheduled",
+                            "active",
+                            "ended"
+                        ],
+                        "type": "string",
+                        "description": "Estado de la programación: aún no activa, activa o terminada",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Creadas desde esta fecha (RFC 3339 o AAAA-MM-DD, incluida)",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Creadas antes de esta fecha (RFC 3339 o AAAA-MM-DD, excluida)",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nombre de una etiqueta de las URLs",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Carpeta de las URLs; 0 lista las que no están en ninguna",
+                        "name": "folder_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor next_cursor o prev_cursor de una respuesta anterior; sustituye a offset",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Lista de URLs",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ListURLsResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Enlaces RFC 8288 a las páginas first, next y prev"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Parámetros inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Crea una versión acortada de una URL proporcionada. Si el usuario ya la había acortado devuelve la existente,\nsalvo que se pidan etiquetas (tags) o carpeta (folder_id): entonces siempre se crea una URL nueva con ellas.\nCon template, url es una plantilla de destino: {path} es la ruta que sigue al código, {query.nombre} un parámetro\nde la visita y {header.Nombre} una de sus cabeceras, con un valor por defecto opcional tras \"|\" ({query.lang|es}).\ndevice_rules envía a otro destino, como la ficha en la tienda de apps o un deep link, las visitas desde iOS,\nAndroid, escritorio o rastreadores, según su User-Agent.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Acortar una URL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clave para reintentar la petición sin repetir sus efectos",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "URL a acortar",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ShortenURLRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "URL acortada exitosamente",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.URLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "URL inválida",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Carpeta no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Petición con la misma clave en curso",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Clave de idempotencia reutilizada con otra petición",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/urls/batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Aplica una operación (delete, set-expiry, add-tags, remove-tags o change-owner) a varias URLs del usuario,\nelegidas por sus códigos cortos (short_codes) o con un filtro (filter) con los criterios del listado.\nLa operación es transaccional: si falla en alguna URL no se aplica a ninguna y committed es false.\nLas URLs de otros usuarios se tratan como inexistentes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Operar sobre varias URLs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clave para reintentar la petición sin repetir sus efectos",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Operación y URLs afectadas",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BatchURLsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Resultado de cada URL",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BatchURLsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Petición inválida o demasiadas URLs",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Nuevo propietario no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Petición con la misma clave en curso",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Filtro con más URLs que el límite o clave de idempotencia reutilizada",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/urls/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Crea varias URLs acortadas a partir de un array JSON de objetos {\"url\": \"...\"} o de un CSV\n(cuerpo text/csv o fichero \"file\" en multipart/form-data) con una URL por fila en la columna \"url\" o en la primera.\nCada URL se valida y deduplica igual que en POST /api/v1/urls. Con atomic=true se crean todas o ninguna.\nLos elementos solo admiten \"url\": cualquier otro campo, como los ajustes de POST /api/v1/urls, se rechaza.",
+                "consumes": [
+                    "application/json",
+                    "text/csv",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Acortar varias URLs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clave para reintentar la petición sin repetir sus efectos",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Crear todas las URLs en una transacción (default: false)",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "description": "URLs a acortar",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.BulkURLItem"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Resultado de cada URL",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BulkShortenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Petición inválida o demasiadas URLs",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Petición con la misma clave en curso",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Cuerpo demasiado grande con Idempotency-Key",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Clave de idempotencia reutilizada con otra petición",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/urls/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene una lista paginada de las URLs eliminadas del usuario que aún no se han purgado, las más recientes primero",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Listar la papelera",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Límite de resultados por página (default: 10, máximo: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Desplazamiento para paginación (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "URLs de la papelera",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ListURLsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Parámetros inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/urls/{shortCode}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Obtiene información detallada sobre una URL acortada",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Obtener información de una URL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Código corto de la URL",
+                        "name": "shortCode",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Información de la URL",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.URLResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "URL no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mueve a la papelera una URL acortada del usuario por su código corto. Se puede restaurar hasta que se purga y\nmientras tanto su código no se reutiliza.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Eliminar una URL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clave para reintentar la petición sin repetir sus efectos",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Código corto de la URL",
+                        "name": "shortCode",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "URL eliminada correctamente",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "URL no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Petición con la misma clave en curso",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Clave de idempotencia reutilizada con otra petición",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cambia las etiquetas, la carpeta o el límite de visitas de una URL del usuario. tags sustituye todas sus etiquetas,\ncreando las que no existan ([] las quita); folder_id la mueve a otra carpeta del usuario (0 la saca de la carpeta);\nmax_visits cambia las visitas permitidas, contando las ya recibidas (0 quita el límite); redirect_code\ncambia el código de la redirección (0 vuelve al del servidor); forward_query y forward_path cambian qué\npartes de la visita se pasan al destino (none deja de pasar la query string); device_rules sustituye las\nreglas por dispositivo ([] las quita).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Modificar una URL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clave para reintentar la petición sin repetir sus efectos",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Código corto de la URL",
+                        "name": "shortCode",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cambios de la URL",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateURLRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "URL modificada",
+                        "schema": {
+                            "$ref": "#/definitions/model.URL"
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "URL o carpeta no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Petición con la misma clave en curso",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Clave de idempotencia reutilizada con otra petición",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/urls/{shortCode}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Saca de la papelera una URL eliminada del usuario, que vuelve a redirigir",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Restaurar una URL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clave para reintentar la petición sin repetir sus efectos",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Código corto de la URL",
+                        "name": "shortCode",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "URL restaurada",
+                        "schema": {
+                            "$ref": "#/definitions/model.URL"
+                        }
+                    },
+                    "401": {
+                        "description": "No autorizado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "URL no encontrada en la papelera",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Petición con la misma clave en curso",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Clave de idempotencia reutilizada con otra petición",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/{shortCode}": {
+            "get": {
+                "description": "Redirige al usuario a la URL original correspondiente al código corto. Si la URL tiene contraseña y el navegador no tiene un pase vigente, muestra un formulario para introducirla.\nAntes de su activación, o tras su fin, redirige temporalmente a su URL alternativa; sin ella muestra una página de aviso antes de la activación.\nSi la URL lo tiene activado, la ruta que sigue al código y la query string de la visita se pasan al destino.\nSi es una plantilla, sus marcadores se rellenan con la visita, codificados para la parte del destino en la que están.\nSi tiene una regla para la plataforma del User-Agent (ios, android, desktop o bot), redirige a su destino.",
+                "produces": [
+                    "application/json",
+                    "text/html"
+                ],
+                "tags": [
+                    "redirection"
+                ],
+                "summary": "Redirigir a la URL original",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Código corto de la URL",
+                        "name": "shortCode",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Formulario de contraseña de una URL protegida"
+                    },
+                    "301": {
+                        "description": "Redirección a la URL original, con el código de la URL o el del servidor (301, 302, 307 o 308)"
+                    },
+                    "302": {
+                        "description": "Redirección a la URL alternativa de una URL no activa"
+                    },
+                    "404": {
+                        "description": "URL no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "410": {
+                        "description": "La URL ha agotado sus visitas, ha caducado o ha terminado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Página de una URL que aún no está activa, con Retry-After"
+                    }
+                }
+            },
+            "post": {
+                "description": "Comprueba la contraseña enviada desde el formulario de una URL protegida. Si es correcta guarda un pase temporal en una cookie y vuelve a la dirección visitada, con su ruta y su query string; si no, vuelve a mostrar el formulario.",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "redirection"
+                ],
+                "summary": "Desbloquear una URL protegida",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Código corto de la URL",
+                        "name": "shortCode",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña de la URL",
+                        "name": "password",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "303": {
+                        "description": "Redirección a la URL corta con el pase en la cookie link_access"
+                    },
+                    "403": {
+                        "description": "Contraseña incorrecta; se muestra de nuevo el formulario"
+                    },
+                    "404": {
+                        "description": "URL no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/{shortCode}/{rest}": {
+            "get": {
+                "description": "Redirige al usuario a la URL original correspondiente al código corto. Si la URL tiene contraseña y el navegador no tiene un pase vigente, muestra un formulario para introducirla.\nAntes de su activación, o tras su fin, redirige temporalmente a su URL alternativa; sin ella muestra una página de aviso antes de la activación.\nSi la URL lo tiene activado, la ruta que sigue al código y la query string de la visita se pasan al destino.\nSi es una plantilla, sus marcadores se rellenan con la visita, codificados para la parte del destino en la que están.\nSi tiene una regla para la plataforma del User-Agent (ios, android, desktop o bot), redirige a su destino.",
+                "produces": [
+                    "application/json",
+                    "text/html"
+                ],
+                "tags": [
+                    "redirection"
+                ],
+                "summary": "Redirigir a la URL original",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Código corto de la URL",
+                        "name": "shortCode",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ruta que sigue al código corto",
+                        "name": "rest",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Formulario de contraseña de una URL protegida"
+                    },
+                    "301": {
+                        "description": "Redirección a la URL original, con el código de la URL o el del servidor (301, 302, 307 o 308)"
+                    },
+                    "302": {
+                        "description": "Redirección a la URL alternativa de una URL no activa"
+                    },
+                    "404": {
+                        "description": "URL no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "410": {
+                        "description": "La URL ha agotado sus visitas, ha caducado o ha terminado",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Página de una URL que aún no está activa, con Retry-After"
+                    }
+                }
+            },
+            "post": {
+                "description": "Comprueba la contraseña enviada desde el formulario de una URL protegida. Si es correcta guarda un pase temporal en una cookie y vuelve a la dirección visitada, con su ruta y su query string; si no, vuelve a mostrar el formulario.",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "redirection"
+                ],
+                "summary": "Desbloquear una URL protegida",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Código corto de la URL",
+                        "name": "shortCode",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ruta que sigue al código corto",
+                        "name": "rest",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contraseña de la URL",
+                        "name": "password",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "303": {
+                        "description": "Redirección a la URL corta con el pase en la cookie link_access"
+                    },
+                    "403": {
+                        "description": "Contraseña incorrecta; se muestra de nuevo el formulario"
+                    },
+                    "404": {
+                        "description": "URL no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Error del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "user": {}
+            }
+        },
+        "handlers.BatchFilter": {
+            "type": "object",
+            "properties": {
+                "created_from": {
+                    "type": "string",
+                    "example": "2026-01-01"
+                },
+                "created_to": {
+                    "type": "string",
+                    "example": "2026-02-01"
+                },
+                "domain": {
+                    "type": "string",
+                    "example": "ejemplo.com"
+                },
+                "expiry": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "expired"
+                    ]
+                },
+                "folder_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "q": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "campaña"
+                },
+                "state": {
+                    "type": "string",
+                    "enum": [
+                        "scheduled",
+                        "active",
+                        "ended"
+                    ]
+                },
+                "tag": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "verano"
+                }
+            }
+        },
+        "handlers.BatchItemResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/handlers.BulkItemError"
+                },
+                "short_code": {
+                    "type": "string",
+                    "example": "abc123"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "applied",
+                        "failed",
+                        "skipped"
+                    ],
+                    "example": "applied"
+                }
+            }
+        },
+        "handlers.BatchURLsRequest": {
+            "type": "object",
+            "required": [
+                "action",
+                "short_codes",
+                "tags"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "delete",
+                        "set-expiry",
+                        "add-tags",
+                        "remove-tags",
+                        "change-owner"
+                    ]
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2026-12-31T23:59:59Z"
+                },
+                "filter": {
+                    "$ref": "#/definitions/handlers.BatchFilter"
+                },
+                "owner": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "otro_usuario"
+                },
+                "short_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "abc123",
+                        "def456"
+                    ]
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "campaña"
+                    ]
+                }
+            }
+        },
+        "handlers.BatchURLsResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "delete"
+                },
+                "applied": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "committed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.BatchItemResult"
+                    }
+                },
+                "skipped": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "handlers.BulkItemError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "invalid_url"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "URL inválida"
+                }
+            }
+        },
+        "handlers.BulkItemResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/handlers.BulkItemError"
+                },
+                "index": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "short_code": {
+                    "type": "string",
+                    "example": "abc123"
+                },
+                "short_url": {
+                    "type": "string",
+                    "example": "http://localhost:8080/abc123"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "created",
+                        "existing",
+                        "failed",
+                        "skipped"
+                    ],
+                    "example": "created"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://www.ejemplo.com/campaña"
+                }
+            }
+        },
+        "handlers.BulkShortenResponse": {
+            "type": "object",
+            "properties": {
+                "atomic": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "created": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "existing": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.BulkItemResult"
+                    }
+                },
+                "skipped": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "handlers.BulkURLItem": {
+            "type": "object",
+            "properties": {
+                "url": {
+                    "type": "string",
+                    "example": "https://www.ejemplo.com/campaña"
+                }
+            }
+        },
+        "handlers.DeviceRuleRequest": {
+            "type": "object",
+            "required": [
+                "destination",
+                "platform"
+            ],
+            "properties": {
+                "destination": {
+                    "type": "string",
+                    "example": "https://apps.apple.com/app/id123456789"
+                },
+                "platform": {
+                    "type": "string",
+                    "enum": [
+                        "ios",
+                        "android",
+                        "desktop",
+                        "bot"
+                    ],
+                    "example": "ios"
+                }
+            }
+        },
+        "handlers.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "url"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "El campo es obligatorio"
+                },
+                "rule": {
+                    "type": "string",
+                    "example": "required"
+                }
+            }
+        },
+        "handlers.FolderRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Campañas 2026"
+                }
+            }
+        },
+        "handlers.ListFoldersResponse": {
+            "type": "object",
+            "properties": {
+                "folders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Folder"
+                    }
+                }
+            }
+        },
+        "handlers.ListTagsResponse": {
+            "type": "object",
+            "properties": {
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Tag"
+                    }
+                }
+            }
+        },
+        "handlers.ListURLsResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJzIjoiLWNyZWF0ZWRfYXQifQ.c2lnbmF0dXJl"
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "prev_cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "urls": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.URL"
+                    }
+                }
+            }
+        },
+        "handlers.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "url_not_found"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.FieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/api/v1/urls/abc123"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "4f2a9c0e8b7d4d1a"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "URL no encontrada"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "/problems/url_not_found"
+                }
+            }
+        },
+        "handlers.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "usuario@ejemplo.com"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6,
+                    "example": "contraseña123"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "usuario123"
+                }
+            }
+        },
+        "handlers.ShortenURLRequest": {
+            "type": "object",
+            "required": [
+                "tags",
+                "url"
+            ],
+            "properties": {
+                "active_from": {
+                    "type": "string",
+                    "example": "2026-06-01T09:00:00Z"
+                },
+                "active_until": {
+                    "type": "string",
+                    "example": "2026-06-30T23:59:59Z"
+                },
+                "device_rules": {
+                    "type": "array",
+                    "maxItems": 4,
+                    "items": {
+                        "$ref": "#/definitions/handlers.DeviceRuleRequest"
+                    }
+                },
+                "fallback_url": {
+                    "type": "string",
+                    "example": "https://www.ejemplo.com/proximamente"
+                },
+                "folder_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "forward_path": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "forward_query": {
+                    "type": "string",
+                    "enum": [
+                        "keep",
+                        "override",
+                        "append"
+                    ],
+                    "example": "keep"
+                },
+                "max_visits": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 72,
+                    "example": "s3creto"
+                },
+                "redirect_code": {
+                    "type": "integer",
+                    "enum": [
+                        301,
+                        302,
+                        307,
+                        308
+                    ],
+                    "example": 302
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "campaña"
+                    ]
+                },
+                "template": {
+                    "description": "Template indica que url es una plantilla con marcadores ({path},\n{query.nombre|defecto}, {header.Nombre|defecto})",
+                    "type": "boolean",
+                    "example": false
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://www.ejemplo.com/pagina-con-url-muy-larga"
+                }
+            }
+        },
+        "handlers.TagRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "campaña"
+                }
+            }
+        },
+        "handlers.URLResponse": {
+            "type": "object",
+            "properties": {
+                "active_from": {
+                    "type": "string",
+                    "example": "2026-06-01T09:00:00Z"
+                },
+                "active_until": {
+                    "type": "string",
+                    "example": "2026-06-30T23:59:59Z"
+                },
+                "device_rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DeviceRule"
+                    }
+                },
+                "fallback_url": {
+                    "type": "string",
+                    "example": "https://www.ejemplo.com/proximamente"
+                },
+                "folder_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "forward_path": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "forward_query": {
+                    "type": "string",
+                    "example": "keep"
+                },
+                "max_visits": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "original_url": {
+                    "type": "string",
+                    "example": "https://www.ejemplo.com/pagina-con-url-muy-larga"
+                },
+                "protected": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "redirect_code": {
+                    "type": "integer",
+                    "example": 302
+                },
+                "short_code": {
+                    "type": "string",
+                    "example": "abc123"
+                },
+                "short_url": {
+                    "type": "string",
+                    "example": "http://localhost:8080/abc123"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Tag"
+                    }
+                },
+                "template": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "visits": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "handlers.UpdateProfileRequest": {
+            "type": "object",
+            "properties": {
+                "language": {
+                    "type": "string",
+                    "enum": [
+                        "es",
+                        "en"
+                    ],
+                    "example": "en"
+                }
+            }
+        },
+        "handlers.UpdateURLRequest": {
+            "type": "object",
+            "required": [
+                "tags"
+            ],
+            "properties": {
+                "device_rules": {
+                    "type": "array",
+                    "maxItems": 4,
+                    "items": {
+                        "$ref": "#/definitions/handlers.DeviceRuleRequest"
+                    }
+                },
+                "folder_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "forward_path": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "forward_query": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "keep",
+                        "override",
+                        "append"
+                    ],
+                    "example": "override"
+                },
+                "max_visits": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 10
+                },
+                "redirect_code": {
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        301,
+                        302,
+                        307,
+                        308
+                    ],
+                    "example": 307
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "campaña"
+                    ]
+                }
+            }
+        },
+        "handlers.UserCredentials": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "contraseña123"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "usuario123"
+                }
+            }
+        },
+        "model.DeviceRule": {
+            "type": "object",
+            "properties": {
+                "destination": {
+                    "type": "string"
+                },
+                "platform": {
+                    "$ref": "#/definitions/model.Platform"
+                }
+            }
+        },
+        "model.Folder": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Platform": {
+            "type": "string",
+            "enum": [
+                "ios",
+                "android",
+                "desktop",
+                "bot"
+            ],
+            "x-enum-varnames": [
+                "PlatformIOS",
+                "PlatformAndroid",
+                "PlatformDesktop",
+                "PlatformBot"
+            ]
+        },
+        "model.QueryForwarding": {
+            "type": "string",
+            "enum": [
+                "",
+                "keep",
+                "override",
+                "append"
+            ],
+            "x-enum-comments": {
+                "QueryAppend": "Se conservan los valores de ambos",
+                "QueryKeep": "Ante un conflicto gana el parámetro del destino",
+                "QueryNotForwarded": "La query string de la visita se descarta",
+                "QueryOverride": "Ante un conflicto gana el parámetro de la visita"
+            },
+            "x-enum-varnames": [
+                "QueryNotForwarded",
+                "QueryKeep",
+                "QueryOverride",
+                "QueryAppend"
+            ]
+        },
+        "model.Tag": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.URL": {
+            "type": "object",
+            "properties": {
+                "active_from": {
+                    "description": "Momento en que empieza a redirigir; nil si desde su creación",
+                    "type": "string"
+                },
+                "active_until": {
+                    "description": "Momento en que deja de redirigir; nil si no termina",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "Fecha en que pasó a la papelera",
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "device_rules": {
+                    "description": "Destinos por plataforma; sin regla se usa OriginalURL",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DeviceRule"
+                    }
+                },
+                "domain": {
+                    "description": "Dominio de destino, para filtrar",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "fallback_url": {
+                    "description": "Destino mientras no está activa; vacío muestra una página",
+                    "type": "string"
+                },
+                "folder_id": {
+                    "description": "Carpeta del propietario; nil si no está en ninguna",
+                    "type": "integer"
+                },
+                "forward_path": {
+                    "description": "Añade al destino la ruta que sigue al código",
+                    "type": "boolean"
+                },
+                "forward_query": {
+                    "description": "Cómo se pasa al destino la query string de la visita",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.QueryForwarding"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "max_visits": {
+                    "description": "Visitas permitidas; nil si no hay límite",
+                    "type": "integer"
+                },
+                "original_url": {
+                    "type": "string"
+                },
+                "redirect_code": {
+                    "description": "Código de la redirección; cero usa el del servidor",
+                    "type": "integer"
+                },
+                "short_code": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Tag"
+                    }
+                },
+                "template": {
+                    "description": "OriginalURL es una plantilla con marcadores",
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "Propietario; cero en las URLs anteriores a la autoría",
+                    "type": "integer"
+                },
+                "visits": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.User": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "language": {
+                    "description": "Idioma preferido; vacío sigue a Accept-Language",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "Token JWT con el formato \"Bearer \u003ctoken\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        },
+        "OPasswordAuth": {
+            "description": "JWT Token created by username and password",
+            "type": "oauth2",
+            "flow": "password",
+            "tokenUrl": "/api/v1/auth/login"
+        }
+    }
+}`
+
+// SwaggerInfov1 holds exported Swagger Info so clients can modify it
+var SwaggerInfov1 = &swag.Spec{
+	Version:          "1.0",
+	Host:             "",
+	BasePath:         "",
+	Schemes:          []string{},
+	Title:            "Tiny URL API",
+	Description:      "Acortador de URLs. Las rutas versionadas viven bajo /api/v1.",
+	InfoInstanceName: "v1",
+	SwaggerTemplate:  docTemplatev1,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
+}
+
+func init() {
+	swag.Register(SwaggerInfov1.InstanceName(), SwaggerInfov1)
+}
@@ -17,6 +17,7 @@ type AuthHandler struct {
 
 // NewAuthHandler crea una nueva instancia del manejador de autenticación
 func NewAuthHandler(authService ports.AuthService) *AuthHandler {
+	useJSONFieldNames()
 	return &AuthHandler{
 		authService: authService,
 	}
@@ -35,40 +36,17 @@ type RegisterRequest struct {
 	Password string `json:"password" binding:"required,min=6" example:"contraseña123"`
 }
 
+// UpdateProfileRequest representa la solicitud para cambiar las preferencias del usuario
+type UpdateProfileRequest struct {
+	Language string `json:"language" binding:"omitempty,oneof=es en" example:"en"`
+}
+
 // AuthResponse representa la respuesta de autenticación con token JWT
 type AuthResponse struct {
 	User  interface{} `json:"user"`
 	Token string      `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
 }
 
-// handleAuthError centraliza el manejo de errores comunes en los handlers de autenticación
-func (h *AuthHandler) handleAuthError(c *gin.Context, err error) bool {
-	if err == nil {
-		return false
-	}
-
-	// Errores específicos de autenticación
-	if errors.Is(err, errors.ErrUserAlreadyExists) {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "El usuario o email ya existe",
-		})
-		return true
-	}
-
-	if errors.Is(err, errors.ErrInvalidCredentials) || errors.Is(err, errors.ErrUserNotFound) {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Credenciales inválidas",
-		})
-		return true
-	}
-
-	// Error genérico del servidor
-	c.JSON(http.StatusInternalServerError, gin.H{
-		"error": "Error del servidor",
-	})
-	return true
-}
-
 // createAuthResponse genera una respuesta de autenticación estandarizada
 func (h *AuthHandler) createAuthResponse(c *gin.Context, user *model.User, token string, statusCode int) {
 	// Ocultar la contraseña en la respuesta
@@ -88,20 +66,18 @@ func (h *AuthHandler) createAuthResponse(c *gin.Context, user *model.User, token
 // @Produce json
 // @Param request body RegisterRequest true "Datos de registro del usuario"
 // @Success 201 {object} AuthResponse "Usuario creado correctamente"
-// @Failure 400 {object} map[string]string "Error en la solicitud"
-// @Failure 500 {object} map[string]string "Error del servidor"
-// @Router /auth/register [post]
+// @Failure 400 {object} Problem "Error en la solicitud"
+// @Failure 500 {object} Problem "Error del servidor"
+// @Router /api/v1/auth/register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
 	var request RegisterRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Datos de registro inválidos",
-		})
+		WriteBindingError(c, err)
 		return
 	}
 
 	user, token, err := h.authService.Register(c.Request.Context(), request.Username, request.Email, request.Password)
-	if h.handleAuthError(c, err) {
+	if handleError(c, err) {
 		return
 	}
 
@@ -117,32 +93,30 @@ func (h *AuthHandler) Register(c *gin.Context) {
 // @Security Bearer
 // @Param request body UserCredentials true "Credenciales de usuario"
 // @Success 200 {object} AuthResponse "Inicio de sesión exitoso"
-// @Failure 400 {object} map[string]string "Credenciales inválidas"
-// @Failure 401 {object} map[string]string "No autorizado"
-// @Failure 500 {object} map[string]string "Error del servidor"
-// @Router /auth/login [post]
+// @Failure 400 {object} Problem "Credenciales inválidas"
+// @Failure 401 {object} Problem "No autorizado"
+// @Failure 500 {object} Problem "Error del servidor"
+// @Router /api/v1/auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var creds UserCredentials
 	if err := c.ShouldBindJSON(&creds); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Credenciales inválidas",
-		})
+		WriteBindingError(c, err)
 		return
 	}
 
-	token, err := h.authService.Login(creds.Username, creds.Password)
-	if h.handleAuthError(c, err) {
+	token, err := h.authService.Login(c.Request.Context(), creds.Username, creds.Password)
+	if handleError(c, err) {
 		return
 	}
 
 	// Obtener los datos del usuario a partir del token
 	userID, err := h.authService.ValidateToken(token)
-	if h.handleAuthError(c, err) {
+	if handleError(c, err) {
 		return
 	}
 
 	user, err := h.authService.GetUser(c.Request.Context(), userID)
-	if h.handleAuthError(c, err) {
+	if handleError(c, err) {
 		return
 	}
 
@@ -157,22 +131,60 @@ func (h *AuthHandler) Login(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Success 200 {object} model.User "Perfil del usuario"
-// @Failure 401 {object} map[string]string "No autenticado"
-// @Failure 500 {object} map[string]string "Error del servidor"
-// @Router /api/profile [get]
+// @Failure 401 {object} Problem "No autenticado"
+// @Failure 500 {object} Problem "Error del servidor"
+// @Router /api/v1/profile [get]
 func (h *AuthHandler) GetUserProfile(c *gin.Context) {
 	// Obtener el ID del usuario del contexto (colocado por el middleware de autenticación)
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "No autenticado",
-		})
+		WriteError(c, errors.ErrUnauthorized)
 		return
 	}
 
 	// Obtener información del usuario
 	user, err := h.authService.GetUser(c.Request.Context(), userID.(uint))
-	if h.handleAuthError(c, err) {
+	if handleError(c, err) {
+		return
+	}
+
+	// Ocultar la contraseña en la respuesta
+	user.Password = ""
+
+	c.JSON(http.StatusOK, user)
+}
+
+// UpdateProfile godoc
+// @Summary Actualizar perfil de usuario
+// @Description Cambia el idioma preferido del usuario autenticado. Un idioma vacío vuelve a usar Accept-Language
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Clave para reintentar la petición sin repetir sus efectos"
+// @Param request body UpdateProfileRequest true "Preferencias del usuario"
+// @Success 200 {object} model.User "Perfil actualizado"
+// @Failure 400 {object} Problem "Datos inválidos"
+// @Failure 401 {object} Problem "No autenticado"
+// @Failure 409 {object} Problem "Petición con la misma clave en curso"
+// @Failure 422 {object} Problem "Clave de idempotencia reutilizada con otra petición"
+// @Failure 500 {object} Problem "Error del servidor"
+// @Router /api/v1/profile [patch]
+func (h *AuthHandler) UpdateProfile(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		WriteError(c, errors.ErrUnauthorized)
+		return
+	}
+
+	var request UpdateProfileRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		WriteBindingError(c, err)
+		return
+	}
+
+	user, err := h.authService.SetLanguage(c.Request.Context(), userID.(uint), request.Language)
+	if handleError(c, err) {
 		return
 	}
 
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+)
+
+// cursorToken es el contenido firmado de un cursor de paginación. Incluye la
+// huella del filtro para que el cursor solo sirva para el mismo listado.
+type cursorToken struct {
+	Sort      string `json:"s"`
+	Filter    string `json:"f"`
+	CreatedAt int64  `json:"t"`
+	Visits    int    `json:"v"`
+	ID        uint   `json:"i"`
+	Before    bool   `json:"b,omitempty"`
+}
+
+// cursorCodec convierte los cursores del dominio en cadenas opacas firmadas
+// con HMAC-SHA256, de modo que los clientes no puedan fabricarlos
+type cursorCodec struct {
+	secret []byte
+}
+
+// newCursorCodec crea un codificador con la clave indicada o, si está vacía,
+// con una clave aleatoria válida mientras viva el proceso
+func newCursorCodec(secret []byte) cursorCodec {
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			panic("cursor secret: " + err.Error())
+		}
+	}
+	return cursorCodec{secret: secret}
+}
+
+// encode devuelve el cursor firmado para el listado con la huella filter
+func (c cursorCodec) encode(cursor *model.URLCursor, filter string) string {
+	payload, _ := json.Marshal(cursorToken{
+		Sort:      string(cursor.Sort),
+		Filter:    filter,
+		CreatedAt: cursor.CreatedAt.UnixNano(),
+		Visits:    cursor.Visits,
+		ID:        cursor.ID,
+		Before:    cursor.Before,
+	})
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(c.sign(encoded))
+}
+
+// decode verifica la firma del cursor y que pertenece al listado con la
+// huella filter; en otro caso devuelve errors.ErrInvalidCursor
+func (c cursorCodec) decode(value, filter string) (*model.URLCursor, error) {
+	encoded, signature, ok := strings.Cut(value, ".")
+	if !ok {
+		return nil, errors.ErrInvalidCursor
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, c.sign(encoded)) {
+		return nil, errors.ErrInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.ErrInvalidCursor
+	}
+	var token cursorToken
+	if err := json.Unmarshal(payload, &token); err != nil || token.Filter != filter {
+		return nil, errors.ErrInvalidCursor
+	}
+
+	return &model.URLCursor{
+		Sort:      model.URLSort(token.Sort),
+		CreatedAt: time.Unix(0, token.CreatedAt),
+		Visits:    token.Visits,
+		ID:        token.ID,
+		Before:    token.Before,
+	}, nil
+}
+
+// sign calcula la firma de la parte codificada del cursor
+func (c cursorCodec) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
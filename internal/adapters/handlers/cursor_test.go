@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+)
+
+func TestCursorCodec_RoundTrip(t *testing.T) {
+	// Arrange
+	codec := newCursorCodec([]byte("secreto"))
+	cursor := &model.URLCursor{
+		Sort:      model.SortVisitsDesc,
+		CreatedAt: time.Date(2026, time.October, 18, 10, 30, 0, 123456789, time.UTC),
+		Visits:    42,
+		ID:        7,
+		Before:    true,
+	}
+
+	// Act
+	decoded, err := codec.decode(codec.encode(cursor, "filtro"), "filtro")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, cursor.Sort, decoded.Sort)
+	assert.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+	assert.Equal(t, cursor.Visits, decoded.Visits)
+	assert.Equal(t, cursor.ID, decoded.ID)
+	assert.True(t, decoded.Before)
+}
+
+func TestCursorCodec_RejectsInvalidCursors(t *testing.T) {
+	// Arrange
+	codec := newCursorCodec([]byte("secreto"))
+	valid := codec.encode(&model.URLCursor{Sort: model.SortCreatedAtDesc, ID: 7}, "filtro")
+	payload, signature, _ := strings.Cut(valid, ".")
+	forged := newCursorCodec([]byte("otro")).encode(&model.URLCursor{Sort: model.SortCreatedAtDesc, ID: 1}, "filtro")
+
+	tests := map[string]struct {
+		cursor string
+		filter string
+	}{
+		"otro filtro":         {valid, "otro-filtro"},
+		"firma de otra clave": {forged, "filtro"},
+		"contenido alterado":  {payload + "x." + signature, "filtro"},
+		"sin firma":           {payload, "filtro"},
+		"basura":              {"no-es-un-cursor", "filtro"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			cursor, err := codec.decode(tt.cursor, tt.filter)
+
+			// Assert
+			assert.Nil(t, cursor)
+			assert.Equal(t, errors.ErrInvalidCursor, err)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports"
+	"tiny-url/internal/i18n"
+)
+
+// FolderHandler maneja las peticiones HTTP de las carpetas del usuario
+type FolderHandler struct {
+	folderService ports.FolderService
+}
+
+// NewFolderHandler crea una nueva instancia del manejador de carpetas
+func NewFolderHandler(folderService ports.FolderService) *FolderHandler {
+	useJSONFieldNames()
+	return &FolderHandler{
+		folderService: folderService,
+	}
+}
+
+// FolderRequest representa el nombre de una carpeta nueva o renombrada
+type FolderRequest struct {
+	Name string `json:"name" binding:"required,max=100" example:"Campañas 2026"`
+}
+
+// ListFoldersResponse representa las carpetas del usuario
+type ListFoldersResponse struct {
+	Folders []model.Folder `json:"folders"`
+}
+
+// ListFolders godoc
+// @Summary Listar carpetas
+// @Description Devuelve las carpetas del usuario ordenadas por nombre
+// @Tags folders
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} ListFoldersResponse "Carpetas del usuario"
+// @Failure 401 {object} Problem "No autorizado"
+// @Failure 500 {object} Problem "Error del servidor"
+// @Router /api/v1/folders [get]
+func (h *FolderHandler) ListFolders(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	folders, err := h.folderService.ListFolders(c.Request.Context(), userID)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, ListFoldersResponse{Folders: folders})
+}
+
+// CreateFolder godoc
+// @Summary Crear una carpeta
+// @Description Crea una carpeta del usuario en la que guardar sus URLs
+// @Tags folders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Clave para reintentar la petición sin repetir sus efectos"
+// @Param request body FolderRequest true "Nombre de la carpeta"
+// @Success 201 {object} model.Folder "Carpeta creada"
+// @Failure 400 {object} Problem "Nombre inválido"
+// @Failure 401 {object} Problem "No autorizado"
+// @Failure 409 {object} Problem "Ya existe una carpeta con ese nombre o petición con la misma clave en curso"
+// @Failure 422 {object} Problem "Clave de idempotencia reutilizada con otra petición"
+// @Failure 500 {object} Problem "Error del servidor"
+// @Router /api/v1/folders [post]
+func (h *FolderHandler) CreateFolder(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var request FolderRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		WriteBindingError(c, err)
+		return
+	}
+
+	folder, err := h.folderService.CreateFolder(c.Request.Context(), userID, request.Name)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusCreated, folder)
+}
+
+// RenameFolder godoc
+// @Summary Renombrar una carpeta
+// @Description Cambia el nombre de una carpeta del usuario
+// @Tags folders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Clave para reintentar la petición sin repetir sus efectos"
+// @Param id path int true "ID de la carpeta"
+// @Param request body FolderRequest true "Nuevo nombre de la carpeta"
+// @Success 200 {object} model.Folder "Carpeta renombrada"
+// @Failure 400 {object} Problem "Nombre inválido"
+// @Failure 401 {object} Problem "No autorizado"
+// @Failure 404 {object} Problem "Carpeta no encontrada"
+// @Failure 409 {object} Problem "Ya existe una carpeta con ese nombre o petición con la misma clave en curso"
+// @Failure 422 {object} Problem "Clave de idempotencia reutilizada con otra petición"
+// @Failure 500 {object} Problem "Error del servidor"
+// @Router /api/v1/folders/{id} [patch]
+func (h *FolderHandler) RenameFolder(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := pathID(c, errors.ErrFolderNotFound)
+	if !ok {
+		return
+	}
+
+	var request FolderRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		WriteBindingError(c, err)
+		return
+	}
+
+	folder, err := h.folderService.RenameFolder(c.Request.Context(), userID, id, request.Name)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, folder)
+}
+
+// DeleteFolder godoc
+// @Summary Eliminar una carpeta
+// @Description Elimina una carpeta del usuario. Sus URLs se conservan fuera de cualquier carpeta
+// @Tags folders
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Clave para reintentar la petición sin repetir sus efectos"
+// @Param id path int true "ID de la carpeta"
+// @Success 200 {object} map[string]string "Carpeta eliminada correctamente"
+// @Failure 401 {object} Problem "No autorizado"
+// @Failure 404 {object} Problem "Carpeta no encontrada"
+// @Failure 409 {object} Problem "Petición con la misma clave en curso"
+// @Failure 422 {object} Problem "Clave de idempotencia reutilizada con otra petición"
+// @Failure 500 {object} Problem "Error del servidor"
+// @Router /api/v1/folders/{id} [delete]
+func (h *FolderHandler) DeleteFolder(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := pathID(c, errors.ErrFolderNotFound)
+	if !ok {
+		return
+	}
+
+	err := h.folderService.DeleteFolder(c.Request.Context(), userID, id)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": i18n.T(Language(c), "folder_deleted"),
+	})
+}
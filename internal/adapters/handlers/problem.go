@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/i18n"
+)
+
+// ProblemContentType es el tipo de contenido de las respuestas de error (RFC 7807)
+const ProblemContentType = "application/problem+json"
+
+// RequestIDKey es la clave del contexto de gin donde se guarda el ID de la petición
+const RequestIDKey = "requestID"
+
+// LanguageKey es la clave del contexto de gin donde se guarda el idioma
+// preferido del usuario autenticado
+const LanguageKey = "language"
+
+// languageResolverKey es la clave del contexto de gin donde se guarda cómo
+// buscar el idioma preferido del usuario autenticado
+const languageResolverKey = "languageResolver"
+
+// Códigos de error estables que los clientes pueden usar para decidir qué hacer
+const (
+	CodeValidationFailed   = "validation_failed"
+	CodeMalformedBody      = "malformed_body"
+	CodeInvalidURL         = "invalid_url"
+	CodeURLNotFound        = "url_not_found"
+	CodeInvalidCursor      = "invalid_cursor"
+	CodeInvalidBatch       = "invalid_batch"
+	CodeBatchTooLarge      = "batch_too_large"
+	CodeURLExhausted       = "url_exhausted"
+	CodeInvalidLimit       = "invalid_limit"
+	CodeInvalidRedirect    = "invalid_redirect"
+	CodeInvalidForwarding  = "invalid_forwarding"
+	CodeInvalidTemplate    = "invalid_template"
+	CodeInvalidDeviceRule  = "invalid_device_rule"
+	CodeURLNotYetActive    = "url_not_yet_active"
+	CodeURLEnded           = "url_ended"
+	CodeInvalidSchedule    = "invalid_schedule"
+	CodeInvalidPassword    = "invalid_password"
+	CodePasswordRequired   = "password_required"
+	CodeWrongPassword      = "wrong_password"
+	CodeTagNotFound        = "tag_not_found"
+	CodeFolderNotFound     = "folder_not_found"
+	CodeInvalidTag         = "invalid_tag"
+	CodeInvalidFolder      = "invalid_folder"
+	CodeConflict           = "conflict"
+	CodeInvalidCredentials = "invalid_credentials"
+	CodeUserNotFound       = "user_not_found"
+	CodeUserAlreadyExists  = "user_already_exists"
+	CodeInvalidToken       = "invalid_token"
+	CodeExpiredToken       = "expired_token"
+	CodeUnauthorized       = "unauthorized"
+	CodeForbidden          = "forbidden"
+	CodeInternalError      = "internal_error"
+	CodeRequestTooLarge    = "request_too_large"
+
+	CodeInvalidIdempotencyKey    = "invalid_idempotency_key"
+	CodeIdempotencyKeyReused     = "idempotency_key_reused"
+	CodeIdempotencyKeyInProgress = "idempotency_key_in_progress"
+)
+
+// Problem representa una respuesta de error con formato RFC 7807
+type Problem struct {
+	Type      string       `json:"type" example:"/problems/url_not_found"`
+	Title     string       `json:"title" example:"URL no encontrada"`
+	Status    int          `json:"status" example:"404"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty" example:"/api/v1/urls/abc123"`
+	Code      string       `json:"code" example:"url_not_found"`
+	RequestID string       `json:"request_id,omitempty" example:"4f2a9c0e8b7d4d1a"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError describe el fallo de validación de un campo de la petición
+type FieldError struct {
+	Field   string `json:"field" example:"url"`
+	Rule    string `json:"rule" example:"required"`
+	Message string `json:"message" example:"El campo es obligatorio"`
+}
+
+// problemSpec asocia un error de dominio con su estado HTTP y su código
+type problemSpec struct {
+	target error
+	status int
+	code   string
+}
+
+// problemSpecs es la tabla única que traduce los errores de dominio a respuestas.
+// Se recorre en orden, así que los errores más específicos van primero. El
+// título de cada problema sale del catálogo de mensajes a partir del código.
+var problemSpecs = []problemSpec{
+	{errors.ErrInvalidURL, http.StatusBadRequest, CodeInvalidURL},
+	{errors.ErrURLNotFound, http.StatusNotFound, CodeURLNotFound},
+	{errors.ErrInvalidCursor, http.StatusBadRequest, CodeInvalidCursor},
+	{errors.ErrInvalidBatch, http.StatusBadRequest, CodeInvalidBatch},
+	{errors.ErrBatchTooLarge, http.StatusUnprocessableEntity, CodeBatchTooLarge},
+	{errors.ErrURLExhausted, http.StatusGone, CodeURLExhausted},
+	{errors.ErrInvalidLimit, http.StatusBadRequest, CodeInvalidLimit},
+	{errors.ErrInvalidRedirect, http.StatusBadRequest, CodeInvalidRedirect},
+	{errors.ErrInvalidForwarding, http.StatusBadRequest, CodeInvalidForwarding},
+	{errors.ErrInvalidTemplate, http.StatusBadRequest, CodeInvalidTemplate},
+	{errors.ErrInvalidDeviceRule, http.StatusBadRequest, CodeInvalidDeviceRule},
+	{errors.ErrURLNotYetActive, http.StatusServiceUnavailable, CodeURLNotYetActive},
+	{errors.ErrURLEnded, http.StatusGone, CodeURLEnded},
+	{errors.ErrInvalidSchedule, http.StatusBadRequest, CodeInvalidSchedule},
+	{errors.ErrInvalidPassword, http.StatusBadRequest, CodeInvalidPassword},
+	{errors.ErrPasswordRequired, http.StatusUnauthorized, CodePasswordRequired},
+	{errors.ErrWrongPassword, http.StatusForbidden, CodeWrongPassword},
+	{errors.ErrTagNotFound, http.StatusNotFound, CodeTagNotFound},
+	{errors.ErrFolderNotFound, http.StatusNotFound, CodeFolderNotFound},
+	{errors.ErrInvalidTag, http.StatusBadRequest, CodeInvalidTag},
+	{errors.ErrInvalidFolder, http.StatusBadRequest, CodeInvalidFolder},
+	{errors.ErrDuplicateKey, http.StatusConflict, CodeConflict},
+	{errors.ErrInvalidCredentials, http.StatusUnauthorized, CodeInvalidCredentials},
+	{errors.ErrUserNotFound, http.StatusNotFound, CodeUserNotFound},
+	{errors.ErrUserAlreadyExists, http.StatusConflict, CodeUserAlreadyExists},
+	{errors.ErrInvalidToken, http.StatusUnauthorized, CodeInvalidToken},
+	{errors.ErrExpiredToken, http.StatusUnauthorized, CodeExpiredToken},
+	{errors.ErrUnauthorized, http.StatusUnauthorized, CodeUnauthorized},
+	{errors.ErrForbidden, http.StatusForbidden, CodeForbidden},
+	{errors.ErrRequestTooLarge, http.StatusRequestEntityTooLarge, CodeRequestTooLarge},
+	{errors.ErrInvalidIdempotencyKey, http.StatusBadRequest, CodeInvalidIdempotencyKey},
+	{errors.ErrIdempotencyKeyReused, http.StatusUnprocessableEntity, CodeIdempotencyKeyReused},
+	{errors.ErrIdempotencyKeyInProgress, http.StatusConflict, CodeIdempotencyKeyInProgress},
+}
+
+// internalProblem se usa para cualquier error no previsto en problemSpecs
+var internalProblem = problemSpec{nil, http.StatusInternalServerError, CodeInternalError}
+
+// NewProblem construye un problema con el código indicado. El título se
+// traduce al idioma de la petición al escribirlo.
+func NewProblem(status int, code string) Problem {
+	return Problem{
+		Type:   "/problems/" + code,
+		Status: status,
+		Code:   code,
+	}
+}
+
+// WriteProblem escribe el problema como application/problem+json y aborta la
+// cadena de manejadores. Completa el título, el ID de la petición y la instancia.
+func WriteProblem(c *gin.Context, problem Problem) {
+	lang := Language(c)
+	if problem.Title == "" {
+		problem.Title = i18n.T(lang, problem.Code)
+	}
+	if problem.RequestID == "" {
+		problem.RequestID = c.GetString(RequestIDKey)
+	}
+	if problem.Instance == "" && c.Request != nil {
+		problem.Instance = c.Request.URL.Path
+	}
+
+	body, err := json.Marshal(problem)
+	if err != nil {
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	c.Header("Content-Language", lang)
+	c.Data(problem.Status, ProblemContentType, body)
+	c.Abort()
+}
+
+// WriteError traduce un error de dominio a su problema y lo escribe. Los
+// errores no previstos se registran con el ID de la petición y se ocultan al
+// cliente.
+func WriteError(c *gin.Context, err error) {
+	spec := problemFor(c, err)
+	WriteProblem(c, NewProblem(spec.status, spec.code))
+}
+
+// problemFor busca el problema que corresponde a un error de dominio. Los
+// errores no previstos se registran con el ID de la petición.
+func problemFor(c *gin.Context, err error) problemSpec {
+	for _, candidate := range problemSpecs {
+		if errors.Is(err, candidate.target) {
+			return candidate
+		}
+	}
+	log.Printf("request %s: %v", c.GetString(RequestIDKey), err)
+	return internalProblem
+}
+
+// WriteBindingError escribe el error producido al leer el cuerpo de la
+// petición, con el detalle de cada campo inválido
+func WriteBindingError(c *gin.Context, err error) {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		problem := NewProblem(http.StatusBadRequest, CodeMalformedBody)
+		if err != io.EOF {
+			problem.Detail = err.Error()
+		}
+		WriteProblem(c, problem)
+		return
+	}
+
+	lang := Language(c)
+	problem := NewProblem(http.StatusBadRequest, CodeValidationFailed)
+	for _, fieldErr := range validationErrors {
+		problem.Errors = append(problem.Errors, FieldError{
+			Field:   fieldErr.Field(),
+			Rule:    fieldErr.Tag(),
+			Message: validationMessage(lang, fieldErr.Tag()),
+		})
+	}
+	WriteProblem(c, problem)
+}
+
+// validationMessage traduce una regla de validación, con un mensaje genérico
+// para las reglas que no están en el catálogo
+func validationMessage(lang, rule string) string {
+	key := "validation." + rule
+	if message := i18n.T(lang, key); message != key {
+		return message
+	}
+	return i18n.T(lang, "validation.default")
+}
+
+// SetLanguageResolver indica cómo buscar el idioma preferido del usuario
+// autenticado. Language solo lo busca la primera vez que hace falta un texto
+// traducido, así que las respuestas sin mensajes no lo consultan.
+func SetLanguageResolver(c *gin.Context, resolve func() string) {
+	c.Set(languageResolverKey, resolve)
+}
+
+// Language devuelve el idioma de la respuesta: la preferencia del usuario
+// autenticado si la tiene y, si no, la negociada con Accept-Language
+func Language(c *gin.Context) string {
+	if resolve, ok := c.Get(languageResolverKey); ok && resolve != nil {
+		c.Set(languageResolverKey, nil)
+		c.Set(LanguageKey, resolve.(func() string)())
+	}
+	if lang := c.GetString(LanguageKey); i18n.Supported(lang) {
+		return lang
+	}
+	return i18n.Negotiate(c.GetHeader("Accept-Language"))
+}
+
+// handleError escribe el error, si lo hay, e indica si la petición ha terminado
+func handleError(c *gin.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+	WriteError(c, err)
+	return true
+}
+
+var jsonFieldNamesOnce sync.Once
+
+// useJSONFieldNames hace que los errores de validación usen el nombre del
+// campo en JSON, o el del parámetro de la query, en lugar del nombre del campo en Go
+func useJSONFieldNames() {
+	jsonFieldNamesOnce.Do(func() {
+		validate, ok := binding.Validator.Engine().(*validator.Validate)
+		if !ok {
+			return
+		}
+		validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+			for _, tag := range []string{"json", "form"} {
+				name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+				if name != "" && name != "-" {
+					return name
+				}
+			}
+			return field.Name
+		})
+	})
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"tiny-url/internal/domain/errors"
+)
+
+// serve ejecuta handler sobre una petición y decodifica el problema devuelto
+func serve(t *testing.T, handler gin.HandlerFunc, body string, acceptLanguage string) (*httptest.ResponseRecorder, Problem) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/test", func(c *gin.Context) {
+		c.Set(RequestIDKey, "req-123")
+		handler(c)
+	})
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/test", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", acceptLanguage)
+	r.ServeHTTP(rr, req)
+
+	var problem Problem
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+	return rr, problem
+}
+
+func TestWriteError_MapsDomainErrors(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{errors.ErrURLNotFound, http.StatusNotFound, CodeURLNotFound},
+		{errors.ErrInvalidURL, http.StatusBadRequest, CodeInvalidURL},
+		{errors.ErrUserAlreadyExists, http.StatusConflict, CodeUserAlreadyExists},
+		{errors.ErrInvalidCredentials, http.StatusUnauthorized, CodeInvalidCredentials},
+		{fmt.Errorf("envuelto: %w", errors.ErrExpiredToken), http.StatusUnauthorized, CodeExpiredToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			// Act
+			rr, problem := serve(t, func(c *gin.Context) { WriteError(c, tt.err) }, "", "")
+
+			// Assert
+			assert.Equal(t, tt.status, rr.Code)
+			assert.Equal(t, ProblemContentType, rr.Header().Get("Content-Type"))
+			assert.Equal(t, tt.code, problem.Code)
+			assert.Equal(t, tt.status, problem.Status)
+			assert.Equal(t, "/problems/"+tt.code, problem.Type)
+			assert.Equal(t, "req-123", problem.RequestID)
+			assert.Equal(t, "/test", problem.Instance)
+		})
+	}
+}
+
+func TestWriteError_HidesUnexpectedErrors(t *testing.T) {
+	// Act
+	rr, problem := serve(t, func(c *gin.Context) { WriteError(c, errors.New("password=secreta")) }, "", "")
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, CodeInternalError, problem.Code)
+	assert.NotContains(t, rr.Body.String(), "secreta")
+}
+
+func TestWriteBindingError_FieldDetails(t *testing.T) {
+	// Arrange
+	useJSONFieldNames()
+	handler := func(c *gin.Context) {
+		var request RegisterRequest
+		WriteBindingError(c, c.ShouldBindJSON(&request))
+	}
+
+	// Act
+	rr, problem := serve(t, handler, `{"username":"usuario","email":"no-es-email","password":"123"}`, "")
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, CodeValidationFailed, problem.Code)
+	require.Len(t, problem.Errors, 2)
+	assert.Equal(t, FieldError{Field: "email", Rule: "email", Message: "Debe ser un email válido"}, problem.Errors[0])
+	assert.Equal(t, "password", problem.Errors[1].Field)
+	assert.Equal(t, "min", problem.Errors[1].Rule)
+}
+
+func TestWriteBindingError_MalformedBody(t *testing.T) {
+	// Arrange
+	handler := func(c *gin.Context) {
+		var request RegisterRequest
+		WriteBindingError(c, c.ShouldBindJSON(&request))
+	}
+
+	// Act
+	rr, problem := serve(t, handler, `{"username":`, "")
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, CodeMalformedBody, problem.Code)
+	assert.Empty(t, problem.Errors)
+}
+
+func TestWriteBindingError_TranslatesToAcceptLanguage(t *testing.T) {
+	// Arrange
+	useJSONFieldNames()
+	handler := func(c *gin.Context) {
+		var request RegisterRequest
+		WriteBindingError(c, c.ShouldBindJSON(&request))
+	}
+
+	// Act
+	rr, problem := serve(t, handler, `{"username":"usuario","email":"no-es-email","password":"secreto123"}`, "en-US,en;q=0.9")
+
+	// Assert
+	assert.Equal(t, "en", rr.Header().Get("Content-Language"))
+	assert.Equal(t, "Invalid request data", problem.Title)
+	require.Len(t, problem.Errors, 1)
+	assert.Equal(t, "Must be a valid email address", problem.Errors[0].Message)
+}
+
+func TestWriteError_UserPreferenceOverridesAcceptLanguage(t *testing.T) {
+	// Act
+	_, problem := serve(t, func(c *gin.Context) {
+		c.Set(LanguageKey, "en")
+		WriteError(c, errors.ErrURLNotFound)
+	}, "", "es")
+
+	// Assert
+	assert.Equal(t, "URL not found", problem.Title)
+}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports"
+	"tiny-url/internal/i18n"
+)
+
+// TagHandler maneja las peticiones HTTP de las etiquetas del usuario
+type TagHandler struct {
+	tagService ports.TagService
+}
+
+// NewTagHandler crea una nueva instancia del manejador de etiquetas
+func NewTagHandler(tagService ports.TagService) *TagHandler {
+	useJSONFieldNames()
+	return &TagHandler{
+		tagService: tagService,
+	}
+}
+
+// TagRequest representa el nombre de una etiqueta nueva o renombrada
+type TagRequest struct {
+	Name string `json:"name" binding:"required,max=50" example:"campaña"`
+}
+
+// ListTagsResponse representa las etiquetas del usuario
+type ListTagsResponse struct {
+	Tags []model.Tag `json:"tags"`
+}
+
+// pathID lee el ID numérico de la ruta. Si no lo es escribe notFound, ya que
+// ningún recurso puede tener ese ID, y devuelve false.
+func pathID(c *gin.Context, notFound error) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 0)
+	if err != nil || id == 0 {
+		WriteError(c, notFound)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// ListTags godoc
+// @Summary Listar etiquetas
+// @Description Devuelve las etiquetas del usuario ordenadas por nombre
+// @Tags tags
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} ListTagsResponse "Etiquetas del usuario"
+// @Failure 401 {object} Problem "No autorizado"
+// @Failure 500 {object} Problem "Error del servidor"
+// @Router /api/v1/tags [get]
+func (h *TagHandler) ListTags(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	tags, err := h.tagService.ListTags(c.Request.Context(), userID)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, ListTagsResponse{Tags: tags})
+}
+
+// CreateTag godoc
+// @Summary Crear una etiqueta
+// @Description Crea una etiqueta del usuario. Las etiquetas también se crean al asignarlas a una URL.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Clave para reintentar la petición sin repetir sus efectos"
+// @Param request body TagRequest true "Nombre de la etiqueta"
+// @Success 201 {object} model.Tag "Etiqueta creada"
+// @Failure 400 {object} Problem "Nombre inválido"
+// @Failure 401 {object} Problem "No autorizado"
+// @Failure 409 {object} Problem "Ya existe una etiqueta con ese nombre o petición con la misma clave en curso"
+// @Failure 422 {object} Problem "Clave de idempotencia reutilizada con otra petición"
+// @Failure 500 {object} Problem "Error del servidor"
+// @Router /api/v1/tags [post]
+func (h *TagHandler) CreateTag(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var request TagRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		WriteBindingError(c, err)
+		return
+	}
+
+	tag, err := h.tagService.CreateTag(c.Request.Context(), userID, request.Name)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusCreated, tag)
+}
+
+// RenameTag godoc
+// @Summary Renombrar una etiqueta
+// @Description Cambia el nombre de una etiqueta del usuario en todas sus URLs
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Clave para reintentar la petición sin repetir sus efectos"
+// @Param id path int true "ID de la etiqueta"
+// @Param request body TagRequest true "Nuevo nombre de la etiqueta"
+// @Success 200 {object} model.Tag "Etiqueta renombrada"
+// @Failure 400 {object} Problem "Nombre inválido"
+// @Failure 401 {object} Problem "No autorizado"
+// @Failure 404 {object} Problem "Etiqueta no encontrada"
+// @Failure 409 {object} Problem "Ya existe una etiqueta con ese nombre o petición con la misma clave en curso"
+// @Failure 422 {object} Problem "Clave de idempotencia reutilizada con otra petición"
+// @Failure 500 {object} Problem "Error del servidor"
+// @Router /api/v1/tags/{id} [patch]
+func (h *TagHandler) RenameTag(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := pathID(c, errors.ErrTagNotFound)
+	if !ok {
+		return
+	}
+
+	var request TagRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		WriteBindingError(c, err)
+		return
+	}
+
+	tag, err := h.tagService.RenameTag(c.Request.Context(), userID, id, request.Name)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
+
+// DeleteTag godoc
+// @Summary Eliminar una etiqueta
+// @Description Elimina una etiqueta del usuario y la quita de todas sus URLs
+// @Tags tags
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Clave para reintentar la petición sin repetir sus efectos"
+// @Param id path int true "ID de la etiqueta"
+// @Success 200 {object} map[string]string "Etiqueta eliminada correctamente"
+// @Failure 401 {object} Problem "No autorizado"
+// @Failure 404 {object} Problem "Etiqueta no encontrada"
+// @Failure 409 {object} Problem "Petición con la misma clave en curso"
+// @Failure 422 {object} Problem "Clave de idempotencia reutilizada con otra petición"
+// @Failure 500 {object} Problem "Error del servidor"
+// @Router /api/v1/tags/{id} [delete]
+func (h *TagHandler) DeleteTag(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := pathID(c, errors.ErrTagNotFound)
+	if !ok {
+		return
+	}
+
+	err := h.tagService.DeleteTag(c.Request.Context(), userID, id)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": i18n.T(Language(c), "tag_deleted"),
+	})
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/i18n"
+)
+
+// BatchFilter elige las URLs de un lote con los mismos criterios que el listado
+type BatchFilter struct {
+	Search      string `json:"q" binding:"omitempty,max=255" example:"campaña"`
+	Domain      string `json:"domain" binding:"omitempty,hostname" example:"ejemplo.com"`
+	Expiry      string `json:"expiry" binding:"omitempty,oneof=active expired" enums:"active,expired"`
+	State       string `json:"state" binding:"omitempty,oneof=scheduled active ended" enums:"scheduled,active,ended"`
+	CreatedFrom string `json:"created_from" example:"2026-01-01"`
+	CreatedTo   string `json:"created_to" example:"2026-02-01"`
+	Tag         string `json:"tag" binding:"omitempty,max=50" example:"verano"`
+	FolderID    *uint  `json:"folder_id" example:"1"`
+}
+
+// BatchURLsRequest representa una operación sobre varias URLs del usuario,
+// elegidas por sus códigos cortos o con un filtro
+type BatchURLsRequest struct {
+	Action     string       `json:"action" binding:"required,oneof=delete set-expiry add-tags remove-tags change-owner" enums:"delete,set-expiry,add-tags,remove-tags,change-owner"`
+	ShortCodes []string     `json:"short_codes" binding:"required_without=Filter,excluded_with=Filter,omitempty,dive,required,max=10" example:"abc123,def456"`
+	Filter     *BatchFilter `json:"filter" binding:"required_without=ShortCodes"`
+	ExpiresAt  *time.Time   `json:"expires_at" example:"2026-12-31T23:59:59Z"`
+	Tags       []string     `json:"tags" binding:"required_if=Action add-tags,required_if=Action remove-tags,omitempty,dive,required,max=50" example:"campaña"`
+	Owner      string       `json:"owner" binding:"required_if=Action change-owner,omitempty,max=100" example:"otro_usuario"`
+}
+
+// BatchItemResult es el resultado de la operación sobre una URL del lote
+type BatchItemResult struct {
+	ShortCode string         `json:"short_code" example:"abc123"`
+	Status    string         `json:"status" example:"applied" enums:"applied,failed,skipped"`
+	Error     *BulkItemError `json:"error,omitempty"`
+}
+
+// BatchURLsResponse resume una operación sobre varias URLs con el resultado de cada una
+type BatchURLsResponse struct {
+	Action    string            `json:"action" example:"delete"`
+	Committed bool              `json:"committed" example:"true"`
+	Applied   int               `json:"applied" example:"2"`
+	Failed    int               `json:"failed" example:"0"`
+	Skipped   int               `json:"skipped" example:"0"`
+	Results   []BatchItemResult `json:"results"`
+}
+
+// BatchURLs godoc
+// @Summary Operar sobre varias URLs
+// @Description Aplica una operación (delete, set-expiry, add-tags, remove-tags o change-owner) a varias URLs del usuario,
+// @Description elegidas por sus códigos cortos (short_codes) o con un filtro (filter) con los criterios del listado.
+// @Description La operación es transaccional: si falla en alguna URL no se aplica a ninguna y committed es false.
+// @Description Las URLs de otros usuarios se tratan como inexistentes.
+// @Tags urls
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Clave para reintentar la petición sin repetir sus efectos"
+// @Param request body BatchURLsRequest true "Operación y URLs afectadas"
+// @Success 200 {object} BatchURLsResponse "Resultado de cada URL"
+// @Failure 400 {object} Problem "Petición inválida o demasiadas URLs"
+// @Failure 401 {object} Problem "No autorizado"
+// @Failure 404 {object} Problem "Nuevo propietario no encontrado"
+// @Failure 409 {object} Problem "Petición con la misma clave en curso"
+// @Failure 422 {object} Problem "Filtro con más URLs que el límite o clave de idempotencia reutilizada"
+// @Failure 500 {object} Problem "Error del servidor"
+// @Router /api/v1/urls/batch [post]
+func (h *URLHandler) BatchURLs(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var request BatchURLsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		WriteBindingError(c, err)
+		return
+	}
+	if len(request.ShortCodes) > h.bulkLimit {
+		h.writeBulkProblem(c, "short_codes", "max")
+		return
+	}
+
+	batch := model.URLBatch{
+		UserID:     userID,
+		Action:     model.BatchAction(request.Action),
+		ShortCodes: request.ShortCodes,
+		MaxTargets: h.bulkLimit,
+		ExpiresAt:  request.ExpiresAt,
+		Tags:       request.Tags,
+		Owner:      request.Owner,
+	}
+	if request.Filter != nil {
+		filter, ok := batchFilter(c, *request.Filter)
+		if !ok {
+			return
+		}
+		batch.Filter = &filter
+	}
+
+	results, err := h.urlService.BatchURLs(c.Request.Context(), batch)
+	if handleError(c, err) {
+		return
+	}
+
+	response := BatchURLsResponse{Action: request.Action, Committed: true, Results: make([]BatchItemResult, len(results))}
+	for i, result := range results {
+		item := BatchItemResult{ShortCode: result.ShortCode, Status: string(result.Status)}
+		switch result.Status {
+		case model.BulkApplied:
+			response.Applied++
+		case model.BulkFailed:
+			response.Failed++
+			spec := problemFor(c, result.Err)
+			item.Error = &BulkItemError{Code: spec.code, Message: i18n.T(Language(c), spec.code)}
+		case model.BulkSkipped:
+			response.Skipped++
+		}
+		response.Results[i] = item
+	}
+	response.Committed = response.Failed == 0
+	c.JSON(http.StatusOK, response)
+}
+
+// batchFilter convierte el filtro de la petición en el del dominio; si alguna
+// fecha no es válida escribe el problema y devuelve false
+func batchFilter(c *gin.Context, request BatchFilter) (model.URLFilter, bool) {
+	createdFrom, fromErr := parseDateParam(request.CreatedFrom)
+	createdTo, toErr := parseDateParam(request.CreatedTo)
+	if fromErr != nil || toErr != nil {
+		writeDateProblem(c, fromErr, toErr)
+		return model.URLFilter{}, false
+	}
+
+	filter := model.URLQuery{Filter: model.URLFilter{
+		CreatedFrom: createdFrom,
+		CreatedTo:   createdTo,
+		Expiry:      model.ExpiryState(request.Expiry),
+		State:       model.URLState(request.State),
+		Domain:      request.Domain,
+		Search:      request.Search,
+		Tag:         request.Tag,
+		FolderID:    request.FolderID,
+	}}.Normalize().Filter
+	return filter, true
+}
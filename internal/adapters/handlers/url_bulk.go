@@ -0,0 +1,293 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/i18n"
+)
+
+// DefaultBulkLimit es el número máximo de URLs por petición de creación masiva
+// si no se configura otro
+const DefaultBulkLimit = 500
+
+// WithBulkLimit establece el número máximo de URLs por petición de creación masiva
+func WithBulkLimit(limit int) URLHandlerOption {
+	return func(h *URLHandler) {
+		if limit > 0 {
+			h.bulkLimit = limit
+		}
+	}
+}
+
+// BulkItemError describe por qué falló un elemento de una operación masiva
+type BulkItemError struct {
+	Code    string `json:"code" example:"invalid_url"`
+	Message string `json:"message" example:"URL inválida"`
+}
+
+// BulkItemResult es el resultado de un elemento de una operación masiva
+type BulkItemResult struct {
+	Index     int            `json:"index" example:"0"`
+	URL       string         `json:"url" example:"https://www.ejemplo.com/campaña"`
+	Status    string         `json:"status" example:"created" enums:"created,existing,failed,skipped"`
+	ShortCode string         `json:"short_code,omitempty" example:"abc123"`
+	ShortURL  string         `json:"short_url,omitempty" example:"http://localhost:8080/abc123"`
+	Error     *BulkItemError `json:"error,omitempty"`
+}
+
+// BulkShortenResponse resume una creación masiva con el resultado de cada URL
+type BulkShortenResponse struct {
+	Atomic   bool             `json:"atomic" example:"false"`
+	Created  int              `json:"created" example:"2"`
+	Existing int              `json:"existing" example:"1"`
+	Failed   int              `json:"failed" example:"0"`
+	Skipped  int              `json:"skipped" example:"0"`
+	Results  []BulkItemResult `json:"results"`
+}
+
+// BulkURLItem es un elemento del array JSON de la creación masiva. Solo
+// admite la URL: los ajustes de POST /api/v1/urls (etiquetas, contraseña,
+// límite de visitas...) se rechazan para no crear sin ellos una URL que los
+// pedía.
+type BulkURLItem struct {
+	URL string `json:"url" example:"https://www.ejemplo.com/campaña"`
+}
+
+// bulkItemMaxBytes es el tamaño máximo medio de una URL de la petición; el
+// cuerpo, en cualquier formato, se limita a bulkLimit URLs de este tamaño
+// antes de leerlo
+const bulkItemMaxBytes = 8 << 10
+
+// BulkMaxBytes es el tamaño máximo del cuerpo de una creación masiva de
+// limit URLs (cero usa DefaultBulkLimit), el mayor que admite la API
+func BulkMaxBytes(limit int) int64 {
+	if limit <= 0 {
+		limit = DefaultBulkLimit
+	}
+	return int64(limit) * bulkItemMaxBytes
+}
+
+// errBulkTooManyItems indica que la petición supera el límite de URLs
+var errBulkTooManyItems = errors.New("too many items")
+
+// BulkShortenURLs godoc
+// @Summary Acortar varias URLs
+// @Description Crea varias URLs acortadas a partir de un array JSON de objetos {"url": "..."} o de un CSV
+// @Description (cuerpo text/csv o fichero "file" en multipart/form-data) con una URL por fila en la columna "url" o en la primera.
+// @Description Cada URL se valida y deduplica igual que en POST /api/v1/urls. Con atomic=true se crean todas o ninguna.
+// @Description Los elementos solo admiten "url": cualquier otro campo, como los ajustes de POST /api/v1/urls, se rechaza.
+// @Tags urls
+// @Accept json
+// @Accept text/csv
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Clave para reintentar la petición sin repetir sus efectos"
+// @Param atomic query bool false "Crear todas las URLs en una transacción (default: false)"
+// @Param request body []BulkURLItem true "URLs a acortar"
+// @Success 200 {object} BulkShortenResponse "Resultado de cada URL"
+// @Failure 400 {object} Problem "Petición inválida o demasiadas URLs"
+// @Failure 401 {object} Problem "No autorizado"
+// @Failure 409 {object} Problem "Petición con la misma clave en curso"
+// @Failure 413 {object} Problem "Cuerpo demasiado grande con Idempotency-Key"
+// @Failure 422 {object} Problem "Clave de idempotencia reutilizada con otra petición"
+// @Failure 500 {object} Problem "Error del servidor"
+// @Router /api/v1/urls/bulk [post]
+func (h *URLHandler) BulkShortenURLs(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	atomic, err := strconv.ParseBool(c.DefaultQuery("atomic", "false"))
+	if err != nil {
+		h.writeBulkProblem(c, "atomic", "boolean")
+		return
+	}
+
+	urls, err := h.readBulkURLs(c)
+	if errors.Is(err, errBulkTooManyItems) {
+		h.writeBulkProblem(c, "urls", "max")
+		return
+	}
+	if err != nil {
+		problem := NewProblem(http.StatusBadRequest, CodeMalformedBody)
+		if err != io.EOF {
+			problem.Detail = err.Error()
+		}
+		WriteProblem(c, problem)
+		return
+	}
+	if len(urls) == 0 {
+		h.writeBulkProblem(c, "urls", "required")
+		return
+	}
+
+	// Validar cada URL con las mismas reglas que la creación individual
+	response := BulkShortenResponse{Atomic: atomic, Results: make([]BulkItemResult, len(urls))}
+	var valid []string
+	var validIndexes []int
+	for i, url := range urls {
+		response.Results[i] = BulkItemResult{Index: i, URL: url}
+		if err := binding.Validator.ValidateStruct(ShortenURLRequest{URL: url}); err != nil {
+			response.Results[i].Status = string(model.BulkFailed)
+			response.Results[i].Error = &BulkItemError{Code: CodeInvalidURL, Message: i18n.T(Language(c), CodeInvalidURL)}
+			continue
+		}
+		valid = append(valid, url)
+		validIndexes = append(validIndexes, i)
+	}
+
+	if atomic && len(valid) < len(urls) {
+		// Una URL inválida impide crear las demás
+		for i := range response.Results {
+			if response.Results[i].Status == "" {
+				response.Results[i].Status = string(model.BulkSkipped)
+			}
+		}
+	} else if len(valid) > 0 {
+		results, err := h.urlService.BulkShortenURLs(c.Request.Context(), userID, valid, atomic)
+		if handleError(c, err) {
+			return
+		}
+		for j, result := range results {
+			item := &response.Results[validIndexes[j]]
+			item.Status = string(result.Status)
+			if result.URL != nil {
+				item.ShortCode = result.URL.ShortCode
+				item.ShortURL = h.buildShortURL(c, result.URL.ShortCode)
+			}
+			if result.Err != nil {
+				spec := problemFor(c, result.Err)
+				item.Error = &BulkItemError{Code: spec.code, Message: i18n.T(Language(c), spec.code)}
+			}
+		}
+	}
+
+	for _, item := range response.Results {
+		switch model.BulkStatus(item.Status) {
+		case model.BulkCreated:
+			response.Created++
+		case model.BulkExisting:
+			response.Existing++
+		case model.BulkFailed:
+			response.Failed++
+		case model.BulkSkipped:
+			response.Skipped++
+		}
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// readBulkURLs lee las URLs del cuerpo según su tipo de contenido. El límite
+// de tamaño cubre JSON, CSV y ficheros subidos, incluida una sola fila enorme.
+func (h *URLHandler) readBulkURLs(c *gin.Context) ([]string, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, BulkMaxBytes(h.bulkLimit))
+	urls, err := h.readBulkBody(c)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return nil, errBulkTooManyItems
+	}
+	return urls, err
+}
+
+// readBulkBody lee las URLs del cuerpo ya limitado según su tipo de contenido
+func (h *URLHandler) readBulkBody(c *gin.Context) ([]string, error) {
+	switch c.ContentType() {
+	case "text/csv":
+		return readCSVURLs(c.Request.Body, h.bulkLimit)
+	case "multipart/form-data":
+		header, err := c.FormFile("file")
+		if err != nil {
+			return nil, err
+		}
+		file, err := header.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		return readCSVURLs(file, h.bulkLimit)
+	default:
+		decoder := json.NewDecoder(c.Request.Body)
+		decoder.DisallowUnknownFields()
+		var items []BulkURLItem
+		if err := decoder.Decode(&items); err != nil {
+			return nil, err
+		}
+		if len(items) > h.bulkLimit {
+			return nil, errBulkTooManyItems
+		}
+		urls := make([]string, len(items))
+		for i, item := range items {
+			urls[i] = item.URL
+		}
+		return urls, nil
+	}
+}
+
+// readCSVURLs lee una URL por fila de la columna "url", si la cabecera la
+// tiene, o de la primera columna. Deja de leer al superar limit filas.
+func readCSVURLs(r io.Reader, limit int) ([]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var urls []string
+	column := 0
+	for row := 0; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return urls, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Una primera fila con una columna "url" es la cabecera
+		if row == 0 {
+			if index := headerColumn(record, "url"); index >= 0 {
+				column = index
+				continue
+			}
+		}
+
+		if column >= len(record) {
+			return nil, fmt.Errorf("row %d has no url column", row+1)
+		}
+		if len(urls) == limit {
+			return nil, errBulkTooManyItems
+		}
+		urls = append(urls, strings.TrimSpace(record[column]))
+	}
+}
+
+// headerColumn devuelve la posición de la columna name en la cabecera, o -1
+func headerColumn(header []string, name string) int {
+	for i, field := range header {
+		if strings.EqualFold(strings.TrimSpace(field), name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// writeBulkProblem escribe un error de validación de un parámetro de la creación masiva
+func (h *URLHandler) writeBulkProblem(c *gin.Context, field, rule string) {
+	problem := NewProblem(http.StatusBadRequest, CodeValidationFailed)
+	problem.Errors = []FieldError{{Field: field, Rule: rule, Message: validationMessage(Language(c), rule)}}
+	if rule == "max" {
+		problem.Detail = fmt.Sprintf("at most %d URLs per request", h.bulkLimit)
+	}
+	WriteProblem(c, problem)
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"tiny-url/internal/domain/errors"
+)
+
+func TestReadCSVURLs_HeaderColumn(t *testing.T) {
+	// Arrange
+	body := "id, URL\n1, https://www.ejemplo.com/a\n2,https://www.ejemplo.com/b\n"
+
+	// Act
+	urls, err := readCSVURLs(strings.NewReader(body), 10)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://www.ejemplo.com/a", "https://www.ejemplo.com/b"}, urls)
+}
+
+func TestReadCSVURLs_FirstColumnWithoutHeader(t *testing.T) {
+	// Arrange
+	body := "https://www.ejemplo.com/a,campaña\nhttps://www.ejemplo.com/b\n"
+
+	// Act
+	urls, err := readCSVURLs(strings.NewReader(body), 10)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://www.ejemplo.com/a", "https://www.ejemplo.com/b"}, urls)
+}
+
+func TestReadCSVURLs_Limit(t *testing.T) {
+	// Arrange
+	body := "url\nhttps://a.com\nhttps://b.com\nhttps://c.com\n"
+
+	// Act
+	_, err := readCSVURLs(strings.NewReader(body), 2)
+
+	// Assert
+	assert.True(t, errors.Is(err, errBulkTooManyItems))
+}
+
+func TestReadCSVURLs_MissingColumn(t *testing.T) {
+	// Arrange
+	body := "id,url\n1\n"
+
+	// Act
+	_, err := readCSVURLs(strings.NewReader(body), 10)
+
+	// Assert
+	assert.Error(t, err)
+}
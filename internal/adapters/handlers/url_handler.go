@@ -1,65 +1,143 @@
 package handlers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
 	"tiny-url/internal/domain/ports"
+	"tiny-url/internal/i18n"
 )
 
 // URLHandler maneja las peticiones HTTP relacionadas con el acortador de URLs
 type URLHandler struct {
-	urlService ports.URLService
+	urlService   ports.URLService
+	cursorSecret []byte
+	cursors      cursorCodec
+	bulkLimit    int
+}
+
+// URLHandlerOption configura un manejador de URLs en su construcción
+type URLHandlerOption func(*URLHandler)
+
+// WithCursorSecret establece la clave con la que se firman los cursores de
+// paginación. Todas las instancias que sirven el mismo listado deben
+// compartirla; sin ella se usa una clave aleatoria por proceso.
+func WithCursorSecret(secret []byte) URLHandlerOption {
+	return func(h *URLHandler) {
+		h.cursorSecret = secret
+	}
 }
 
 // NewURLHandler crea una nueva instancia del manejador de URLs
-func NewURLHandler(urlService ports.URLService) *URLHandler {
-	return &URLHandler{
+func NewURLHandler(urlService ports.URLService, opts ...URLHandlerOption) *URLHandler {
+	useJSONFieldNames()
+	h := &URLHandler{
 		urlService: urlService,
+		bulkLimit:  DefaultBulkLimit,
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	h.cursors = newCursorCodec(h.cursorSecret)
+	return h
 }
 
 // ShortenURLRequest representa la solicitud para acortar una URL
 type ShortenURLRequest struct {
-	URL string `json:"url" binding:"required,url" example:"https://www.ejemplo.com/pagina-con-url-muy-larga"`
+	URL       string   `json:"url" binding:"required,url" example:"https://www.ejemplo.com/pagina-con-url-muy-larga"`
+	Tags      []string `json:"tags" binding:"omitempty,dive,required,max=50" example:"campaña"`
+	FolderID  *uint    `json:"folder_id" binding:"omitempty,min=1" example:"1"`
+	Password  string   `json:"password" binding:"omitempty,max=72" example:"s3creto"`
+	MaxVisits *int     `json:"max_visits" binding:"omitempty,min=1" example:"1"`
+
+	ActiveFrom  *time.Time `json:"active_from" example:"2026-06-01T09:00:00Z"`
+	ActiveUntil *time.Time `json:"active_until" example:"2026-06-30T23:59:59Z"`
+	FallbackURL string     `json:"fallback_url" binding:"omitempty,url" example:"https://www.ejemplo.com/proximamente"`
+
+	RedirectCode int    `json:"redirect_code" binding:"omitempty,oneof=301 302 307 308" enums:"301,302,307,308" example:"302"`
+	ForwardQuery string `json:"forward_query" binding:"omitempty,oneof=keep override append" enums:"keep,override,append" example:"keep"`
+	ForwardPath  bool   `json:"forward_path" example:"false"`
+
+	// Template indica que url es una plantilla con marcadores ({path},
+	// {query.nombre|defecto}, {header.Nombre|defecto})
+	Template bool `json:"template" example:"false"`
+
+	DeviceRules []DeviceRuleRequest `json:"device_rules" binding:"omitempty,max=4,dive"`
 }
 
-// URLResponse representa la respuesta con la información de una URL acortada
-type URLResponse struct {
-	OriginalURL string `json:"original_url" example:"https://www.ejemplo.com/pagina-con-url-muy-larga"`
-	ShortCode   string `json:"short_code" example:"abc123"`
-	ShortURL    string `json:"short_url" example:"http://localhost:8080/abc123"`
-	Visits      int    `json:"visits" example:"5"`
+// DeviceRuleRequest representa una regla por dispositivo: las visitas desde
+// platform van a destination en lugar de a la URL original
+type DeviceRuleRequest struct {
+	Platform    string `json:"platform" binding:"required,oneof=ios android desktop bot" enums:"ios,android,desktop,bot" example:"ios"`
+	Destination string `json:"destination" binding:"required" example:"https://apps.apple.com/app/id123456789"`
 }
 
-// handleError maneja los errores comunes de forma centralizada
-func (h *URLHandler) handleError(c *gin.Context, err error) bool {
-	if err == nil {
-		return false
+// deviceRules convierte las reglas pedidas en reglas del dominio
+func deviceRules(requests []DeviceRuleRequest) []model.DeviceRule {
+	rules := make([]model.DeviceRule, len(requests))
+	for i, request := range requests {
+		rules[i] = model.DeviceRule{Platform: model.Platform(request.Platform), Destination: request.Destination}
 	}
+	return rules
+}
 
-	if errors.Is(err, errors.ErrInvalidURL) {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "URL inválida",
-		})
-		return true
+// settings devuelve los ajustes opcionales de la URL pedida
+func (r ShortenURLRequest) settings() model.URLSettings {
+	return model.URLSettings{
+		Tags:         r.Tags,
+		FolderID:     r.FolderID,
+		Password:     r.Password,
+		MaxVisits:    r.MaxVisits,
+		ActiveFrom:   r.ActiveFrom,
+		ActiveUntil:  r.ActiveUntil,
+		FallbackURL:  r.FallbackURL,
+		RedirectCode: r.RedirectCode,
+		ForwardQuery: model.QueryForwarding(r.ForwardQuery),
+		ForwardPath:  r.ForwardPath,
+		Template:     r.Template,
+		DeviceRules:  deviceRules(r.DeviceRules),
 	}
+}
 
-	if errors.Is(err, errors.ErrURLNotFound) {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": "URL no encontrada",
-		})
-		return true
-	}
+// URLResponse representa la respuesta con la información de una URL acortada
+type URLResponse struct {
+	OriginalURL  string      `json:"original_url" example:"https://www.ejemplo.com/pagina-con-url-muy-larga"`
+	ShortCode    string      `json:"short_code" example:"abc123"`
+	ShortURL     string      `json:"short_url" example:"http://localhost:8080/abc123"`
+	Visits       int         `json:"visits" example:"5"`
+	Tags         []model.Tag `json:"tags"`
+	FolderID     *uint       `json:"folder_id" example:"1"`
+	Protected    bool        `json:"protected" example:"false"`
+	MaxVisits    *int        `json:"max_visits" example:"1"`
+	ActiveFrom   *time.Time  `json:"active_from" example:"2026-06-01T09:00:00Z"`
+	ActiveUntil  *time.Time  `json:"active_until" example:"2026-06-30T23:59:59Z"`
+	FallbackURL  string      `json:"fallback_url" example:"https://www.ejemplo.com/proximamente"`
+	RedirectCode int         `json:"redirect_code" example:"302"`
+	ForwardQuery string      `json:"forward_query" example:"keep"`
+	ForwardPath  bool        `json:"forward_path" example:"false"`
+	Template     bool        `json:"template" example:"false"`
 
-	// Error genérico del servidor
-	c.JSON(http.StatusInternalServerError, gin.H{
-		"error": "Error del servidor",
-	})
-	return true
+	DeviceRules []model.DeviceRule `json:"device_rules"`
+}
+
+// currentUserID devuelve el usuario autenticado por AuthMiddleware; si no lo
+// hay escribe el error y devuelve false
+func currentUserID(c *gin.Context) (uint, bool) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		WriteError(c, errors.ErrUnauthorized)
+		return 0, false
+	}
+	return userID.(uint), true
 }
 
 // buildShortURL construye la URL completa a partir del código corto
@@ -74,60 +152,123 @@ func (h *URLHandler) buildShortURL(c *gin.Context, shortCode string) string {
 
 // ShortenURL godoc
 // @Summary Acortar una URL
-// @Description Crea una versión acortada de una URL proporcionada
+// @Description Crea una versión acortada de una URL proporcionada. Si el usuario ya la había acortado devuelve la existente,
+// @Description salvo que se pidan etiquetas (tags) o carpeta (folder_id): entonces siempre se crea una URL nueva con ellas.
+// @Description Con template, url es una plantilla de destino: {path} es la ruta que sigue al código, {query.nombre} un parámetro
+// @Description de la visita y {header.Nombre} una de sus cabeceras, con un valor por defecto opcional tras "|" ({query.lang|es}).
+// @Description device_rules envía a otro destino, como la ficha en la tienda de apps o un deep link, las visitas desde iOS,
+// @Description Android, escritorio o rastreadores, según su User-Agent.
 // @Tags urls
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param Idempotency-Key header string false "Clave para reintentar la petición sin repetir sus efectos"
 // @Param request body ShortenURLRequest true "URL a acortar"
 // @Success 201 {object} URLResponse "URL acortada exitosamente"
-// @Failure 400 {object} map[string]string "URL inválida"
-// @Failure 401 {object} map[string]string "No autorizado"
-// @Failure 500 {object} map[string]string "Error del servidor"
-// @Router /api/urls [post]
+// @Failure 400 {object} Problem "URL inválida"
+// @Failure 401 {object} Problem "No autorizado"
+// @Failure 404 {object} Problem "Carpeta no encontrada"
+// @Failure 409 {object} Problem "Petición con la misma clave en curso"
+// @Failure 422 {object} Problem "Clave de idempotencia reutilizada con otra petición"
+// @Failure 500 {object} Problem "Error del servidor"
+// @Router /api/v1/urls [post]
 func (h *URLHandler) ShortenURL(c *gin.Context) {
 	var request ShortenURLRequest
 
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "URL inválida",
-		})
+		WriteBindingError(c, err)
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
 		return
 	}
 
-	url, err := h.urlService.ShortenURL(c.Request.Context(), request.URL)
-	if h.handleError(c, err) {
+	url, err := h.urlService.ShortenURL(c.Request.Context(), userID, request.URL, request.settings())
+	if handleError(c, err) {
 		return
 	}
 
 	shortURL := h.buildShortURL(c, url.ShortCode)
 
 	c.JSON(http.StatusCreated, gin.H{
-		"original_url": url.OriginalURL,
-		"short_code":   url.ShortCode,
-		"short_url":    shortURL,
-		"visits":       url.Visits,
+		"original_url":  url.OriginalURL,
+		"short_code":    url.ShortCode,
+		"short_url":     shortURL,
+		"visits":        url.Visits,
+		"tags":          url.Tags,
+		"folder_id":     url.FolderID,
+		"protected":     url.Protected(),
+		"max_visits":    url.MaxVisits,
+		"active_from":   url.ActiveFrom,
+		"active_until":  url.ActiveUntil,
+		"fallback_url":  url.FallbackURL,
+		"redirect_code": url.RedirectCode,
+		"forward_query": url.ForwardQuery,
+		"forward_path":  url.ForwardPath,
+		"template":      url.Templated,
+		"device_rules":  url.DeviceRules,
 	})
 }
 
 // RedirectURL godoc
 // @Summary Redirigir a la URL original
-// @Description Redirige al usuario a la URL original correspondiente al código corto
+// @Description Redirige al usuario a la URL original correspondiente al código corto. Si la URL tiene contraseña y el navegador no tiene un pase vigente, muestra un formulario para introducirla.
+// @Description Antes de su activación, o tras su fin, redirige temporalmente a su URL alternativa; sin ella muestra una página de aviso antes de la activación.
+// @Description Si la URL lo tiene activado, la ruta que sigue al código y la query string de la visita se pasan al destino.
+// @Description Si es una plantilla, sus marcadores se rellenan con la visita, codificados para la parte del destino en la que están.
+// @Description Si tiene una regla para la plataforma del User-Agent (ios, android, desktop o bot), redirige a su destino.
 // @Tags redirection
-// @Produce json
+// @Produce json,html
 // @Param shortCode path string true "Código corto de la URL"
-// @Success 301 "Redirección a la URL original"
-// @Failure 404 {object} map[string]string "URL no encontrada"
-// @Failure 500 {object} map[string]string "Error del servidor"
+// @Param rest path string false "Ruta que sigue al código corto"
+// @Success 200 "Formulario de contraseña de una URL protegida"
+// @Success 301 "Redirección a la URL original, con el código de la URL o el del servidor (301, 302, 307 o 308)"
+// @Success 302 "Redirección a la URL alternativa de una URL no activa"
+// @Failure 404 {object} Problem "URL no encontrada"
+// @Failure 410 {object} Problem "La URL ha agotado sus visitas, ha caducado o ha terminado"
+// @Failure 500 {object} Problem "Error del servidor"
+// @Failure 503 "Página de una URL que aún no está activa, con Retry-After"
 // @Router /{shortCode} [get]
+// @Router /{shortCode}/{rest} [get]
 func (h *URLHandler) RedirectURL(c *gin.Context) {
 	shortCode := c.Param("shortCode")
-	originalURL, err := h.urlService.RedirectURL(c.Request.Context(), shortCode)
-	if h.handleError(c, err) {
+	visit := model.Visit{
+		AccessToken: accessToken(c),
+		Path:        c.Param("rest"),
+		RawQuery:    c.Request.URL.RawQuery,
+		Header:      c.Request.Header,
+	}
+	redirect, err := h.urlService.RedirectURL(c.Request.Context(), shortCode, visit)
+	var notYetActive *errors.NotYetActiveError
+	switch {
+	case errors.Is(err, errors.ErrPasswordRequired):
+		renderPasswordPrompt(c, http.StatusOK, false)
+		return
+	case errors.As(err, &notYetActive):
+		renderNotYetActive(c, notYetActive.ActiveFrom)
+		return
+	}
+	if handleError(c, err) {
 		return
 	}
 
-	c.Redirect(http.StatusMovedPermanently, originalURL)
+	writeCacheHeaders(c, redirect.MaxAge)
+	c.Redirect(redirect.Status, redirect.Location)
+}
+
+// writeCacheHeaders indica durante cuánto tiempo se puede reutilizar la
+// respuesta. Sin tiempo se prohíbe guardarla, para que cada visita llegue al
+// servidor y se cuente.
+func writeCacheHeaders(c *gin.Context, maxAge time.Duration) {
+	if maxAge <= 0 {
+		c.Header("Cache-Control", "no-store")
+		c.Header("Expires", time.Unix(0, 0).UTC().Format(http.TimeFormat))
+		return
+	}
+	c.Header("Cache-Control", "public, max-age="+strconv.Itoa(int(maxAge.Seconds())))
+	c.Header("Expires", time.Now().Add(maxAge).UTC().Format(http.TimeFormat))
 }
 
 // GetURLInfo godoc
@@ -138,14 +279,14 @@ func (h *URLHandler) RedirectURL(c *gin.Context) {
 // @Security BearerAuth
 // @Param shortCode path string true "Código corto de la URL"
 // @Success 200 {object} URLResponse "Información de la URL"
-// @Failure 401 {object} map[string]string "No autorizado"
-// @Failure 404 {object} map[string]string "URL no encontrada"
-// @Failure 500 {object} map[string]string "Error del servidor"
-// @Router /api/urls/{shortCode} [get]
+// @Failure 401 {object} Problem "No autorizado"
+// @Failure 404 {object} Problem "URL no encontrada"
+// @Failure 500 {object} Problem "Error del servidor"
+// @Router /api/v1/urls/{shortCode} [get]
 func (h *URLHandler) GetURLInfo(c *gin.Context) {
 	shortCode := c.Param("shortCode")
 	url, err := h.urlService.GetURL(c.Request.Context(), shortCode)
-	if h.handleError(c, err) {
+	if handleError(c, err) {
 		return
 	}
 
@@ -157,64 +298,296 @@ func (h *URLHandler) GetURLInfo(c *gin.Context) {
 	})
 }
 
+// ListURLsRequest representa los parámetros del listado de URLs
+type ListURLsRequest struct {
+	Limit       int    `form:"limit" binding:"omitempty,min=0"`
+	Offset      int    `form:"offset" binding:"omitempty,min=0"`
+	Sort        string `form:"sort" binding:"omitempty,oneof=created_at -created_at visits -visits"`
+	Search      string `form:"q" binding:"omitempty,max=255"`
+	Domain      string `form:"domain" binding:"omitempty,hostname"`
+	Expiry      string `form:"expiry" binding:"omitempty,oneof=active expired"`
+	State       string `form:"state" binding:"omitempty,oneof=scheduled active ended"`
+	CreatedFrom string `form:"created_from"`
+	CreatedTo   string `form:"created_to"`
+	Tag         string `form:"tag" binding:"omitempty,max=50"`
+	FolderID    *uint  `form:"folder_id"`
+	Cursor      string `form:"cursor" binding:"omitempty,max=512"`
+}
+
+// fingerprint identifica el listado pedido, sin la paginación, para que un
+// cursor no pueda usarse con otros filtros u otra ordenación
+func (r ListURLsRequest) fingerprint() string {
+	hash := sha256.Sum256([]byte(strings.Join([]string{
+		r.Sort, r.Search, strings.ToLower(r.Domain), r.Expiry, r.State, r.CreatedFrom, r.CreatedTo, r.Tag, r.folder(),
+	}, "\x00")))
+	return hex.EncodeToString(hash[:8])
+}
+
+// folder devuelve la carpeta pedida como texto; vacío si no se filtra por carpeta
+func (r ListURLsRequest) folder() string {
+	if r.FolderID == nil {
+		return ""
+	}
+	return strconv.FormatUint(uint64(*r.FolderID), 10)
+}
+
+// ListURLsResponse representa una página del listado de URLs
+type ListURLsResponse struct {
+	URLs       []*model.URL `json:"urls"`
+	Total      int64        `json:"total" example:"42"`
+	Limit      int          `json:"limit" example:"10"`
+	Offset     int          `json:"offset" example:"0"`
+	NextCursor string       `json:"next_cursor,omitempty" example:"eyJzIjoiLWNyZWF0ZWRfYXQifQ.c2lnbmF0dXJl"`
+	PrevCursor string       `json:"prev_cursor,omitempty"`
+}
+
 // ListURLs godoc
 // @Summary Listar todas las URLs
-// @Description Obtiene una lista paginada de todas las URLs acortadas
+// @Description Obtiene una lista paginada de URLs, con filtros, búsqueda, ordenación y el total de resultados.
+// @Description Para recorrer listados grandes se recomiendan los cursores next_cursor y prev_cursor, estables aunque se creen URLs entre páginas.
 // @Tags urls
 // @Produce json
 // @Security BearerAuth
-// @Param limit query int false "Límite de resultados por página (default: 10)"
+// @Param limit query int false "Límite de resultados por página (default: 10, máximo: 100)"
 // @Param offset query int false "Desplazamiento para paginación (default: 0)"
-// @Success 200 {object} map[string]interface{} "Lista de URLs"
-// @Failure 401 {object} map[string]string "No autorizado"
-// @Failure 500 {object} map[string]string "Error del servidor"
-// @Router /api/urls [get]
+// @Param sort query string false "Orden: created_at, visits; con '-' delante es descendente (default: -created_at)" Enums(created_at, -created_at, visits, -visits)
+// @Param q query string false "Texto a buscar en la URL original y en el código corto"
+// @Param domain query string false "Dominio de destino, incluidos sus subdominios"
+// @Param expiry query string false "Estado de caducidad" Enums(active, expired)
+// @Param state query string false "Estado de la programación: aún no activa, activa o terminada" Enums(scheduled, active, ended)
+// @Param created_from query string false "Creadas desde esta fecha (RFC 3339 o AAAA-MM-DD, incluida)"
+// @Param created_to query string false "Creadas antes de esta fecha (RFC 3339 o AAAA-MM-DD, excluida)"
+// @Param tag query string false "Nombre de una etiqueta de las URLs"
+// @Param folder_id query int false "Carpeta de las URLs; 0 lista las que no están en ninguna"
+// @Param cursor query string false "Cursor next_cursor o prev_cursor de una respuesta anterior; sustituye a offset"
+// @Success 200 {object} ListURLsResponse "Lista de URLs"
+// @Header 200 {string} Link "Enlaces RFC 8288 a las páginas first, next y prev"
+// @Failure 400 {object} Problem "Parámetros inválidos"
+// @Failure 401 {object} Problem "No autorizado"
+// @Failure 500 {object} Problem "Error del servidor"
+// @Router /api/v1/urls [get]
 func (h *URLHandler) ListURLs(c *gin.Context) {
-	limitStr := c.DefaultQuery("limit", "10")
-	offsetStr := c.DefaultQuery("offset", "0")
+	var request ListURLsRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		WriteBindingError(c, err)
+		return
+	}
 
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil {
-		limit = 10
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
 	}
 
-	offset, err := strconv.Atoi(offsetStr)
-	if err != nil {
-		offset = 0
+	createdFrom, fromErr := parseDateParam(request.CreatedFrom)
+	createdTo, toErr := parseDateParam(request.CreatedTo)
+	if fromErr != nil || toErr != nil {
+		writeDateProblem(c, fromErr, toErr)
+		return
 	}
 
-	urls, err := h.urlService.ListURLs(c.Request.Context(), limit, offset)
-	if h.handleError(c, err) {
+	var cursor *model.URLCursor
+	if request.Cursor != "" {
+		var err error
+		cursor, err = h.cursors.decode(request.Cursor, request.fingerprint())
+		if handleError(c, err) {
+			return
+		}
+	}
+
+	query := model.URLQuery{
+		Cursor: cursor,
+		Filter: model.URLFilter{
+			UserID:      userID,
+			CreatedFrom: createdFrom,
+			CreatedTo:   createdTo,
+			Expiry:      model.ExpiryState(request.Expiry),
+			State:       model.URLState(request.State),
+			Domain:      request.Domain,
+			Search:      request.Search,
+			Tag:         request.Tag,
+			FolderID:    request.FolderID,
+		},
+		Sort:   model.URLSort(request.Sort),
+		Limit:  request.Limit,
+		Offset: request.Offset,
+	}.Normalize()
+
+	page, err := h.urlService.ListURLs(c.Request.Context(), query)
+	if handleError(c, err) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"urls":   urls,
-		"limit":  limit,
-		"offset": offset,
-	})
+	response := ListURLsResponse{
+		URLs:   page.URLs,
+		Total:  page.Total,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	}
+	links := []string{pageLink(c, "", "first")}
+	if page.Next != nil {
+		response.NextCursor = h.cursors.encode(page.Next, request.fingerprint())
+		links = append(links, pageLink(c, response.NextCursor, "next"))
+	}
+	if page.Prev != nil {
+		response.PrevCursor = h.cursors.encode(page.Prev, request.fingerprint())
+		links = append(links, pageLink(c, response.PrevCursor, "prev"))
+	}
+	// Add y no Header: en la API antigua ya hay un Link al sucesor
+	c.Writer.Header().Add("Link", strings.Join(links, ", "))
+
+	c.JSON(http.StatusOK, response)
+}
+
+// pageLink construye un enlace RFC 8288 a otra página del listado actual,
+// conservando los filtros y sustituyendo la paginación por el cursor
+func pageLink(c *gin.Context, cursor, rel string) string {
+	target := *c.Request.URL
+	params := target.Query()
+	params.Del("offset")
+	params.Del("cursor")
+	if cursor != "" {
+		params.Set("cursor", cursor)
+	}
+	target.RawQuery = params.Encode()
+	return fmt.Sprintf(`<%s>; rel="%s"`, target.RequestURI(), rel)
+}
+
+// writeDateProblem escribe el error de validación de las fechas created_from
+// y created_to que no se pudieron interpretar
+func writeDateProblem(c *gin.Context, fromErr, toErr error) {
+	lang := Language(c)
+	problem := NewProblem(http.StatusBadRequest, CodeValidationFailed)
+	if fromErr != nil {
+		problem.Errors = append(problem.Errors, FieldError{Field: "created_from", Rule: "datetime", Message: validationMessage(lang, "datetime")})
+	}
+	if toErr != nil {
+		problem.Errors = append(problem.Errors, FieldError{Field: "created_to", Rule: "datetime", Message: validationMessage(lang, "datetime")})
+	}
+	WriteProblem(c, problem)
+}
+
+// parseDateParam interpreta una fecha RFC 3339 o AAAA-MM-DD; vacía devuelve nil
+func parseDateParam(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return &parsed, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid date %q", value)
+}
+
+// UpdateURLRequest representa los cambios de una URL. Los campos ausentes no se modifican.
+type UpdateURLRequest struct {
+	Tags         *[]string `json:"tags" binding:"omitempty,dive,required,max=50" example:"campaña"`
+	FolderID     *uint     `json:"folder_id" example:"1"`
+	MaxVisits    *int      `json:"max_visits" binding:"omitempty,min=0" example:"10"`
+	RedirectCode *int      `json:"redirect_code" binding:"omitempty,oneof=0 301 302 307 308" enums:"0,301,302,307,308" example:"307"`
+	ForwardQuery *string   `json:"forward_query" binding:"omitempty,oneof=none keep override append" enums:"none,keep,override,append" example:"override"`
+	ForwardPath  *bool     `json:"forward_path" example:"true"`
+
+	DeviceRules *[]DeviceRuleRequest `json:"device_rules" binding:"omitempty,max=4,dive"`
+}
+
+// forwardQuery devuelve la política de paso de la query string pedida; nil
+// si no se cambia
+func (r UpdateURLRequest) forwardQuery() *model.QueryForwarding {
+	if r.ForwardQuery == nil {
+		return nil
+	}
+	policy := model.QueryForwarding(*r.ForwardQuery)
+	if policy == "none" {
+		policy = model.QueryNotForwarded
+	}
+	return &policy
+}
+
+// UpdateURL godoc
+// @Summary Modificar una URL
+// @Description Cambia las etiquetas, la carpeta o el límite de visitas de una URL del usuario. tags sustituye todas sus etiquetas,
+// @Description creando las que no existan ([] las quita); folder_id la mueve a otra carpeta del usuario (0 la saca de la carpeta);
+// @Description max_visits cambia las visitas permitidas, contando las ya recibidas (0 quita el límite); redirect_code
+// @Description cambia el código de la redirección (0 vuelve al del servidor); forward_query y forward_path cambian qué
+// @Description partes de la visita se pasan al destino (none deja de pasar la query string); device_rules sustituye las
+// @Description reglas por dispositivo ([] las quita).
+// @Tags urls
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Clave para reintentar la petición sin repetir sus efectos"
+// @Param shortCode path string true "Código corto de la URL"
+// @Param request body UpdateURLRequest true "Cambios de la URL"
+// @Success 200 {object} model.URL "URL modificada"
+// @Failure 400 {object} Problem "Datos inválidos"
+// @Failure 401 {object} Problem "No autorizado"
+// @Failure 404 {object} Problem "URL o carpeta no encontrada"
+// @Failure 409 {object} Problem "Petición con la misma clave en curso"
+// @Failure 422 {object} Problem "Clave de idempotencia reutilizada con otra petición"
+// @Failure 500 {object} Problem "Error del servidor"
+// @Router /api/v1/urls/{shortCode} [patch]
+func (h *URLHandler) UpdateURL(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var request UpdateURLRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		WriteBindingError(c, err)
+		return
+	}
+
+	changes := model.URLChanges{
+		Tags:         request.Tags,
+		FolderID:     request.FolderID,
+		MaxVisits:    request.MaxVisits,
+		RedirectCode: request.RedirectCode,
+		ForwardQuery: request.forwardQuery(),
+		ForwardPath:  request.ForwardPath,
+	}
+	if request.DeviceRules != nil {
+		rules := deviceRules(*request.DeviceRules)
+		changes.DeviceRules = &rules
+	}
+	url, err := h.urlService.UpdateURL(c.Request.Context(), userID, c.Param("shortCode"), changes)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, url)
 }
 
 // DeleteURL godoc
 // @Summary Eliminar una URL
-// @Description Elimina una URL acortada por su código corto
+// @Description Mueve a la papelera una URL acortada del usuario por su código corto. Se puede restaurar hasta que se purga y
+// @Description mientras tanto su código no se reutiliza.
 // @Tags urls
 // @Produce json
 // @Security BearerAuth
+// @Param Idempotency-Key header string false "Clave para reintentar la petición sin repetir sus efectos"
 // @Param shortCode path string true "Código corto de la URL"
 // @Success 200 {object} map[string]string "URL eliminada correctamente"
-// @Failure 401 {object} map[string]string "No autorizado"
-// @Failure 404 {object} map[string]string "URL no encontrada"
-// @Failure 500 {object} map[string]string "Error del servidor"
-// @Router /api/urls/{shortCode} [delete]
+// @Failure 401 {object} Problem "No autorizado"
+// @Failure 404 {object} Problem "URL no encontrada"
+// @Failure 409 {object} Problem "Petición con la misma clave en curso"
+// @Failure 422 {object} Problem "Clave de idempotencia reutilizada con otra petición"
+// @Failure 500 {object} Problem "Error del servidor"
+// @Router /api/v1/urls/{shortCode} [delete]
 func (h *URLHandler) DeleteURL(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
 	shortCode := c.Param("shortCode")
-	err := h.urlService.DeleteURL(c.Request.Context(), shortCode)
-	if h.handleError(c, err) {
+	err := h.urlService.DeleteURL(c.Request.Context(), userID, shortCode)
+	if handleError(c, err) {
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "URL eliminada correctamente",
+		"message": i18n.T(Language(c), "url_deleted"),
 	})
 }
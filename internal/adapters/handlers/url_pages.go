@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"tiny-url/internal/i18n"
+)
+
+// pageLayout envuelve el contenido de las páginas que se muestran a quien
+// visita una URL corta en lugar de redirigirle
+const pageLayout = `{{define "layout"}}<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>{{.Title}}</title>
+</head>
+<body>
+<main>
+<h1>{{.Title}}</h1>
+<p>{{.Message}}</p>
+{{template "content" .}}
+</main>
+</body>
+</html>
+{{end}}`
+
+// passwordPrompt es la página que pide la contraseña de una URL protegida
+var passwordPrompt = template.Must(template.Must(template.New("password").Parse(pageLayout)).Parse(`{{define "content"}}
+{{if .Error}}<p role="alert">{{.Error}}</p>{{end}}
+<form method="post" action="{{.Action}}">
+<label for="password">{{.Label}}</label>
+<input id="password" name="password" type="password" autocomplete="current-password" required autofocus>
+<button type="submit">{{.Submit}}</button>
+</form>
+{{end}}`))
+
+// notYetActivePage es la página de una URL que aún no ha empezado a redirigir
+var notYetActivePage = template.Must(template.Must(template.New("scheduled").Parse(pageLayout)).Parse(`{{define "content"}}
+<p><time datetime="{{.ActiveFrom}}">{{.ActiveFromText}}</time></p>
+{{end}}`))
+
+// passwordPromptPage son los textos de la página de contraseña en el idioma
+// del visitante
+type passwordPromptPage struct {
+	Lang    string
+	Title   string
+	Message string
+	Label   string
+	Submit  string
+	Error   string
+	Action  string
+}
+
+// notYetActivePageData son los textos de la página de una URL programada
+type notYetActivePageData struct {
+	Lang           string
+	Title          string
+	Message        string
+	ActiveFrom     string
+	ActiveFromText string
+}
+
+// renderPasswordPrompt muestra el formulario de contraseña de la URL de la
+// petición; wrong indica que la contraseña enviada no era la correcta
+func renderPasswordPrompt(c *gin.Context, status int, wrong bool) {
+	lang := Language(c)
+	page := passwordPromptPage{
+		Lang:    lang,
+		Title:   i18n.T(lang, "password_prompt.title"),
+		Message: i18n.T(lang, "password_prompt.message"),
+		Label:   i18n.T(lang, "password_prompt.label"),
+		Submit:  i18n.T(lang, "password_prompt.submit"),
+		Action:  c.Request.URL.RequestURI(),
+	}
+	if wrong {
+		page.Error = i18n.T(lang, "password_prompt.wrong")
+	}
+	renderPage(c, status, passwordPrompt, lang, page)
+}
+
+// renderNotYetActive muestra que la URL empezará a redirigir en activeFrom.
+// Responde 503 con Retry-After para que buscadores y clientes vuelvan
+// entonces en lugar de dar la URL por inexistente.
+func renderNotYetActive(c *gin.Context, activeFrom time.Time) {
+	lang := Language(c)
+	activeFrom = activeFrom.UTC()
+	c.Header("Retry-After", activeFrom.Format(http.TimeFormat))
+	renderPage(c, http.StatusServiceUnavailable, notYetActivePage, lang, notYetActivePageData{
+		Lang:           lang,
+		Title:          i18n.T(lang, "not_yet_active.title"),
+		Message:        i18n.T(lang, "not_yet_active.message"),
+		ActiveFrom:     activeFrom.Format(time.RFC3339),
+		ActiveFromText: activeFrom.Format("2006-01-02 15:04 MST"),
+	})
+}
+
+// renderPage escribe una página HTML. Depende del estado de la URL y del
+// visitante, así que no debe guardarse en cachés.
+func renderPage(c *gin.Context, status int, page *template.Template, lang string, data any) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("Content-Language", lang)
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Status(status)
+	if err := page.ExecuteTemplate(c.Writer, "layout", data); err != nil {
+		c.Error(err)
+	}
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"tiny-url/internal/domain/model"
+)
+
+// TrashURLsRequest representa los parámetros del listado de la papelera
+type TrashURLsRequest struct {
+	Limit  int `form:"limit" binding:"omitempty,min=0"`
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
+
+// TrashURLs godoc
+// @Summary Listar la papelera
+// @Description Obtiene una lista paginada de las URLs eliminadas del usuario que aún no se han purgado, las más recientes primero
+// @Tags urls
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Límite de resultados por página (default: 10, máximo: 100)"
+// @Param offset query int false "Desplazamiento para paginación (default: 0)"
+// @Success 200 {object} ListURLsResponse "URLs de la papelera"
+// @Failure 400 {object} Problem "Parámetros inválidos"
+// @Failure 401 {object} Problem "No autorizado"
+// @Failure 500 {object} Problem "Error del servidor"
+// @Router /api/v1/urls/trash [get]
+func (h *URLHandler) TrashURLs(c *gin.Context) {
+	var request TrashURLsRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		WriteBindingError(c, err)
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	query := model.URLQuery{
+		Filter: model.URLFilter{UserID: userID, Trashed: true},
+		Limit:  request.Limit,
+		Offset: request.Offset,
+	}.Normalize()
+
+	page, err := h.urlService.ListURLs(c.Request.Context(), query)
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, ListURLsResponse{
+		URLs:   page.URLs,
+		Total:  page.Total,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	})
+}
+
+// RestoreURL godoc
+// @Summary Restaurar una URL
+// @Description Saca de la papelera una URL eliminada del usuario, que vuelve a redirigir
+// @Tags urls
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Clave para reintentar la petición sin repetir sus efectos"
+// @Param shortCode path string true "Código corto de la URL"
+// @Success 200 {object} model.URL "URL restaurada"
+// @Failure 401 {object} Problem "No autorizado"
+// @Failure 404 {object} Problem "URL no encontrada en la papelera"
+// @Failure 409 {object} Problem "Petición con la misma clave en curso"
+// @Failure 422 {object} Problem "Clave de idempotencia reutilizada con otra petición"
+// @Failure 500 {object} Problem "Error del servidor"
+// @Router /api/v1/urls/{shortCode}/restore [post]
+func (h *URLHandler) RestoreURL(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	url, err := h.urlService.RestoreURL(c.Request.Context(), userID, c.Param("shortCode"))
+	if handleError(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, url)
+}
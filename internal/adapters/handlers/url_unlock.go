@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"tiny-url/internal/domain/errors"
+)
+
+// AccessCookieName es la cookie en la que el navegador guarda el pase de una
+// URL protegida. Su ruta es la de la URL corta, así que cada URL tiene el suyo
+// y sirve también para las rutas que siguen al código.
+const AccessCookieName = "link_access"
+
+// UnlockURL godoc
+// @Summary Desbloquear una URL protegida
+// @Description Comprueba la contraseña enviada desde el formulario de una URL protegida. Si es correcta guarda un pase temporal en una cookie y vuelve a la dirección visitada, con su ruta y su query string; si no, vuelve a mostrar el formulario.
+// @Tags redirection
+// @Accept x-www-form-urlencoded
+// @Produce html
+// @Param shortCode path string true "Código corto de la URL"
+// @Param rest path string false "Ruta que sigue al código corto"
+// @Param password formData string true "Contraseña de la URL"
+// @Success 303 "Redirección a la URL corta con el pase en la cookie link_access"
+// @Failure 403 "Contraseña incorrecta; se muestra de nuevo el formulario"
+// @Failure 404 {object} Problem "URL no encontrada"
+// @Failure 500 {object} Problem "Error del servidor"
+// @Router /{shortCode} [post]
+// @Router /{shortCode}/{rest} [post]
+func (h *URLHandler) UnlockURL(c *gin.Context) {
+	shortCode := c.Param("shortCode")
+	access, err := h.urlService.UnlockURL(c.Request.Context(), shortCode, c.PostForm("password"))
+	if errors.Is(err, errors.ErrWrongPassword) {
+		renderPasswordPrompt(c, http.StatusForbidden, true)
+		return
+	}
+	if handleError(c, err) {
+		return
+	}
+
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     AccessCookieName,
+		Value:    access.Token,
+		Path:     "/" + shortCode,
+		Expires:  access.ExpiresAt,
+		HttpOnly: true,
+		Secure:   c.Request.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+	c.Redirect(http.StatusSeeOther, c.Request.URL.RequestURI())
+}
+
+// accessToken devuelve el pase que el navegador guardó para la URL visitada
+func accessToken(c *gin.Context) string {
+	token, err := c.Cookie(AccessCookieName)
+	if err != nil {
+		return ""
+	}
+	return token
+}
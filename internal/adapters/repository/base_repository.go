@@ -1,76 +1,135 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"gorm.io/gorm"
 
 	"tiny-url/internal/domain/errors"
 )
 
+// Timeouts define el tiempo máximo de cada tipo de operación contra la base de datos.
+// Un valor cero significa que solo se respeta el plazo del contexto recibido.
+type Timeouts struct {
+	Read  time.Duration
+	Write time.Duration
+}
+
+// Option configura un repositorio en su construcción
+type Option func(*BaseRepository)
+
+// WithTimeouts establece los tiempos máximos de lectura y escritura
+func WithTimeouts(timeouts Timeouts) Option {
+	return func(r *BaseRepository) {
+		r.timeouts = timeouts
+	}
+}
+
 // BaseRepository proporciona operaciones comunes para los repositorios
 type BaseRepository struct {
-	db *gorm.DB
+	db       *gorm.DB
+	timeouts Timeouts
 }
 
 // newBaseRepository crea una nueva instancia del repositorio base
-func newBaseRepository(db *gorm.DB) BaseRepository {
-	return BaseRepository{db: db}
+func newBaseRepository(db *gorm.DB, opts ...Option) BaseRepository {
+	r := BaseRepository{db: db}
+	for _, opt := range opts {
+		opt(&r)
+	}
+	return r
 }
 
-// handleGormError maneja los errores comunes de GORM
+// handleGormError maneja los errores comunes de GORM. Los errores de clave
+// duplicada llegan traducidos por el dialecto, sea PostgreSQL o SQLite.
 func (r *BaseRepository) handleGormError(err error, notFoundErr error, message string) error {
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return notFoundErr
 		}
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errors.ErrDuplicateKey
+		}
 		return errors.Wrap(err, message)
 	}
 	return nil
 }
 
+// withTimeout deriva un contexto limitado por timeout, si está configurado
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// read ejecuta una operación de lectura con el contexto y el límite de lectura
+func (r *BaseRepository) read(ctx context.Context, fn func(db *gorm.DB) *gorm.DB) *gorm.DB {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Read)
+	defer cancel()
+	return fn(r.db.WithContext(ctx))
+}
+
+// write ejecuta una operación de escritura con el contexto y el límite de escritura
+func (r *BaseRepository) write(ctx context.Context, fn func(db *gorm.DB) *gorm.DB) *gorm.DB {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Write)
+	defer cancel()
+	return fn(r.db.WithContext(ctx))
+}
+
 // findOne busca un único registro usando una condición
-func (r *BaseRepository) findOne(dest interface{}, condition string, args ...interface{}) error {
-	result := r.db.Where(condition, args...).First(dest)
+func (r *BaseRepository) findOne(ctx context.Context, dest interface{}, condition string, args ...interface{}) error {
+	result := r.read(ctx, func(db *gorm.DB) *gorm.DB {
+		return db.Where(condition, args...).First(dest)
+	})
 	return result.Error
 }
 
 // findById busca un registro por su ID
-func (r *BaseRepository) findById(dest interface{}, id uint) error {
-	result := r.db.First(dest, id)
+func (r *BaseRepository) findById(ctx context.Context, dest interface{}, id uint) error {
+	result := r.read(ctx, func(db *gorm.DB) *gorm.DB {
+		return db.First(dest, id)
+	})
 	return result.Error
 }
 
 // create crea un nuevo registro
-func (r *BaseRepository) create(value interface{}) error {
-	result := r.db.Create(value)
+func (r *BaseRepository) create(ctx context.Context, value interface{}) error {
+	result := r.write(ctx, func(db *gorm.DB) *gorm.DB {
+		return db.Create(value)
+	})
 	return result.Error
 }
 
 // update actualiza un registro existente
-func (r *BaseRepository) update(value interface{}) (int64, error) {
-	result := r.db.Save(value)
+func (r *BaseRepository) update(ctx context.Context, value interface{}) (int64, error) {
+	result := r.write(ctx, func(db *gorm.DB) *gorm.DB {
+		return db.Save(value)
+	})
 	return result.RowsAffected, result.Error
 }
 
 // delete elimina un registro
-func (r *BaseRepository) delete(value interface{}, condition string, args ...interface{}) (int64, error) {
-	result := r.db.Where(condition, args...).Delete(value)
+func (r *BaseRepository) delete(ctx context.Context, value interface{}, condition string, args ...interface{}) (int64, error) {
+	result := r.write(ctx, func(db *gorm.DB) *gorm.DB {
+		return db.Where(condition, args...).Delete(value)
+	})
 	return result.RowsAffected, result.Error
 }
 
 // deleteById elimina un registro por su ID
-func (r *BaseRepository) deleteById(value interface{}, id uint) (int64, error) {
-	result := r.db.Delete(value, id)
+func (r *BaseRepository) deleteById(ctx context.Context, value interface{}, id uint) (int64, error) {
+	result := r.write(ctx, func(db *gorm.DB) *gorm.DB {
+		return db.Delete(value, id)
+	})
 	return result.RowsAffected, result.Error
 }
 
-// findAll busca todos los registros con paginación
-func (r *BaseRepository) findAll(dest interface{}, limit, offset int) error {
-	result := r.db.Limit(limit).Offset(offset).Find(dest)
-	return result.Error
-}
-
 // updateColumn actualiza una columna específica
-func (r *BaseRepository) updateColumn(model interface{}, condition string, columnName string, value interface{}, args ...interface{}) (int64, error) {
-	result := r.db.Model(model).Where(condition, args...).UpdateColumn(columnName, value)
+func (r *BaseRepository) updateColumn(ctx context.Context, model interface{}, condition string, columnName string, value interface{}, args ...interface{}) (int64, error) {
+	result := r.write(ctx, func(db *gorm.DB) *gorm.DB {
+		return db.Model(model).Where(condition, args...).UpdateColumn(columnName, value)
+	})
 	return result.RowsAffected, result.Error
 }
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"tiny-url/internal/adapters/repository/repositorytest"
+	"tiny-url/internal/domain/ports"
+)
+
+// truncate vacía las tablas indicadas antes y después de cada caso de la suite.
+// La suite usa la conexión compartida en lugar de una transacción porque
+// ejecuta operaciones concurrentes.
+func truncate(t *testing.T, tables ...string) {
+	clean := func() {
+		for _, table := range tables {
+			require.NoError(t, testDB.Exec("DELETE FROM "+table).Error)
+		}
+	}
+	clean()
+	t.Cleanup(clean)
+}
+
+func TestURLRepository_Conformance(t *testing.T) {
+	repositorytest.RunURLRepositorySuite(t, func(t *testing.T) ports.URLRepository {
+		truncate(t, "url_tags", "tags", "urls", "folders")
+		return NewURLRepository(testDB)
+	})
+}
+
+func TestTagRepository_Conformance(t *testing.T) {
+	repositorytest.RunTagRepositorySuite(t, func(t *testing.T) (ports.TagRepository, ports.URLRepository) {
+		truncate(t, "url_tags", "tags", "urls")
+		return NewTagRepository(testDB), NewURLRepository(testDB)
+	})
+}
+
+func TestFolderRepository_Conformance(t *testing.T) {
+	repositorytest.RunFolderRepositorySuite(t, func(t *testing.T) (ports.FolderRepository, ports.URLRepository) {
+		truncate(t, "url_tags", "tags", "urls", "folders")
+		return NewFolderRepository(testDB), NewURLRepository(testDB)
+	})
+}
+
+func TestUserRepository_Conformance(t *testing.T) {
+	repositorytest.RunUserRepositorySuite(t, func(t *testing.T) ports.UserRepository {
+		truncate(t, "users")
+		return NewUserRepository(testDB)
+	})
+}
+
+func TestIdempotencyRepository_Conformance(t *testing.T) {
+	repositorytest.RunIdempotencyRepositorySuite(t, func(t *testing.T) ports.IdempotencyRepository {
+		truncate(t, "idempotency_keys")
+		return NewIdempotencyRepository(testDB)
+	})
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports"
+)
+
+// FolderRepository implementa ports.FolderRepository
+type FolderRepository struct {
+	BaseRepository
+}
+
+// NewFolderRepository crea una nueva instancia del repositorio de carpetas
+func NewFolderRepository(db *gorm.DB, opts ...Option) ports.FolderRepository {
+	return &FolderRepository{
+		BaseRepository: newBaseRepository(db, opts...),
+	}
+}
+
+// List obtiene las carpetas del usuario ordenadas por nombre
+func (r *FolderRepository) List(ctx context.Context, userID uint) ([]model.Folder, error) {
+	folders := []model.Folder{}
+	result := r.read(ctx, func(db *gorm.DB) *gorm.DB {
+		return db.Where("user_id = ?", userID).Order("name").Find(&folders)
+	})
+	if result.Error != nil {
+		return nil, errors.Wrap(result.Error, "error al listar carpetas")
+	}
+	return folders, nil
+}
+
+// GetByID busca una carpeta del usuario por su ID
+func (r *FolderRepository) GetByID(ctx context.Context, userID, id uint) (*model.Folder, error) {
+	var folder model.Folder
+	err := r.findOne(ctx, &folder, "id = ? AND user_id = ?", id, userID)
+	if err := r.handleGormError(err, errors.ErrFolderNotFound, "error al buscar carpeta"); err != nil {
+		return nil, err
+	}
+	return &folder, nil
+}
+
+// Create guarda una nueva carpeta en la base de datos
+func (r *FolderRepository) Create(ctx context.Context, folder *model.Folder) error {
+	err := r.create(ctx, folder)
+	return r.handleGormError(err, nil, "error al crear carpeta")
+}
+
+// Rename cambia el nombre de una carpeta existente
+func (r *FolderRepository) Rename(ctx context.Context, folder *model.Folder) error {
+	result := r.write(ctx, func(db *gorm.DB) *gorm.DB {
+		return db.Model(folder).Where("user_id = ?", folder.UserID).Update("name", folder.Name)
+	})
+	if result.Error != nil {
+		return r.handleGormError(result.Error, nil, "error al renombrar carpeta")
+	}
+	if result.RowsAffected == 0 {
+		return errors.ErrFolderNotFound
+	}
+	return nil
+}
+
+// Delete elimina una carpeta del usuario. Sus URLs quedan sin carpeta por
+// la clave foránea.
+func (r *FolderRepository) Delete(ctx context.Context, userID, id uint) error {
+	rowsAffected, err := r.delete(ctx, &model.Folder{}, "id = ? AND user_id = ?", id, userID)
+	if err != nil {
+		return errors.Wrap(err, "error al eliminar carpeta")
+	}
+	if rowsAffected == 0 {
+		return errors.ErrFolderNotFound
+	}
+	return nil
+}
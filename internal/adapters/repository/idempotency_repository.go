@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports"
+)
+
+// IdempotencyRepository implementa ports.IdempotencyRepository
+type IdempotencyRepository struct {
+	BaseRepository
+}
+
+// NewIdempotencyRepository crea una nueva instancia del repositorio de idempotencia
+func NewIdempotencyRepository(db *gorm.DB, opts ...Option) ports.IdempotencyRepository {
+	return &IdempotencyRepository{
+		BaseRepository: newBaseRepository(db, opts...),
+	}
+}
+
+// Create reserva la clave de un usuario
+func (r *IdempotencyRepository) Create(ctx context.Context, record *model.IdempotencyRecord) error {
+	if record.Body == nil {
+		record.Body = []byte{}
+	}
+	err := r.create(ctx, record)
+	return r.handleGormError(err, nil, "error al reservar la clave de idempotencia")
+}
+
+// Get busca el registro de un usuario por su clave
+func (r *IdempotencyRepository) Get(ctx context.Context, userID uint, key string) (*model.IdempotencyRecord, error) {
+	var record model.IdempotencyRecord
+	err := r.findOne(ctx, &record, "user_id = ? AND idempotency_key = ?", userID, key)
+	if err := r.handleGormError(err, errors.ErrRecordNotFound, "error al buscar la clave de idempotencia"); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Update guarda la respuesta de un registro ya reservado
+func (r *IdempotencyRepository) Update(ctx context.Context, record *model.IdempotencyRecord) error {
+	if record.Body == nil {
+		record.Body = []byte{}
+	}
+	result := r.write(ctx, func(db *gorm.DB) *gorm.DB {
+		return db.Model(&model.IdempotencyRecord{}).
+			Where("user_id = ? AND idempotency_key = ?", record.UserID, record.Key).
+			Updates(map[string]interface{}{
+				"status_code":  record.StatusCode,
+				"content_type": record.ContentType,
+				"body":         record.Body,
+				"expires_at":   record.ExpiresAt,
+			})
+	})
+	if result.Error != nil {
+		return errors.Wrap(result.Error, "error al guardar la respuesta idempotente")
+	}
+	if result.RowsAffected == 0 {
+		return errors.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Delete libera la clave de un usuario
+func (r *IdempotencyRepository) Delete(ctx context.Context, userID uint, key string) error {
+	_, err := r.delete(ctx, &model.IdempotencyRecord{}, "user_id = ? AND idempotency_key = ?", userID, key)
+	if err != nil {
+		return errors.Wrap(err, "error al liberar la clave de idempotencia")
+	}
+	return nil
+}
+
+// DeleteExpired elimina los registros caducados en now
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	rowsAffected, err := r.delete(ctx, &model.IdempotencyRecord{}, "expires_at <= ?", now)
+	if err != nil {
+		return 0, errors.Wrap(err, "error al purgar las claves de idempotencia")
+	}
+	return rowsAffected, nil
+}
@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports"
+)
+
+// FolderRepository implementa ports.FolderRepository en memoria
+type FolderRepository struct {
+	store *Store
+}
+
+// NewFolderRepository crea un repositorio de carpetas sobre el almacén indicado
+func NewFolderRepository(store *Store) ports.FolderRepository {
+	return &FolderRepository{store: store}
+}
+
+// List obtiene las carpetas del usuario ordenadas por nombre
+func (r *FolderRepository) List(ctx context.Context, userID uint) ([]model.Folder, error) {
+	folders := []model.Folder{}
+	err := r.store.read(ctx, func(d *data) error {
+		for _, folder := range d.folders {
+			if folder.UserID == userID {
+				folders = append(folders, *folder)
+			}
+		}
+		slices.SortFunc(folders, func(a, b model.Folder) int { return strings.Compare(a.Name, b.Name) })
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return folders, nil
+}
+
+// GetByID busca una carpeta del usuario por su ID
+func (r *FolderRepository) GetByID(ctx context.Context, userID, id uint) (*model.Folder, error) {
+	var found model.Folder
+	err := r.store.read(ctx, func(d *data) error {
+		folder, ok := d.folders[id]
+		if !ok || folder.UserID != userID {
+			return errors.ErrFolderNotFound
+		}
+		found = *folder
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &found, nil
+}
+
+// Create guarda una nueva carpeta. El nombre debe ser único para el usuario.
+func (r *FolderRepository) Create(ctx context.Context, folder *model.Folder) error {
+	return r.store.write(ctx, func(d *data) error {
+		key := folderKey{userID: folder.UserID, name: folder.Name}
+		if _, ok := d.foldersByName[key]; ok {
+			return errors.ErrDuplicateKey
+		}
+
+		// Asignar la clave y las marcas de tiempo como lo haría la base de datos
+		now := time.Now()
+		d.nextFolderID++
+		folder.ID = d.nextFolderID
+		if folder.CreatedAt.IsZero() {
+			folder.CreatedAt = now
+		}
+		folder.UpdatedAt = now
+		stored := *folder
+		d.folders[folder.ID] = &stored
+		d.foldersByName[key] = folder.ID
+		return nil
+	})
+}
+
+// Rename cambia el nombre de una carpeta existente
+func (r *FolderRepository) Rename(ctx context.Context, folder *model.Folder) error {
+	return r.store.write(ctx, func(d *data) error {
+		stored, ok := d.folders[folder.ID]
+		if !ok || stored.UserID != folder.UserID {
+			return errors.ErrFolderNotFound
+		}
+		key := folderKey{userID: folder.UserID, name: folder.Name}
+		if id, ok := d.foldersByName[key]; ok && id != folder.ID {
+			return errors.ErrDuplicateKey
+		}
+
+		delete(d.foldersByName, folderKey{userID: stored.UserID, name: stored.Name})
+		d.foldersByName[key] = folder.ID
+		stored.Name = folder.Name
+		stored.UpdatedAt = time.Now()
+		folder.UpdatedAt = stored.UpdatedAt
+		return nil
+	})
+}
+
+// Delete elimina una carpeta del usuario. Sus URLs, también las de la
+// papelera, quedan sin carpeta como con la clave foránea de SQL.
+func (r *FolderRepository) Delete(ctx context.Context, userID, id uint) error {
+	return r.store.write(ctx, func(d *data) error {
+		folder, ok := d.folders[id]
+		if !ok || folder.UserID != userID {
+			return errors.ErrFolderNotFound
+		}
+		delete(d.folders, id)
+		delete(d.foldersByName, folderKey{userID: folder.UserID, name: folder.Name})
+		for _, url := range d.urls {
+			if url.FolderID != nil && *url.FolderID == id {
+				url.FolderID = nil
+			}
+		}
+		return nil
+	})
+}
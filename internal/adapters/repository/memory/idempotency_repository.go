@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports"
+)
+
+// IdempotencyRepository implementa ports.IdempotencyRepository en memoria
+type IdempotencyRepository struct {
+	store *Store
+}
+
+// NewIdempotencyRepository crea un repositorio de idempotencia sobre el almacén indicado
+func NewIdempotencyRepository(store *Store) ports.IdempotencyRepository {
+	return &IdempotencyRepository{store: store}
+}
+
+// Create reserva la clave de un usuario. La pareja usuario y clave debe ser única.
+func (r *IdempotencyRepository) Create(ctx context.Context, record *model.IdempotencyRecord) error {
+	return r.store.write(ctx, func(d *data) error {
+		key := idempotencyKey{record.UserID, record.Key}
+		if _, ok := d.idempotency[key]; ok {
+			return errors.ErrDuplicateKey
+		}
+
+		d.nextIdempotencyID++
+		record.ID = d.nextIdempotencyID
+		if record.CreatedAt.IsZero() {
+			record.CreatedAt = time.Now()
+		}
+
+		d.idempotency[key] = copyIdempotencyRecord(record)
+		return nil
+	})
+}
+
+// Get busca el registro de un usuario por su clave
+func (r *IdempotencyRepository) Get(ctx context.Context, userID uint, key string) (*model.IdempotencyRecord, error) {
+	var found *model.IdempotencyRecord
+	err := r.store.read(ctx, func(d *data) error {
+		record, ok := d.idempotency[idempotencyKey{userID, key}]
+		if !ok {
+			return errors.ErrRecordNotFound
+		}
+		found = copyIdempotencyRecord(record)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// Update guarda la respuesta de un registro ya reservado
+func (r *IdempotencyRepository) Update(ctx context.Context, record *model.IdempotencyRecord) error {
+	return r.store.write(ctx, func(d *data) error {
+		stored, ok := d.idempotency[idempotencyKey{record.UserID, record.Key}]
+		if !ok {
+			return errors.ErrRecordNotFound
+		}
+		stored.StatusCode = record.StatusCode
+		stored.ContentType = record.ContentType
+		stored.Body = append([]byte(nil), record.Body...)
+		stored.ExpiresAt = record.ExpiresAt
+		return nil
+	})
+}
+
+// Delete libera la clave de un usuario
+func (r *IdempotencyRepository) Delete(ctx context.Context, userID uint, key string) error {
+	return r.store.write(ctx, func(d *data) error {
+		delete(d.idempotency, idempotencyKey{userID, key})
+		return nil
+	})
+}
+
+// DeleteExpired elimina los registros caducados en now
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	var deleted int64
+	err := r.store.write(ctx, func(d *data) error {
+		for key, record := range d.idempotency {
+			if record.Expired(now) {
+				delete(d.idempotency, key)
+				deleted++
+			}
+		}
+		return nil
+	})
+	return deleted, err
+}
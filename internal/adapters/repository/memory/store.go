@@ -0,0 +1,189 @@
+// Package memory implementa los puertos de repositorio en memoria. Está pensado
+// para pruebas, demostraciones y el modo de almacenamiento "memory" del
+// servidor; los datos se pierden al detener el proceso.
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"tiny-url/internal/domain/model"
+)
+
+// Store contiene los datos compartidos por los repositorios en memoria.
+// Todas las operaciones son seguras para uso concurrente.
+type Store struct {
+	mu   sync.RWMutex
+	data *data
+}
+
+// data agrupa las tablas en memoria y sus índices únicos
+type data struct {
+	urls       map[uint]*model.URL
+	urlsByCode map[string]uint
+	users      map[uint]*model.User
+	nextURLID  uint
+	nextUserID uint
+
+	// tags guarda las etiquetas de cada usuario; las URLs llevan copia de las suyas
+	tags       map[uint]*model.Tag
+	tagsByName map[tagKey]uint
+	nextTagID  uint
+
+	// folders guarda las carpetas de cada usuario
+	folders       map[uint]*model.Folder
+	foldersByName map[folderKey]uint
+	nextFolderID  uint
+
+	// idempotency guarda los registros de idempotencia por usuario y clave
+	idempotency       map[idempotencyKey]*model.IdempotencyRecord
+	nextIdempotencyID uint
+}
+
+// tagKey es la clave única de una etiqueta
+type tagKey struct {
+	userID uint
+	name   string
+}
+
+// folderKey es la clave única de una carpeta
+type folderKey struct {
+	userID uint
+	name   string
+}
+
+// idempotencyKey es la clave única de un registro de idempotencia
+type idempotencyKey struct {
+	userID uint
+	key    string
+}
+
+// NewStore crea un almacén vacío
+func NewStore() *Store {
+	return &Store{data: newData()}
+}
+
+// newData crea unas tablas vacías
+func newData() *data {
+	return &data{
+		urls:       make(map[uint]*model.URL),
+		urlsByCode: make(map[string]uint),
+		users:      make(map[uint]*model.User),
+		tags:       make(map[uint]*model.Tag),
+		tagsByName: make(map[tagKey]uint),
+
+		folders:       make(map[uint]*model.Folder),
+		foldersByName: make(map[folderKey]uint),
+
+		idempotency: make(map[idempotencyKey]*model.IdempotencyRecord),
+	}
+}
+
+// clone devuelve una copia profunda de las tablas
+func (d *data) clone() *data {
+	c := &data{
+		urls:       make(map[uint]*model.URL, len(d.urls)),
+		urlsByCode: make(map[string]uint, len(d.urlsByCode)),
+		users:      make(map[uint]*model.User, len(d.users)),
+		nextURLID:  d.nextURLID,
+		nextUserID: d.nextUserID,
+		tags:       make(map[uint]*model.Tag, len(d.tags)),
+		tagsByName: make(map[tagKey]uint, len(d.tagsByName)),
+		nextTagID:  d.nextTagID,
+
+		folders:       make(map[uint]*model.Folder, len(d.folders)),
+		foldersByName: make(map[folderKey]uint, len(d.foldersByName)),
+		nextFolderID:  d.nextFolderID,
+
+		idempotency:       make(map[idempotencyKey]*model.IdempotencyRecord, len(d.idempotency)),
+		nextIdempotencyID: d.nextIdempotencyID,
+	}
+	for id, url := range d.urls {
+		c.urls[id] = copyURL(url)
+	}
+	for code, id := range d.urlsByCode {
+		c.urlsByCode[code] = id
+	}
+	for id, user := range d.users {
+		c.users[id] = copyUser(user)
+	}
+	for id, tag := range d.tags {
+		tag := *tag
+		c.tags[id] = &tag
+	}
+	for key, id := range d.tagsByName {
+		c.tagsByName[key] = id
+	}
+	for id, folder := range d.folders {
+		folder := *folder
+		c.folders[id] = &folder
+	}
+	for key, id := range d.foldersByName {
+		c.foldersByName[key] = id
+	}
+	for key, record := range d.idempotency {
+		c.idempotency[key] = copyIdempotencyRecord(record)
+	}
+	return c
+}
+
+// read ejecuta fn con acceso de lectura a las tablas
+func (s *Store) read(ctx context.Context, fn func(d *data) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return fn(s.data)
+}
+
+// write ejecuta fn con acceso exclusivo a las tablas
+func (s *Store) write(ctx context.Context, fn func(d *data) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fn(s.data)
+}
+
+// copyURL devuelve una copia independiente de url
+func copyURL(url *model.URL) *model.URL {
+	c := *url
+	if url.ExpiresAt != nil {
+		expiresAt := *url.ExpiresAt
+		c.ExpiresAt = &expiresAt
+	}
+	if url.FolderID != nil {
+		folderID := *url.FolderID
+		c.FolderID = &folderID
+	}
+	if url.MaxVisits != nil {
+		maxVisits := *url.MaxVisits
+		c.MaxVisits = &maxVisits
+	}
+	if url.ActiveFrom != nil {
+		activeFrom := *url.ActiveFrom
+		c.ActiveFrom = &activeFrom
+	}
+	if url.ActiveUntil != nil {
+		activeUntil := *url.ActiveUntil
+		c.ActiveUntil = &activeUntil
+	}
+	c.Tags = append([]model.Tag{}, url.Tags...)
+	c.DeviceRules = append([]model.DeviceRule{}, url.DeviceRules...)
+	return &c
+}
+
+// copyUser devuelve una copia independiente de user
+func copyUser(user *model.User) *model.User {
+	c := *user
+	return &c
+}
+
+// copyIdempotencyRecord devuelve una copia independiente de record
+func copyIdempotencyRecord(record *model.IdempotencyRecord) *model.IdempotencyRecord {
+	c := *record
+	c.Body = append([]byte(nil), record.Body...)
+	return &c
+}
@@ -0,0 +1,118 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports"
+)
+
+// TagRepository implementa ports.TagRepository en memoria
+type TagRepository struct {
+	store *Store
+}
+
+// NewTagRepository crea un repositorio de etiquetas sobre el almacén indicado
+func NewTagRepository(store *Store) ports.TagRepository {
+	return &TagRepository{store: store}
+}
+
+// List obtiene las etiquetas del usuario ordenadas por nombre
+func (r *TagRepository) List(ctx context.Context, userID uint) ([]model.Tag, error) {
+	tags := []model.Tag{}
+	err := r.store.read(ctx, func(d *data) error {
+		for _, tag := range d.tags {
+			if tag.UserID == userID {
+				tags = append(tags, *tag)
+			}
+		}
+		sortTags(tags)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// GetByID busca una etiqueta del usuario por su ID
+func (r *TagRepository) GetByID(ctx context.Context, userID, id uint) (*model.Tag, error) {
+	var found model.Tag
+	err := r.store.read(ctx, func(d *data) error {
+		tag, ok := d.tags[id]
+		if !ok || tag.UserID != userID {
+			return errors.ErrTagNotFound
+		}
+		found = *tag
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &found, nil
+}
+
+// Create guarda una nueva etiqueta. El nombre debe ser único para el usuario.
+func (r *TagRepository) Create(ctx context.Context, tag *model.Tag) error {
+	return r.store.write(ctx, func(d *data) error {
+		key := tagKey{userID: tag.UserID, name: tag.Name}
+		if _, ok := d.tagsByName[key]; ok {
+			return errors.ErrDuplicateKey
+		}
+
+		// Asignar la clave y la fecha de creación como lo haría la base de datos
+		d.nextTagID++
+		tag.ID = d.nextTagID
+		if tag.CreatedAt.IsZero() {
+			tag.CreatedAt = time.Now()
+		}
+		stored := *tag
+		d.tags[tag.ID] = &stored
+		d.tagsByName[key] = tag.ID
+		return nil
+	})
+}
+
+// Rename cambia el nombre de una etiqueta existente y de sus copias en las URLs
+func (r *TagRepository) Rename(ctx context.Context, tag *model.Tag) error {
+	return r.store.write(ctx, func(d *data) error {
+		stored, ok := d.tags[tag.ID]
+		if !ok || stored.UserID != tag.UserID {
+			return errors.ErrTagNotFound
+		}
+		key := tagKey{userID: tag.UserID, name: tag.Name}
+		if id, ok := d.tagsByName[key]; ok && id != tag.ID {
+			return errors.ErrDuplicateKey
+		}
+
+		delete(d.tagsByName, tagKey{userID: stored.UserID, name: stored.Name})
+		d.tagsByName[key] = tag.ID
+		stored.Name = tag.Name
+		for _, url := range d.urls {
+			if i := slices.IndexFunc(url.Tags, func(t model.Tag) bool { return t.ID == tag.ID }); i >= 0 {
+				url.Tags[i].Name = tag.Name
+				sortTags(url.Tags)
+			}
+		}
+		return nil
+	})
+}
+
+// Delete elimina una etiqueta del usuario y la quita de sus URLs
+func (r *TagRepository) Delete(ctx context.Context, userID, id uint) error {
+	return r.store.write(ctx, func(d *data) error {
+		tag, ok := d.tags[id]
+		if !ok || tag.UserID != userID {
+			return errors.ErrTagNotFound
+		}
+		delete(d.tags, id)
+		delete(d.tagsByName, tagKey{userID: tag.UserID, name: tag.Name})
+		for _, url := range d.urls {
+			url.Tags = slices.DeleteFunc(url.Tags, func(t model.Tag) bool { return t.ID == id })
+		}
+		return nil
+	})
+}
@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"context"
+
+	"tiny-url/internal/domain/ports"
+)
+
+// UnitOfWork implementa ports.UnitOfWork sobre un almacén en memoria
+type UnitOfWork struct {
+	store *Store
+}
+
+// NewUnitOfWork crea una unidad de trabajo sobre el almacén indicado
+func NewUnitOfWork(store *Store) ports.UnitOfWork {
+	return &UnitOfWork{store: store}
+}
+
+// txRepos expone los repositorios ligados a una transacción concreta
+type txRepos struct {
+	urls    ports.URLRepository
+	users   ports.UserRepository
+	folders ports.FolderRepository
+}
+
+// URLs devuelve el repositorio de URLs de la transacción
+func (r *txRepos) URLs() ports.URLRepository {
+	return r.urls
+}
+
+// Users devuelve el repositorio de usuarios de la transacción
+func (r *txRepos) Users() ports.UserRepository {
+	return r.users
+}
+
+// Folders devuelve el repositorio de carpetas de la transacción
+func (r *txRepos) Folders() ports.FolderRepository {
+	return r.folders
+}
+
+// Do ejecuta fn sobre una copia de los datos y la publica solo si fn no
+// devuelve error. Las transacciones se serializan y bloquean al resto de
+// operaciones del almacén, por lo que fn solo debe usar los repositorios
+// recibidos.
+func (u *UnitOfWork) Do(ctx context.Context, fn func(tx ports.Repos) error) error {
+	return u.store.write(ctx, func(d *data) error {
+		tx := &Store{data: d.clone()}
+		if err := fn(&txRepos{
+			urls:    NewURLRepository(tx),
+			users:   NewUserRepository(tx),
+			folders: NewFolderRepository(tx),
+		}); err != nil {
+			return err
+		}
+
+		u.store.data = tx.data
+		return nil
+	})
+}
@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitOfWork_Commit(t *testing.T) {
+	// Arrange
+	store := NewStore()
+	uow := NewUnitOfWork(store)
+	ctx := context.Background()
+
+	// Act
+	err := uow.Do(ctx, func(repos ports.Repos) error {
+		if err := repos.Users().CreateUser(ctx, &model.User{Username: "usuario", Email: "a@ejemplo.com"}); err != nil {
+			return err
+		}
+		return repos.URLs().Create(ctx, &model.URL{OriginalURL: "https://www.ejemplo.com", ShortCode: "abc123"})
+	})
+
+	// Assert
+	require.NoError(t, err)
+	_, err = NewUserRepository(store).GetByUsername(ctx, "usuario")
+	assert.NoError(t, err)
+	_, err = NewURLRepository(store).GetByShortCode(ctx, "abc123")
+	assert.NoError(t, err)
+}
+
+func TestUnitOfWork_Rollback(t *testing.T) {
+	// Arrange
+	store := NewStore()
+	uow := NewUnitOfWork(store)
+	ctx := context.Background()
+	failure := errors.New("fallo simulado")
+
+	// Act
+	err := uow.Do(ctx, func(repos ports.Repos) error {
+		if err := repos.Users().CreateUser(ctx, &model.User{Username: "usuario", Email: "a@ejemplo.com"}); err != nil {
+			return err
+		}
+		if err := repos.URLs().Create(ctx, &model.URL{OriginalURL: "https://www.ejemplo.com", ShortCode: "abc123"}); err != nil {
+			return err
+		}
+		return failure
+	})
+
+	// Assert
+	assert.Equal(t, failure, err)
+	_, err = NewUserRepository(store).GetByUsername(ctx, "usuario")
+	assert.Equal(t, errors.ErrUserNotFound, err)
+	_, err = NewURLRepository(store).GetByShortCode(ctx, "abc123")
+	assert.Equal(t, errors.ErrURLNotFound, err)
+}
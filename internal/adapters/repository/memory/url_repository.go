@@ -0,0 +1,361 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports"
+)
+
+// URLRepository implementa ports.URLRepository en memoria
+type URLRepository struct {
+	store *Store
+}
+
+// NewURLRepository crea un repositorio de URLs sobre el almacén indicado
+func NewURLRepository(store *Store) ports.URLRepository {
+	return &URLRepository{store: store}
+}
+
+// Create guarda una nueva URL. El código corto debe ser único.
+func (r *URLRepository) Create(ctx context.Context, url *model.URL) error {
+	return r.store.write(ctx, func(d *data) error {
+		if _, ok := d.urlsByCode[url.ShortCode]; ok {
+			return errors.ErrDuplicateKey
+		}
+
+		// Asignar la clave y las marcas de tiempo como lo haría la base de datos
+		now := time.Now()
+		d.nextURLID++
+		url.ID = d.nextURLID
+		if url.CreatedAt.IsZero() {
+			url.CreatedAt = now
+		}
+		if url.UpdatedAt.IsZero() {
+			url.UpdatedAt = now
+		}
+
+		// Las etiquetas se asignan con AddTags y las reglas con SetDeviceRules
+		stored := copyURL(url)
+		stored.Tags = []model.Tag{}
+		stored.DeviceRules = []model.DeviceRule{}
+		d.urls[url.ID] = stored
+		d.urlsByCode[url.ShortCode] = url.ID
+		return nil
+	})
+}
+
+// GetByShortCode busca una URL por su código corto fuera de la papelera
+func (r *URLRepository) GetByShortCode(ctx context.Context, shortCode string) (*model.URL, error) {
+	return r.getByShortCode(ctx, shortCode, false)
+}
+
+// GetDeletedByShortCode busca una URL de la papelera por su código corto
+func (r *URLRepository) GetDeletedByShortCode(ctx context.Context, shortCode string) (*model.URL, error) {
+	return r.getByShortCode(ctx, shortCode, true)
+}
+
+// getByShortCode busca una URL por su código corto dentro o fuera de la papelera
+func (r *URLRepository) getByShortCode(ctx context.Context, shortCode string, trashed bool) (*model.URL, error) {
+	var found *model.URL
+	err := r.store.read(ctx, func(d *data) error {
+		url, ok := d.urlByCode(shortCode, trashed)
+		if !ok {
+			return errors.ErrURLNotFound
+		}
+		found = copyURL(url)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// urlByCode devuelve la URL guardada con el código corto si está en la
+// papelera (trashed) o fuera de ella
+func (d *data) urlByCode(shortCode string, trashed bool) (*model.URL, bool) {
+	id, ok := d.urlsByCode[shortCode]
+	if !ok || d.urls[id].DeletedAt.Valid != trashed {
+		return nil, false
+	}
+	return d.urls[id], true
+}
+
+// liveURL devuelve la URL guardada con el ID si existe fuera de la papelera
+func (d *data) liveURL(id uint) (*model.URL, bool) {
+	url, ok := d.urls[id]
+	if !ok || url.DeletedAt.Valid {
+		return nil, false
+	}
+	return url, true
+}
+
+// GetByOriginalURL busca la URL de un usuario por su URL original. Devuelve
+// (nil, nil) si no existe.
+func (r *URLRepository) GetByOriginalURL(ctx context.Context, userID uint, originalURL string) (*model.URL, error) {
+	var found *model.URL
+	err := r.store.read(ctx, func(d *data) error {
+		// Con varias coincidencias se devuelve la más antigua, igual que First en GORM
+		for _, url := range d.urls {
+			if url.UserID == userID && url.OriginalURL == originalURL && !url.DeletedAt.Valid && (found == nil || url.ID < found.ID) {
+				found = url
+			}
+		}
+		if found != nil {
+			found = copyURL(found)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// Update guarda el propietario, la caducidad y la carpeta de una URL existente
+func (r *URLRepository) Update(ctx context.Context, url *model.URL) error {
+	return r.store.write(ctx, func(d *data) error {
+		stored, ok := d.liveURL(url.ID)
+		if !ok {
+			return errors.ErrURLNotFound
+		}
+		updated := copyURL(url)
+		stored.UserID = updated.UserID
+		stored.ExpiresAt = updated.ExpiresAt
+		stored.FolderID = updated.FolderID
+		stored.MaxVisits = updated.MaxVisits
+		stored.RedirectCode = updated.RedirectCode
+		stored.ForwardQuery = updated.ForwardQuery
+		stored.ForwardPath = updated.ForwardPath
+		stored.UpdatedAt = time.Now()
+		url.UpdatedAt = stored.UpdatedAt
+		return nil
+	})
+}
+
+// AddTags añade etiquetas del propietario a la URL, creando las que falten
+func (r *URLRepository) AddTags(ctx context.Context, url *model.URL, names []string) error {
+	return r.store.write(ctx, func(d *data) error {
+		stored, ok := d.liveURL(url.ID)
+		if !ok {
+			return errors.ErrURLNotFound
+		}
+		for _, name := range names {
+			tag := d.tagFor(stored.UserID, name)
+			if !slices.ContainsFunc(stored.Tags, func(t model.Tag) bool { return t.ID == tag.ID }) {
+				stored.Tags = append(stored.Tags, *tag)
+			}
+		}
+		sortTags(stored.Tags)
+		url.Tags = copyURL(stored).Tags
+		return nil
+	})
+}
+
+// RemoveTags quita etiquetas de la URL
+func (r *URLRepository) RemoveTags(ctx context.Context, url *model.URL, names []string) error {
+	return r.store.write(ctx, func(d *data) error {
+		stored, ok := d.liveURL(url.ID)
+		if !ok {
+			return errors.ErrURLNotFound
+		}
+		stored.Tags = slices.DeleteFunc(stored.Tags, func(t model.Tag) bool { return slices.Contains(names, t.Name) })
+		url.Tags = copyURL(stored).Tags
+		return nil
+	})
+}
+
+// tagFor devuelve la etiqueta del usuario con ese nombre, creándola si no existe
+func (d *data) tagFor(userID uint, name string) *model.Tag {
+	key := tagKey{userID: userID, name: name}
+	if id, ok := d.tagsByName[key]; ok {
+		return d.tags[id]
+	}
+	d.nextTagID++
+	tag := &model.Tag{ID: d.nextTagID, UserID: userID, Name: name, CreatedAt: time.Now()}
+	d.tags[tag.ID] = tag
+	d.tagsByName[key] = tag.ID
+	return tag
+}
+
+// sortTags ordena las etiquetas por nombre, como las devuelven los repositorios SQL
+func sortTags(tags []model.Tag) {
+	slices.SortFunc(tags, func(a, b model.Tag) int { return strings.Compare(a.Name, b.Name) })
+}
+
+// SetDeviceRules sustituye las reglas por dispositivo de la URL
+func (r *URLRepository) SetDeviceRules(ctx context.Context, url *model.URL, rules []model.DeviceRule) error {
+	return r.store.write(ctx, func(d *data) error {
+		stored, ok := d.liveURL(url.ID)
+		if !ok {
+			return errors.ErrURLNotFound
+		}
+		stored.DeviceRules = make([]model.DeviceRule, len(rules))
+		for i, rule := range rules {
+			stored.DeviceRules[i] = model.DeviceRule{URLID: url.ID, Platform: rule.Platform, Destination: rule.Destination}
+		}
+		slices.SortFunc(stored.DeviceRules, func(a, b model.DeviceRule) int {
+			return strings.Compare(string(a.Platform), string(b.Platform))
+		})
+		url.DeviceRules = copyURL(stored).DeviceRules
+		return nil
+	})
+}
+
+// IncrementVisits incrementa el contador de visitas de una URL si no ha
+// alcanzado su límite
+func (r *URLRepository) IncrementVisits(ctx context.Context, shortCode string) (bool, error) {
+	allowed := false
+	err := r.store.write(ctx, func(d *data) error {
+		url, ok := d.urlByCode(shortCode, false)
+		if !ok {
+			return errors.ErrURLNotFound
+		}
+		if url.Exhausted() {
+			return nil
+		}
+		url.Visits++
+		allowed = true
+		return nil
+	})
+	return allowed, err
+}
+
+// List obtiene una página de URLs filtrada y ordenada junto con el total.
+// Un límite negativo devuelve todas las URLs a partir del desplazamiento.
+func (r *URLRepository) List(ctx context.Context, query model.URLQuery) ([]*model.URL, int64, error) {
+	var urls []*model.URL
+	var total int64
+	err := r.store.read(ctx, func(d *data) error {
+		matches := make([]*model.URL, 0, len(d.urls))
+		for _, url := range d.urls {
+			if query.Filter.Matches(url) {
+				matches = append(matches, url)
+			}
+		}
+		sortURLs(matches, query.Sort)
+		total = int64(len(matches))
+
+		if query.Cursor != nil {
+			matches = seek(matches, *query.Cursor, query.Limit)
+		} else {
+			matches = paginate(matches, query.Limit, query.Offset)
+		}
+		urls = make([]*model.URL, 0, len(matches))
+		for _, url := range matches {
+			urls = append(urls, copyURL(url))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return urls, total, nil
+}
+
+// Delete mueve una URL a la papelera por su código corto. El código sigue
+// ocupado hasta que se purga.
+func (r *URLRepository) Delete(ctx context.Context, shortCode string) error {
+	return r.store.write(ctx, func(d *data) error {
+		url, ok := d.urlByCode(shortCode, false)
+		if !ok {
+			return errors.ErrURLNotFound
+		}
+		url.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+		return nil
+	})
+}
+
+// Restore saca de la papelera una URL por su código corto
+func (r *URLRepository) Restore(ctx context.Context, shortCode string) error {
+	return r.store.write(ctx, func(d *data) error {
+		url, ok := d.urlByCode(shortCode, true)
+		if !ok {
+			return errors.ErrURLNotFound
+		}
+		url.DeletedAt = gorm.DeletedAt{}
+		return nil
+	})
+}
+
+// PurgeDeleted elimina definitivamente las URLs que entraron en la papelera antes de before
+func (r *URLRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := r.store.write(ctx, func(d *data) error {
+		for id, url := range d.urls {
+			if url.DeletedAt.Valid && url.DeletedAt.Time.Before(before) {
+				delete(d.urls, id)
+				delete(d.urlsByCode, url.ShortCode)
+				purged++
+			}
+		}
+		return nil
+	})
+	return purged, err
+}
+
+// sortURLs ordena las URLs por el campo pedido, desempatando por ID igual
+// que los repositorios SQL
+func sortURLs(urls []*model.URL, order model.URLSort) {
+	less := func(a, b *model.URL) bool {
+		switch order.Field() {
+		case "visits":
+			if a.Visits != b.Visits {
+				return a.Visits < b.Visits
+			}
+		default:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.Before(b.CreatedAt)
+			}
+		}
+		return a.ID < b.ID
+	}
+	sort.Slice(urls, func(i, j int) bool {
+		if order.Desc() {
+			return less(urls[j], urls[i])
+		}
+		return less(urls[i], urls[j])
+	})
+}
+
+// seek devuelve hasta limit URLs inmediatamente posteriores a la posición del
+// cursor, o anteriores si cursor.Before, de una lista ya ordenada
+func seek(urls []*model.URL, cursor model.URLCursor, limit int) []*model.URL {
+	// Primera URL posterior al cursor
+	start := sort.Search(len(urls), func(i int) bool { return cursor.Compare(urls[i]) > 0 })
+	if !cursor.Before {
+		return paginate(urls[start:], limit, 0)
+	}
+
+	// Las anteriores terminan justo antes de la URL del cursor, si sigue existiendo
+	end := start
+	if end > 0 && cursor.Compare(urls[end-1]) == 0 {
+		end--
+	}
+	if limit >= 0 && end > limit {
+		return urls[end-limit : end]
+	}
+	return urls[:end]
+}
+
+// paginate aplica desplazamiento y límite a una lista ordenada
+func paginate[T any](items []T, limit, offset int) []T {
+	if offset > 0 {
+		if offset >= len(items) {
+			return nil
+		}
+		items = items[offset:]
+	}
+	if limit >= 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
@@ -0,0 +1,153 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURLRepository_Create_GetByShortCode(t *testing.T) {
+	// Arrange
+	repo := NewURLRepository(NewStore())
+	ctx := context.Background()
+	url := &model.URL{OriginalURL: "https://www.ejemplo.com", ShortCode: "abc123"}
+
+	// Act
+	err := repo.Create(ctx, url)
+	require.NoError(t, err)
+	retrievedURL, err := repo.GetByShortCode(ctx, "abc123")
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotZero(t, url.ID)
+	assert.False(t, url.CreatedAt.IsZero())
+	assert.Equal(t, url.ID, retrievedURL.ID)
+	assert.Equal(t, url.OriginalURL, retrievedURL.OriginalURL)
+}
+
+func TestURLRepository_Create_DuplicateShortCode(t *testing.T) {
+	// Arrange
+	repo := NewURLRepository(NewStore())
+	ctx := context.Background()
+	require.NoError(t, repo.Create(ctx, &model.URL{OriginalURL: "https://a.com", ShortCode: "abc123"}))
+
+	// Act
+	err := repo.Create(ctx, &model.URL{OriginalURL: "https://b.com", ShortCode: "abc123"})
+
+	// Assert
+	assert.Equal(t, errors.ErrDuplicateKey, err)
+}
+
+func TestURLRepository_ReturnsCopies(t *testing.T) {
+	// Arrange
+	repo := NewURLRepository(NewStore())
+	ctx := context.Background()
+	url := &model.URL{OriginalURL: "https://www.ejemplo.com", ShortCode: "abc123"}
+	require.NoError(t, repo.Create(ctx, url))
+
+	// Act
+	url.OriginalURL = "https://modificada.com"
+	retrievedURL, err := repo.GetByShortCode(ctx, "abc123")
+	require.NoError(t, err)
+	retrievedURL.Visits = 100
+
+	// Assert
+	again, err := repo.GetByShortCode(ctx, "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, "https://www.ejemplo.com", again.OriginalURL)
+	assert.Equal(t, 0, again.Visits)
+}
+
+func TestURLRepository_GetByOriginalURL_NotFound(t *testing.T) {
+	// Arrange
+	repo := NewURLRepository(NewStore())
+
+	// Act
+	url, err := repo.GetByOriginalURL(context.Background(), 0, "https://no-existe.com")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, url)
+}
+
+func TestURLRepository_IncrementVisits_Concurrent(t *testing.T) {
+	// Arrange
+	repo := NewURLRepository(NewStore())
+	ctx := context.Background()
+	require.NoError(t, repo.Create(ctx, &model.URL{OriginalURL: "https://www.ejemplo.com", ShortCode: "abc123"}))
+
+	// Act
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := repo.IncrementVisits(ctx, "abc123")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	url, err := repo.GetByShortCode(ctx, "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, 50, url.Visits)
+}
+
+func TestURLRepository_List(t *testing.T) {
+	// Arrange
+	repo := NewURLRepository(NewStore())
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		require.NoError(t, repo.Create(ctx, &model.URL{
+			OriginalURL: fmt.Sprintf("https://www.ejemplo-%d.com", i),
+			ShortCode:   fmt.Sprintf("code%d", i),
+		}))
+	}
+
+	// Act
+	urls, total, err := repo.List(ctx, model.URLQuery{Limit: 2, Offset: 1}.Normalize())
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, urls, 2)
+	assert.Equal(t, int64(5), total)
+	assert.Equal(t, "code3", urls[0].ShortCode)
+	assert.Equal(t, "code2", urls[1].ShortCode)
+}
+
+func TestURLRepository_Delete(t *testing.T) {
+	// Arrange
+	repo := NewURLRepository(NewStore())
+	ctx := context.Background()
+	require.NoError(t, repo.Create(ctx, &model.URL{OriginalURL: "https://www.ejemplo.com", ShortCode: "abc123"}))
+
+	// Act
+	err := repo.Delete(ctx, "abc123")
+
+	// Assert
+	require.NoError(t, err)
+	_, err = repo.GetByShortCode(ctx, "abc123")
+	assert.Equal(t, errors.ErrURLNotFound, err)
+	assert.Equal(t, errors.ErrURLNotFound, repo.Delete(ctx, "abc123"))
+}
+
+func TestURLRepository_CanceledContext(t *testing.T) {
+	// Arrange
+	repo := NewURLRepository(NewStore())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	_, err := repo.GetByShortCode(ctx, "abc123")
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+}
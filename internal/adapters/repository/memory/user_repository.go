@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports"
+)
+
+// UserRepository implementa ports.UserRepository en memoria
+type UserRepository struct {
+	store *Store
+}
+
+// NewUserRepository crea un repositorio de usuarios sobre el almacén indicado
+func NewUserRepository(store *Store) ports.UserRepository {
+	return &UserRepository{store: store}
+}
+
+// CreateUser guarda un nuevo usuario. El nombre de usuario y el email deben ser únicos.
+func (r *UserRepository) CreateUser(ctx context.Context, user *model.User) error {
+	return r.store.write(ctx, func(d *data) error {
+		if d.conflictingUser(user) {
+			return errors.ErrDuplicateKey
+		}
+
+		// Asignar la clave y las marcas de tiempo como lo haría la base de datos
+		now := time.Now()
+		d.nextUserID++
+		user.ID = d.nextUserID
+		if user.CreatedAt.IsZero() {
+			user.CreatedAt = now
+		}
+		user.UpdatedAt = now
+
+		d.users[user.ID] = copyUser(user)
+		return nil
+	})
+}
+
+// GetByID busca un usuario por su ID
+func (r *UserRepository) GetByID(ctx context.Context, id uint) (*model.User, error) {
+	return r.find(ctx, func(user *model.User) bool { return user.ID == id })
+}
+
+// GetByUsername busca un usuario por su nombre de usuario
+func (r *UserRepository) GetByUsername(ctx context.Context, username string) (*model.User, error) {
+	return r.find(ctx, func(user *model.User) bool { return user.Username == username })
+}
+
+// GetByEmail busca un usuario por su email
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*model.User, error) {
+	return r.find(ctx, func(user *model.User) bool { return user.Email == email })
+}
+
+// UpdateUser actualiza un usuario existente
+func (r *UserRepository) UpdateUser(ctx context.Context, user *model.User) error {
+	return r.store.write(ctx, func(d *data) error {
+		if _, ok := d.users[user.ID]; !ok {
+			return errors.ErrUserNotFound
+		}
+		if d.conflictingUser(user) {
+			return errors.ErrDuplicateKey
+		}
+
+		user.UpdatedAt = time.Now()
+		d.users[user.ID] = copyUser(user)
+		return nil
+	})
+}
+
+// DeleteUser elimina un usuario por su ID
+func (r *UserRepository) DeleteUser(ctx context.Context, id uint) error {
+	return r.store.write(ctx, func(d *data) error {
+		if _, ok := d.users[id]; !ok {
+			return errors.ErrUserNotFound
+		}
+		delete(d.users, id)
+		return nil
+	})
+}
+
+// find devuelve una copia del primer usuario que cumple match
+func (r *UserRepository) find(ctx context.Context, match func(user *model.User) bool) (*model.User, error) {
+	var found *model.User
+	err := r.store.read(ctx, func(d *data) error {
+		for _, user := range d.users {
+			if match(user) {
+				found = copyUser(user)
+				return nil
+			}
+		}
+		return errors.ErrUserNotFound
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// conflictingUser indica si otro usuario ya usa el nombre de usuario o el email de user
+func (d *data) conflictingUser(user *model.User) bool {
+	for id, existing := range d.users {
+		if id == user.ID {
+			continue
+		}
+		if existing.Username == user.Username || existing.Email == user.Email {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserRepository_CreateUser_GetByID(t *testing.T) {
+	// Arrange
+	repo := NewUserRepository(NewStore())
+	ctx := context.Background()
+	user := &model.User{Username: "usuario", Email: "usuario@ejemplo.com", Password: "hash"}
+
+	// Act
+	err := repo.CreateUser(ctx, user)
+	require.NoError(t, err)
+	retrievedUser, err := repo.GetByID(ctx, user.ID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, user.Username, retrievedUser.Username)
+	assert.Equal(t, user.Email, retrievedUser.Email)
+}
+
+func TestUserRepository_CreateUser_Duplicate(t *testing.T) {
+	// Arrange
+	repo := NewUserRepository(NewStore())
+	ctx := context.Background()
+	require.NoError(t, repo.CreateUser(ctx, &model.User{Username: "usuario", Email: "a@ejemplo.com"}))
+
+	// Act
+	sameUsername := repo.CreateUser(ctx, &model.User{Username: "usuario", Email: "b@ejemplo.com"})
+	sameEmail := repo.CreateUser(ctx, &model.User{Username: "otro", Email: "a@ejemplo.com"})
+
+	// Assert
+	assert.Equal(t, errors.ErrDuplicateKey, sameUsername)
+	assert.Equal(t, errors.ErrDuplicateKey, sameEmail)
+}
+
+func TestUserRepository_UpdateUser(t *testing.T) {
+	// Arrange
+	repo := NewUserRepository(NewStore())
+	ctx := context.Background()
+	user := &model.User{Username: "usuario", Email: "a@ejemplo.com"}
+	require.NoError(t, repo.CreateUser(ctx, user))
+	require.NoError(t, repo.CreateUser(ctx, &model.User{Username: "otro", Email: "b@ejemplo.com"}))
+
+	// Act
+	user.Email = "nuevo@ejemplo.com"
+	err := repo.UpdateUser(ctx, user)
+	require.NoError(t, err)
+	user.Email = "b@ejemplo.com"
+	conflict := repo.UpdateUser(ctx, user)
+
+	// Assert
+	assert.Equal(t, errors.ErrDuplicateKey, conflict)
+	retrievedUser, err := repo.GetByUsername(ctx, "usuario")
+	require.NoError(t, err)
+	assert.Equal(t, "nuevo@ejemplo.com", retrievedUser.Email)
+}
+
+func TestUserRepository_NotFound(t *testing.T) {
+	// Arrange
+	repo := NewUserRepository(NewStore())
+	ctx := context.Background()
+
+	// Act
+	_, byID := repo.GetByID(ctx, 1)
+	_, byUsername := repo.GetByUsername(ctx, "nadie")
+	_, byEmail := repo.GetByEmail(ctx, "nadie@ejemplo.com")
+	update := repo.UpdateUser(ctx, &model.User{ID: 1, Username: "nadie"})
+	remove := repo.DeleteUser(ctx, 1)
+
+	// Assert
+	assert.Equal(t, errors.ErrUserNotFound, byID)
+	assert.Equal(t, errors.ErrUserNotFound, byUsername)
+	assert.Equal(t, errors.ErrUserNotFound, byEmail)
+	assert.Equal(t, errors.ErrUserNotFound, update)
+	assert.Equal(t, errors.ErrUserNotFound, remove)
+}
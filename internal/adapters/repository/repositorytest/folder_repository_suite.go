@@ -0,0 +1,162 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports"
+)
+
+// FolderRepositoryFactory crea un repositorio de carpetas vacío y el de URLs
+// que comparte su almacenamiento para un caso de la suite. La factoría es
+// responsable de registrar la limpieza con t.Cleanup.
+type FolderRepositoryFactory func(t *testing.T) (ports.FolderRepository, ports.URLRepository)
+
+// RunFolderRepositorySuite comprueba que un ports.FolderRepository cumple los
+// contratos que el dominio espera de él
+func RunFolderRepositorySuite(t *testing.T, factory FolderRepositoryFactory) {
+	t.Run("Create assigns ID and List sorts by name", func(t *testing.T) {
+		// Arrange
+		repo, _ := factory(t)
+		ctx := context.Background()
+		work := &model.Folder{UserID: 1, Name: "Trabajo"}
+
+		// Act
+		require.NoError(t, repo.Create(ctx, work))
+		require.NoError(t, repo.Create(ctx, &model.Folder{UserID: 1, Name: "Campañas"}))
+		require.NoError(t, repo.Create(ctx, &model.Folder{UserID: 2, Name: "Ajena"}))
+		folders, err := repo.List(ctx, 1)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotZero(t, work.ID)
+		assert.False(t, work.CreatedAt.IsZero())
+		assert.Equal(t, []string{"Campañas", "Trabajo"}, folderNames(folders))
+	})
+
+	t.Run("Create rejects duplicate names of the same user", func(t *testing.T) {
+		// Arrange
+		repo, _ := factory(t)
+		ctx := context.Background()
+		require.NoError(t, repo.Create(ctx, &model.Folder{UserID: 1, Name: "Trabajo"}))
+
+		// Act
+		err := repo.Create(ctx, &model.Folder{UserID: 1, Name: "Trabajo"})
+		otherUser := repo.Create(ctx, &model.Folder{UserID: 2, Name: "Trabajo"})
+
+		// Assert
+		assert.Equal(t, errors.ErrDuplicateKey, err)
+		assert.NoError(t, otherUser)
+	})
+
+	t.Run("GetByID and Rename only find folders of the user", func(t *testing.T) {
+		// Arrange
+		repo, _ := factory(t)
+		ctx := context.Background()
+		folder := &model.Folder{UserID: 1, Name: "Trabajo"}
+		require.NoError(t, repo.Create(ctx, folder))
+
+		// Act
+		_, getErr := repo.GetByID(ctx, 2, folder.ID)
+		renameErr := repo.Rename(ctx, &model.Folder{ID: folder.ID, UserID: 2, Name: "Mía"})
+		err := repo.Rename(ctx, &model.Folder{ID: folder.ID, UserID: 1, Name: "Oficina"})
+
+		// Assert
+		assert.Equal(t, errors.ErrFolderNotFound, getErr)
+		assert.Equal(t, errors.ErrFolderNotFound, renameErr)
+		require.NoError(t, err)
+		found, err := repo.GetByID(ctx, 1, folder.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Oficina", found.Name)
+	})
+
+	t.Run("Rename rejects names in use", func(t *testing.T) {
+		// Arrange
+		repo, _ := factory(t)
+		ctx := context.Background()
+		work, home := &model.Folder{UserID: 1, Name: "Trabajo"}, &model.Folder{UserID: 1, Name: "Casa"}
+		require.NoError(t, repo.Create(ctx, work))
+		require.NoError(t, repo.Create(ctx, home))
+
+		// Act
+		err := repo.Rename(ctx, &model.Folder{ID: home.ID, UserID: 1, Name: "Trabajo"})
+
+		// Assert
+		assert.Equal(t, errors.ErrDuplicateKey, err)
+	})
+
+	t.Run("URLs are saved and filtered by folder", func(t *testing.T) {
+		// Arrange
+		repo, urls := factory(t)
+		ctx := context.Background()
+		folder := &model.Folder{UserID: 1, Name: "Trabajo"}
+		require.NoError(t, repo.Create(ctx, folder))
+		filed, moved, loose := newURL("filed"), newURL("moved"), newURL("loose")
+		filed.FolderID = &folder.ID
+		for _, url := range []*model.URL{filed, moved, loose} {
+			url.UserID = 1
+			require.NoError(t, urls.Create(ctx, url))
+		}
+
+		// Act
+		moved.FolderID = &folder.ID
+		require.NoError(t, urls.Update(ctx, moved))
+
+		// Assert
+		none := uint(0)
+		for name, tc := range map[string]struct {
+			folderID *uint
+			expected []string
+		}{
+			"in the folder":    {&folder.ID, []string{"moved", "filed"}},
+			"without a folder": {&none, []string{"loose"}},
+			"any or no folder": {nil, []string{"loose", "moved", "filed"}},
+		} {
+			query := listQuery(10, 0)
+			query.Filter = model.URLFilter{UserID: 1, FolderID: tc.folderID}
+			page, total, err := urls.List(ctx, query)
+			require.NoError(t, err, name)
+			assert.Equal(t, int64(len(tc.expected)), total, name)
+			assert.Equal(t, tc.expected, shortCodes(page), name)
+		}
+	})
+
+	t.Run("Delete leaves its URLs without a folder", func(t *testing.T) {
+		// Arrange
+		repo, urls := factory(t)
+		ctx := context.Background()
+		folder := &model.Folder{UserID: 1, Name: "Trabajo"}
+		require.NoError(t, repo.Create(ctx, folder))
+		url := newURL("orphan")
+		url.UserID = 1
+		url.FolderID = &folder.ID
+		require.NoError(t, urls.Create(ctx, url))
+
+		// Act
+		foreign := repo.Delete(ctx, 2, folder.ID)
+		err := repo.Delete(ctx, 1, folder.ID)
+
+		// Assert
+		assert.Equal(t, errors.ErrFolderNotFound, foreign)
+		require.NoError(t, err)
+		retrieved, err := urls.GetByShortCode(ctx, url.ShortCode)
+		require.NoError(t, err)
+		assert.Nil(t, retrieved.FolderID)
+		_, err = repo.GetByID(ctx, 1, folder.ID)
+		assert.Equal(t, errors.ErrFolderNotFound, err)
+	})
+}
+
+// folderNames devuelve los nombres de las carpetas en orden
+func folderNames(folders []model.Folder) []string {
+	names := make([]string, len(folders))
+	for i, folder := range folders {
+		names[i] = folder.Name
+	}
+	return names
+}
@@ -0,0 +1,143 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports"
+)
+
+// IdempotencyRepositoryFactory crea un repositorio de idempotencia vacío para
+// un caso de la suite. La factoría es responsable de registrar la limpieza con t.Cleanup.
+type IdempotencyRepositoryFactory func(t *testing.T) ports.IdempotencyRepository
+
+// RunIdempotencyRepositorySuite comprueba que un ports.IdempotencyRepository
+// cumple los contratos que el middleware de idempotencia espera de él
+func RunIdempotencyRepositorySuite(t *testing.T, factory IdempotencyRepositoryFactory) {
+	t.Run("Create reserves the key and Get returns it", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		record := newIdempotencyRecord(1, "create", time.Hour)
+
+		// Act
+		err := repo.Create(ctx, record)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotZero(t, record.ID)
+
+		retrieved, err := repo.Get(ctx, 1, "create")
+		require.NoError(t, err)
+		assert.Equal(t, record.RequestHash, retrieved.RequestHash)
+		assert.False(t, retrieved.Completed())
+	})
+
+	t.Run("Create rejects a key already reserved by the same user", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		require.NoError(t, repo.Create(ctx, newIdempotencyRecord(1, "duplicate", time.Hour)))
+
+		// Act
+		sameUser := repo.Create(ctx, newIdempotencyRecord(1, "duplicate", time.Hour))
+		otherUser := repo.Create(ctx, newIdempotencyRecord(2, "duplicate", time.Hour))
+
+		// Assert
+		assert.Equal(t, errors.ErrDuplicateKey, sameUser)
+		assert.NoError(t, otherUser)
+	})
+
+	t.Run("Get returns ErrRecordNotFound on miss", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+
+		// Act
+		record, err := repo.Get(ctx, 1, "missing")
+
+		// Assert
+		assert.Nil(t, record)
+		assert.Equal(t, errors.ErrRecordNotFound, err)
+	})
+
+	t.Run("Update stores the response", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		record := newIdempotencyRecord(1, "update", time.Hour)
+		require.NoError(t, repo.Create(ctx, record))
+
+		// Act
+		record.StatusCode = 201
+		record.ContentType = "application/json"
+		record.Body = []byte(`{"short_code":"abc123"}`)
+		record.ExpiresAt = record.ExpiresAt.Add(24 * time.Hour)
+		err := repo.Update(ctx, record)
+
+		// Assert
+		require.NoError(t, err)
+		retrieved, err := repo.Get(ctx, 1, "update")
+		require.NoError(t, err)
+		assert.True(t, retrieved.Completed())
+		assert.Equal(t, 201, retrieved.StatusCode)
+		assert.Equal(t, "application/json", retrieved.ContentType)
+		assert.Equal(t, `{"short_code":"abc123"}`, string(retrieved.Body))
+		assert.WithinDuration(t, record.ExpiresAt, retrieved.ExpiresAt, time.Second)
+	})
+
+	t.Run("Update returns ErrRecordNotFound on miss", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+
+		// Act
+		err := repo.Update(ctx, newIdempotencyRecord(1, "missing", time.Hour))
+
+		// Assert
+		assert.Equal(t, errors.ErrRecordNotFound, err)
+	})
+
+	t.Run("Delete releases the key", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		require.NoError(t, repo.Create(ctx, newIdempotencyRecord(1, "delete", time.Hour)))
+
+		// Act
+		err := repo.Delete(ctx, 1, "delete")
+
+		// Assert
+		require.NoError(t, err)
+		assert.NoError(t, repo.Create(ctx, newIdempotencyRecord(1, "delete", time.Hour)))
+		assert.NoError(t, repo.Delete(ctx, 1, "missing"))
+	})
+
+	t.Run("DeleteExpired removes only expired records", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		require.NoError(t, repo.Create(ctx, newIdempotencyRecord(1, "expired", -time.Minute)))
+		require.NoError(t, repo.Create(ctx, newIdempotencyRecord(1, "valid", time.Hour)))
+
+		// Act
+		deleted, err := repo.DeleteExpired(ctx, time.Now())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+		_, err = repo.Get(ctx, 1, "expired")
+		assert.Equal(t, errors.ErrRecordNotFound, err)
+		_, err = repo.Get(ctx, 1, "valid")
+		assert.NoError(t, err)
+	})
+}
+
+// newIdempotencyRecord crea un registro en curso que caduca dentro de ttl
+func newIdempotencyRecord(userID uint, key string, ttl time.Duration) *model.IdempotencyRecord {
+	return &model.IdempotencyRecord{
+		UserID:      userID,
+		Key:         key,
+		RequestHash: "hash-" + key,
+		ExpiresAt:   time.Now().Add(ttl),
+	}
+}
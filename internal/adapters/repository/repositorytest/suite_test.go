@@ -0,0 +1,92 @@
+package repositorytest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"tiny-url/internal/adapters/repository"
+	"tiny-url/internal/adapters/repository/memory"
+	"tiny-url/internal/adapters/repository/repositorytest"
+	"tiny-url/internal/config"
+	"tiny-url/internal/database"
+	"tiny-url/internal/domain/ports"
+)
+
+// newSQLite abre una base de datos SQLite en memoria con el esquema migrado
+func newSQLite(t *testing.T) database.Service {
+	db, err := database.NewSQLite(config.Database{SQLitePath: database.MemorySQLitePath})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := db.Migrator()
+	require.NoError(t, err)
+	_, err = migrator.Up(context.Background())
+	require.NoError(t, err)
+	return db
+}
+
+func TestMemory_URLRepository(t *testing.T) {
+	repositorytest.RunURLRepositorySuite(t, func(t *testing.T) ports.URLRepository {
+		return memory.NewURLRepository(memory.NewStore())
+	})
+}
+
+func TestMemory_UserRepository(t *testing.T) {
+	repositorytest.RunUserRepositorySuite(t, func(t *testing.T) ports.UserRepository {
+		return memory.NewUserRepository(memory.NewStore())
+	})
+}
+
+func TestSQLite_URLRepository(t *testing.T) {
+	repositorytest.RunURLRepositorySuite(t, func(t *testing.T) ports.URLRepository {
+		return repository.NewURLRepository(newSQLite(t).Gorm())
+	})
+}
+
+func TestSQLite_UserRepository(t *testing.T) {
+	repositorytest.RunUserRepositorySuite(t, func(t *testing.T) ports.UserRepository {
+		return repository.NewUserRepository(newSQLite(t).Gorm())
+	})
+}
+
+func TestMemory_IdempotencyRepository(t *testing.T) {
+	repositorytest.RunIdempotencyRepositorySuite(t, func(t *testing.T) ports.IdempotencyRepository {
+		return memory.NewIdempotencyRepository(memory.NewStore())
+	})
+}
+
+func TestSQLite_IdempotencyRepository(t *testing.T) {
+	repositorytest.RunIdempotencyRepositorySuite(t, func(t *testing.T) ports.IdempotencyRepository {
+		return repository.NewIdempotencyRepository(newSQLite(t).Gorm())
+	})
+}
+
+func TestMemory_TagRepository(t *testing.T) {
+	repositorytest.RunTagRepositorySuite(t, func(t *testing.T) (ports.TagRepository, ports.URLRepository) {
+		store := memory.NewStore()
+		return memory.NewTagRepository(store), memory.NewURLRepository(store)
+	})
+}
+
+func TestSQLite_TagRepository(t *testing.T) {
+	repositorytest.RunTagRepositorySuite(t, func(t *testing.T) (ports.TagRepository, ports.URLRepository) {
+		db := newSQLite(t).Gorm()
+		return repository.NewTagRepository(db), repository.NewURLRepository(db)
+	})
+}
+
+func TestMemory_FolderRepository(t *testing.T) {
+	repositorytest.RunFolderRepositorySuite(t, func(t *testing.T) (ports.FolderRepository, ports.URLRepository) {
+		store := memory.NewStore()
+		return memory.NewFolderRepository(store), memory.NewURLRepository(store)
+	})
+}
+
+func TestSQLite_FolderRepository(t *testing.T) {
+	repositorytest.RunFolderRepositorySuite(t, func(t *testing.T) (ports.FolderRepository, ports.URLRepository) {
+		db := newSQLite(t).Gorm()
+		return repository.NewFolderRepository(db), repository.NewURLRepository(db)
+	})
+}
@@ -0,0 +1,158 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports"
+)
+
+// TagRepositoryFactory crea un repositorio de etiquetas vacío y el de URLs que
+// comparte su almacenamiento para un caso de la suite. La factoría es
+// responsable de registrar la limpieza con t.Cleanup.
+type TagRepositoryFactory func(t *testing.T) (ports.TagRepository, ports.URLRepository)
+
+// RunTagRepositorySuite comprueba que un ports.TagRepository cumple los
+// contratos que el dominio espera de él
+func RunTagRepositorySuite(t *testing.T, factory TagRepositoryFactory) {
+	t.Run("Create assigns ID and List sorts by name", func(t *testing.T) {
+		// Arrange
+		repo, _ := factory(t)
+		ctx := context.Background()
+		verano, otono := &model.Tag{UserID: 1, Name: "verano"}, &model.Tag{UserID: 1, Name: "otoño"}
+
+		// Act
+		require.NoError(t, repo.Create(ctx, verano))
+		require.NoError(t, repo.Create(ctx, otono))
+		require.NoError(t, repo.Create(ctx, &model.Tag{UserID: 2, Name: "ajena"}))
+		tags, err := repo.List(ctx, 1)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotZero(t, verano.ID)
+		assert.Equal(t, []string{"otoño", "verano"}, tagNames(tags))
+	})
+
+	t.Run("List returns an empty slice without tags", func(t *testing.T) {
+		// Arrange
+		repo, _ := factory(t)
+
+		// Act
+		tags, err := repo.List(context.Background(), 1)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotNil(t, tags)
+		assert.Empty(t, tags)
+	})
+
+	t.Run("Create rejects duplicate names of the same user", func(t *testing.T) {
+		// Arrange
+		repo, _ := factory(t)
+		ctx := context.Background()
+		require.NoError(t, repo.Create(ctx, &model.Tag{UserID: 1, Name: "verano"}))
+
+		// Act
+		err := repo.Create(ctx, &model.Tag{UserID: 1, Name: "verano"})
+		otherUser := repo.Create(ctx, &model.Tag{UserID: 2, Name: "verano"})
+
+		// Assert
+		assert.Equal(t, errors.ErrDuplicateKey, err)
+		assert.NoError(t, otherUser)
+	})
+
+	t.Run("GetByID only finds tags of the user", func(t *testing.T) {
+		// Arrange
+		repo, _ := factory(t)
+		ctx := context.Background()
+		tag := &model.Tag{UserID: 1, Name: "verano"}
+		require.NoError(t, repo.Create(ctx, tag))
+
+		// Act
+		found, err := repo.GetByID(ctx, 1, tag.ID)
+		_, otherErr := repo.GetByID(ctx, 2, tag.ID)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "verano", found.Name)
+		assert.Equal(t, errors.ErrTagNotFound, otherErr)
+	})
+
+	t.Run("Rename changes the name on tagged URLs", func(t *testing.T) {
+		// Arrange
+		repo, urls := factory(t)
+		ctx := context.Background()
+		url := newURL("rename")
+		url.UserID = 1
+		require.NoError(t, urls.Create(ctx, url))
+		require.NoError(t, urls.AddTags(ctx, url, []string{"verano", "zeta"}))
+		tags, err := repo.List(ctx, 1)
+		require.NoError(t, err)
+		tag := tags[0]
+
+		// Act
+		tag.Name = "agosto"
+		err = repo.Rename(ctx, &tag)
+
+		// Assert
+		require.NoError(t, err)
+		retrieved, err := urls.GetByShortCode(ctx, url.ShortCode)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"agosto", "zeta"}, retrieved.TagNames())
+	})
+
+	t.Run("Rename rejects names in use and tags of other users", func(t *testing.T) {
+		// Arrange
+		repo, _ := factory(t)
+		ctx := context.Background()
+		verano, otono := &model.Tag{UserID: 1, Name: "verano"}, &model.Tag{UserID: 1, Name: "otoño"}
+		require.NoError(t, repo.Create(ctx, verano))
+		require.NoError(t, repo.Create(ctx, otono))
+
+		// Act
+		duplicate := repo.Rename(ctx, &model.Tag{ID: otono.ID, UserID: 1, Name: "verano"})
+		foreign := repo.Rename(ctx, &model.Tag{ID: otono.ID, UserID: 2, Name: "invierno"})
+
+		// Assert
+		assert.Equal(t, errors.ErrDuplicateKey, duplicate)
+		assert.Equal(t, errors.ErrTagNotFound, foreign)
+	})
+
+	t.Run("Delete removes the tag from its URLs", func(t *testing.T) {
+		// Arrange
+		repo, urls := factory(t)
+		ctx := context.Background()
+		url := newURL("untag")
+		url.UserID = 1
+		require.NoError(t, urls.Create(ctx, url))
+		require.NoError(t, urls.AddTags(ctx, url, []string{"verano", "zeta"}))
+		tags, err := repo.List(ctx, 1)
+		require.NoError(t, err)
+
+		// Act
+		foreign := repo.Delete(ctx, 2, tags[0].ID)
+		err = repo.Delete(ctx, 1, tags[0].ID)
+
+		// Assert
+		assert.Equal(t, errors.ErrTagNotFound, foreign)
+		require.NoError(t, err)
+		retrieved, err := urls.GetByShortCode(ctx, url.ShortCode)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"zeta"}, retrieved.TagNames())
+		assert.Equal(t, errors.ErrTagNotFound, repo.Delete(ctx, 1, tags[0].ID))
+	})
+}
+
+// tagNames devuelve los nombres de las etiquetas en orden
+func tagNames(tags []model.Tag) []string {
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	return names
+}
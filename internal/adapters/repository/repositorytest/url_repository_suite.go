@@ -0,0 +1,743 @@
+// Package repositorytest contiene suites de conformidad que cualquier
+// implementación de los puertos de repositorio debe superar. Cada adaptador
+// las ejecuta desde sus propios tests con una factoría que crea instancias
+// vacías.
+package repositorytest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports"
+)
+
+// URLRepositoryFactory crea un repositorio de URLs vacío para un caso de la suite.
+// La factoría es responsable de registrar la limpieza con t.Cleanup.
+type URLRepositoryFactory func(t *testing.T) ports.URLRepository
+
+// concurrentVisits es el número de incrementos simultáneos de la prueba de concurrencia
+const concurrentVisits = 50
+
+// RunURLRepositorySuite comprueba que un ports.URLRepository cumple los
+// contratos que el dominio espera de él
+func RunURLRepositorySuite(t *testing.T, factory URLRepositoryFactory) {
+	t.Run("Create assigns ID and timestamps", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		url := newURL("create")
+
+		// Act
+		err := repo.Create(ctx, url)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotZero(t, url.ID)
+		assert.False(t, url.CreatedAt.IsZero())
+
+		retrievedURL, err := repo.GetByShortCode(ctx, url.ShortCode)
+		require.NoError(t, err)
+		assert.Equal(t, url.ID, retrievedURL.ID)
+		assert.Equal(t, url.OriginalURL, retrievedURL.OriginalURL)
+		assert.Equal(t, 0, retrievedURL.Visits)
+	})
+
+	t.Run("Create keeps the password hash", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		url := newURL("protected")
+		url.PasswordHash = "$2a$10$hash"
+
+		// Act
+		err := repo.Create(ctx, url)
+
+		// Assert
+		require.NoError(t, err)
+		retrievedURL, err := repo.GetByShortCode(ctx, url.ShortCode)
+		require.NoError(t, err)
+		assert.Equal(t, "$2a$10$hash", retrievedURL.PasswordHash)
+		assert.True(t, retrievedURL.Protected())
+	})
+
+	t.Run("Create rejects duplicate short codes", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		url := newURL("duplicate")
+		require.NoError(t, repo.Create(ctx, url))
+
+		// Act
+		err := repo.Create(ctx, &model.URL{OriginalURL: url.OriginalURL + "/otra", ShortCode: url.ShortCode})
+
+		// Assert
+		assert.Equal(t, errors.ErrDuplicateKey, err)
+	})
+
+	t.Run("GetByShortCode returns ErrURLNotFound on miss", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+
+		// Act
+		url, err := repo.GetByShortCode(ctx, "missing")
+
+		// Assert
+		assert.Nil(t, url)
+		assert.Equal(t, errors.ErrURLNotFound, err)
+	})
+
+	t.Run("GetByOriginalURL returns nil without error on miss", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+
+		// Act
+		url, err := repo.GetByOriginalURL(ctx, 1, "https://missing.example.com")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Nil(t, url)
+	})
+
+	t.Run("GetByOriginalURL finds an existing URL", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		url := newURL("original")
+		url.UserID = 1
+		require.NoError(t, repo.Create(ctx, url))
+
+		// Act
+		retrievedURL, err := repo.GetByOriginalURL(ctx, 1, url.OriginalURL)
+
+		// Assert
+		require.NoError(t, err)
+		require.NotNil(t, retrievedURL)
+		assert.Equal(t, url.ShortCode, retrievedURL.ShortCode)
+	})
+
+	t.Run("GetByOriginalURL only finds URLs of the user", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		url := newURL("owned")
+		url.UserID = 1
+		require.NoError(t, repo.Create(ctx, url))
+
+		// Act
+		retrievedURL, err := repo.GetByOriginalURL(ctx, 2, url.OriginalURL)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Nil(t, retrievedURL)
+	})
+
+	t.Run("Update saves owner and expiry", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		url := newURL("update")
+		url.UserID = 1
+		require.NoError(t, repo.Create(ctx, url))
+		expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
+		// Act
+		url.UserID = 2
+		url.ExpiresAt = &expiresAt
+		err := repo.Update(ctx, url)
+
+		// Assert
+		require.NoError(t, err)
+		retrievedURL, err := repo.GetByShortCode(ctx, url.ShortCode)
+		require.NoError(t, err)
+		assert.Equal(t, uint(2), retrievedURL.UserID)
+		require.NotNil(t, retrievedURL.ExpiresAt)
+		assert.True(t, expiresAt.Equal(*retrievedURL.ExpiresAt))
+	})
+
+	t.Run("Update saves the visit limit and redirect code", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		url := newURL("update-limit")
+		require.NoError(t, repo.Create(ctx, url))
+		maxVisits := 1
+
+		// Act
+		url.MaxVisits = &maxVisits
+		url.RedirectCode = 307
+		err := repo.Update(ctx, url)
+
+		// Assert
+		require.NoError(t, err)
+		retrievedURL, err := repo.GetByShortCode(ctx, url.ShortCode)
+		require.NoError(t, err)
+		require.NotNil(t, retrievedURL.MaxVisits)
+		assert.Equal(t, 1, *retrievedURL.MaxVisits)
+		assert.Equal(t, 307, retrievedURL.RedirectCode)
+	})
+
+	t.Run("SetDeviceRules replaces the device rules", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		url := newURL("devices")
+		require.NoError(t, repo.Create(ctx, url))
+		require.NoError(t, repo.SetDeviceRules(ctx, url, []model.DeviceRule{
+			{Platform: model.PlatformIOS, Destination: "https://apps.apple.com/app/id1"},
+		}))
+
+		// Act
+		err := repo.SetDeviceRules(ctx, url, []model.DeviceRule{
+			{Platform: model.PlatformIOS, Destination: "miapp://inicio"},
+			{Platform: model.PlatformAndroid, Destination: "https://play.google.com/store/apps/details?id=app"},
+		})
+
+		// Assert
+		require.NoError(t, err)
+		retrievedURL, err := repo.GetByShortCode(ctx, url.ShortCode)
+		require.NoError(t, err)
+		expected := []model.DeviceRule{
+			{URLID: url.ID, Platform: model.PlatformAndroid, Destination: "https://play.google.com/store/apps/details?id=app"},
+			{URLID: url.ID, Platform: model.PlatformIOS, Destination: "miapp://inicio"},
+		}
+		assert.Equal(t, expected, retrievedURL.DeviceRules)
+		assert.Equal(t, expected, url.DeviceRules)
+
+		require.NoError(t, repo.SetDeviceRules(ctx, url, nil))
+		retrievedURL, err = repo.GetByShortCode(ctx, url.ShortCode)
+		require.NoError(t, err)
+		assert.Empty(t, retrievedURL.DeviceRules)
+	})
+
+	t.Run("Update returns ErrURLNotFound on miss", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+
+		// Act
+		err := repo.Update(ctx, &model.URL{ID: 999, ShortCode: "missing"})
+
+		// Assert
+		assert.Equal(t, errors.ErrURLNotFound, err)
+	})
+
+	t.Run("AddTags and RemoveTags manage the owner's tags", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		first, second, other := newURL("tagged1"), newURL("tagged2"), newURL("tagged3")
+		first.UserID, second.UserID, other.UserID = 1, 1, 2
+		for _, url := range []*model.URL{first, second, other} {
+			require.NoError(t, repo.Create(ctx, url))
+		}
+
+		// Act
+		require.NoError(t, repo.AddTags(ctx, first, []string{"verano", "campaña"}))
+		require.NoError(t, repo.AddTags(ctx, first, []string{"verano"}))
+		require.NoError(t, repo.AddTags(ctx, second, []string{"verano"}))
+		require.NoError(t, repo.AddTags(ctx, other, []string{"verano"}))
+		require.NoError(t, repo.RemoveTags(ctx, second, []string{"verano", "inexistente"}))
+
+		// Assert
+		assert.Equal(t, []string{"campaña", "verano"}, first.TagNames())
+		assert.Empty(t, second.TagNames())
+
+		retrievedFirst, err := repo.GetByShortCode(ctx, first.ShortCode)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"campaña", "verano"}, retrievedFirst.TagNames())
+
+		retrievedOther, err := repo.GetByShortCode(ctx, other.ShortCode)
+		require.NoError(t, err)
+		require.Len(t, retrievedOther.Tags, 1)
+		assert.NotEqual(t, retrievedFirst.Tags[1].ID, retrievedOther.Tags[0].ID, "cada usuario tiene sus etiquetas")
+
+		page, _, err := repo.List(ctx, listQuery(10, 0))
+		require.NoError(t, err)
+		for _, url := range page {
+			if url.ShortCode == first.ShortCode {
+				assert.Equal(t, []string{"campaña", "verano"}, url.TagNames())
+			}
+		}
+	})
+
+	t.Run("IncrementVisits returns ErrURLNotFound on miss", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+
+		// Act
+		allowed, err := repo.IncrementVisits(ctx, "missing")
+
+		// Assert
+		assert.Equal(t, errors.ErrURLNotFound, err)
+		assert.False(t, allowed)
+	})
+
+	t.Run("IncrementVisits does not lose concurrent updates", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		url := newURL("visits")
+		require.NoError(t, repo.Create(ctx, url))
+
+		// Act
+		var wg sync.WaitGroup
+		errs := make(chan error, concurrentVisits)
+		for i := 0; i < concurrentVisits; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := repo.IncrementVisits(ctx, url.ShortCode)
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		// Assert
+		for err := range errs {
+			require.NoError(t, err)
+		}
+		retrievedURL, err := repo.GetByShortCode(ctx, url.ShortCode)
+		require.NoError(t, err)
+		assert.Equal(t, concurrentVisits, retrievedURL.Visits)
+	})
+
+	t.Run("IncrementVisits never exceeds the visit limit", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		url := newURL("limited")
+		maxVisits := 3
+		url.MaxVisits = &maxVisits
+		require.NoError(t, repo.Create(ctx, url))
+
+		// Act
+		var wg sync.WaitGroup
+		var allowedVisits atomic.Int32
+		errs := make(chan error, concurrentVisits)
+		for i := 0; i < concurrentVisits; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				allowed, err := repo.IncrementVisits(ctx, url.ShortCode)
+				if allowed {
+					allowedVisits.Add(1)
+				}
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		// Assert
+		for err := range errs {
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(maxVisits), allowedVisits.Load())
+		retrievedURL, err := repo.GetByShortCode(ctx, url.ShortCode)
+		require.NoError(t, err)
+		assert.Equal(t, maxVisits, retrievedURL.Visits)
+		assert.True(t, retrievedURL.Exhausted())
+	})
+
+	t.Run("List pages newest first without gaps or overlaps", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		var codes []string
+		for i := 0; i < 7; i++ {
+			url := newURL(fmt.Sprintf("list%d", i))
+			require.NoError(t, repo.Create(ctx, url))
+			codes = append([]string{url.ShortCode}, codes...)
+		}
+
+		// Act
+		var listed []string
+		for offset := 0; offset < len(codes)+3; offset += 3 {
+			page, total, err := repo.List(ctx, listQuery(3, offset))
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(codes)), total)
+			assert.LessOrEqual(t, len(page), 3)
+			for _, url := range page {
+				listed = append(listed, url.ShortCode)
+			}
+		}
+
+		// Assert
+		assert.Equal(t, codes, listed)
+	})
+
+	t.Run("List returns an empty page past the end", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		require.NoError(t, repo.Create(ctx, newURL("end")))
+
+		// Act
+		page, total, err := repo.List(ctx, listQuery(10, 10))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, page)
+		assert.Equal(t, int64(1), total)
+	})
+
+	t.Run("List sorts by visits and creation date", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		base := time.Now().Add(-time.Hour).Truncate(time.Second)
+		for i, visits := range []int{5, 1, 3} {
+			url := newURL(fmt.Sprintf("sort%d", i))
+			url.Visits = visits
+			url.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+			require.NoError(t, repo.Create(ctx, url))
+		}
+
+		// Act & Assert
+		for sort, expected := range map[model.URLSort][]string{
+			model.SortVisitsAsc:     {"sort1", "sort2", "sort0"},
+			model.SortVisitsDesc:    {"sort0", "sort2", "sort1"},
+			model.SortCreatedAtAsc:  {"sort0", "sort1", "sort2"},
+			model.SortCreatedAtDesc: {"sort2", "sort1", "sort0"},
+		} {
+			query := listQuery(10, 0)
+			query.Sort = sort
+			page, _, err := repo.List(ctx, query)
+			require.NoError(t, err)
+			assert.Equal(t, expected, shortCodes(page), "sort %s", sort)
+		}
+	})
+
+	t.Run("List seeks by cursor without gaps when URLs are inserted", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		base := time.Now().Add(-time.Hour).Truncate(time.Second)
+		for i := 0; i < 7; i++ {
+			url := newURL(fmt.Sprintf("seek%d", i))
+			url.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+			require.NoError(t, repo.Create(ctx, url))
+		}
+
+		// Act
+		first, _, err := repo.List(ctx, listQuery(3, 0))
+		require.NoError(t, err)
+		require.NoError(t, repo.Create(ctx, newURL("seeknew")))
+
+		listed := shortCodes(first)
+		cursor := model.CursorAt(first[len(first)-1], model.DefaultURLSort, false)
+		for {
+			query := listQuery(3, 0)
+			query.Cursor = cursor
+			page, total, err := repo.List(ctx, query.Normalize())
+			require.NoError(t, err)
+			assert.Equal(t, int64(8), total)
+			if len(page) == 0 {
+				break
+			}
+			listed = append(listed, shortCodes(page)...)
+			cursor = model.CursorAt(page[len(page)-1], model.DefaultURLSort, false)
+		}
+
+		// Assert
+		assert.Equal(t, []string{"seek6", "seek5", "seek4", "seek3", "seek2", "seek1", "seek0"}, listed)
+	})
+
+	t.Run("List seeks backwards from a cursor", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		var urls []*model.URL
+		for i := 0; i < 6; i++ {
+			url := newURL(fmt.Sprintf("back%d", i))
+			url.Visits = 1
+			require.NoError(t, repo.Create(ctx, url))
+			urls = append(urls, url)
+		}
+
+		// Act
+		query := listQuery(3, 0)
+		query.Sort = model.SortVisitsDesc
+		query.Cursor = model.CursorAt(urls[1], model.SortVisitsDesc, true)
+		page, _, err := repo.List(ctx, query.Normalize())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"back4", "back3", "back2"}, shortCodes(page))
+	})
+
+	t.Run("List filters and counts matching URLs", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		now := time.Now().Truncate(time.Second)
+		past, future := now.Add(-time.Hour), now.Add(time.Hour)
+		fixtures := []*model.URL{
+			{OriginalURL: "https://blog.acme.com/Post_1", ShortCode: "blog1", ExpiresAt: &past, CreatedAt: now.Add(-72 * time.Hour)},
+			{OriginalURL: "https://acme.com/docs", ShortCode: "docs1", ExpiresAt: &future, CreatedAt: now.Add(-48 * time.Hour), UserID: 7},
+			{OriginalURL: "https://notacme.com/100%", ShortCode: "other1", CreatedAt: now.Add(-24 * time.Hour)},
+		}
+		for _, url := range fixtures {
+			url.Domain = model.DomainOf(url.OriginalURL)
+			require.NoError(t, repo.Create(ctx, url))
+		}
+		from, to := now.Add(-60*time.Hour), now.Add(-24*time.Hour)
+
+		// Act & Assert
+		for name, tc := range map[string]struct {
+			filter   model.URLFilter
+			expected []string
+		}{
+			"domain includes subdomains": {model.URLFilter{Domain: "acme.com"}, []string{"docs1", "blog1"}},
+			"search ignores case":        {model.URLFilter{Search: "post_"}, []string{"blog1"}},
+			"search matches short codes": {model.URLFilter{Search: "OTHER"}, []string{"other1"}},
+			"search escapes wildcards":   {model.URLFilter{Search: "100%"}, []string{"other1"}},
+			"expired":                    {model.URLFilter{Expiry: model.ExpiryExpired, Now: now}, []string{"blog1"}},
+			"active":                     {model.URLFilter{Expiry: model.ExpiryActive, Now: now}, []string{"other1", "docs1"}},
+			"created range is half-open": {model.URLFilter{CreatedFrom: &from, CreatedTo: &to}, []string{"docs1"}},
+			"owner":                      {model.URLFilter{UserID: 7}, []string{"docs1"}},
+		} {
+			query := listQuery(1, 0)
+			query.Filter = tc.filter
+			page, total, err := repo.List(ctx, query)
+			require.NoError(t, err, name)
+			assert.Equal(t, int64(len(tc.expected)), total, name)
+			assert.Equal(t, tc.expected[:1], shortCodes(page), name)
+		}
+	})
+
+	t.Run("List filters by schedule state", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		now := time.Now().Truncate(time.Second)
+		past, future := now.Add(-time.Hour), now.Add(time.Hour)
+		fixtures := []*model.URL{
+			{OriginalURL: "https://acme.com/launch", ShortCode: "launch", ActiveFrom: &future, CreatedAt: now.Add(-72 * time.Hour)},
+			{OriginalURL: "https://acme.com/live", ShortCode: "live", ActiveFrom: &past, ActiveUntil: &future, CreatedAt: now.Add(-48 * time.Hour)},
+			{OriginalURL: "https://acme.com/over", ShortCode: "over", ActiveUntil: &now, CreatedAt: now.Add(-24 * time.Hour)},
+			{OriginalURL: "https://acme.com/always", ShortCode: "always", CreatedAt: now.Add(-12 * time.Hour)},
+		}
+		for _, url := range fixtures {
+			require.NoError(t, repo.Create(ctx, url))
+		}
+
+		// Act & Assert
+		for state, expected := range map[model.URLState][]string{
+			model.StateScheduled: {"launch"},
+			model.StateActive:    {"always", "live"},
+			model.StateEnded:     {"over"},
+		} {
+			query := listQuery(10, 0)
+			query.Filter = model.URLFilter{State: state, Now: now}
+			page, total, err := repo.List(ctx, query)
+			require.NoError(t, err, state)
+			assert.Equal(t, int64(len(expected)), total, state)
+			assert.Equal(t, expected, shortCodes(page), state)
+		}
+	})
+
+	t.Run("List filters by tag", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		summer, winter := newURL("summer"), newURL("winter")
+		for _, url := range []*model.URL{summer, winter} {
+			url.UserID = 1
+			require.NoError(t, repo.Create(ctx, url))
+		}
+		require.NoError(t, repo.AddTags(ctx, summer, []string{"verano", "viajes"}))
+		require.NoError(t, repo.AddTags(ctx, winter, []string{"viajes"}))
+
+		// Act & Assert
+		for tag, expected := range map[string][]string{
+			"verano":   {"summer"},
+			"viajes":   {"winter", "summer"},
+			"invierno": {},
+		} {
+			query := listQuery(10, 0)
+			query.Filter = model.URLFilter{UserID: 1, Tag: tag}
+			page, total, err := repo.List(ctx, query)
+			require.NoError(t, err, tag)
+			assert.Equal(t, int64(len(expected)), total, tag)
+			assert.Equal(t, expected, shortCodes(page), tag)
+		}
+	})
+
+	t.Run("Delete removes the URL", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		url := newURL("delete")
+		require.NoError(t, repo.Create(ctx, url))
+
+		// Act
+		err := repo.Delete(ctx, url.ShortCode)
+
+		// Assert
+		require.NoError(t, err)
+		_, err = repo.GetByShortCode(ctx, url.ShortCode)
+		assert.Equal(t, errors.ErrURLNotFound, err)
+	})
+
+	t.Run("Delete removes a tagged URL", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		url := newURL("deletetags")
+		url.UserID = 1
+		require.NoError(t, repo.Create(ctx, url))
+		require.NoError(t, repo.AddTags(ctx, url, []string{"viejo"}))
+
+		// Act
+		err := repo.Delete(ctx, url.ShortCode)
+
+		// Assert
+		require.NoError(t, err)
+		_, err = repo.GetByShortCode(ctx, url.ShortCode)
+		assert.Equal(t, errors.ErrURLNotFound, err)
+	})
+
+	t.Run("Delete returns ErrURLNotFound on miss", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+
+		// Act
+		err := repo.Delete(ctx, "missing")
+
+		// Assert
+		assert.Equal(t, errors.ErrURLNotFound, err)
+	})
+
+	t.Run("Delete moves the URL to the trash", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		kept, trashed := newURL("kept"), newURL("trashed")
+		require.NoError(t, repo.Create(ctx, kept))
+		require.NoError(t, repo.Create(ctx, trashed))
+
+		// Act
+		require.NoError(t, repo.Delete(ctx, trashed.ShortCode))
+
+		// Assert
+		deleted, err := repo.GetDeletedByShortCode(ctx, trashed.ShortCode)
+		require.NoError(t, err)
+		assert.True(t, deleted.DeletedAt.Valid)
+		_, err = repo.GetDeletedByShortCode(ctx, kept.ShortCode)
+		assert.Equal(t, errors.ErrURLNotFound, err)
+
+		page, total, err := repo.List(ctx, listQuery(10, 0))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, []string{"kept"}, shortCodes(page))
+
+		query := listQuery(10, 0)
+		query.Filter = model.URLFilter{Trashed: true}
+		page, total, err = repo.List(ctx, query)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, []string{"trashed"}, shortCodes(page))
+	})
+
+	t.Run("Delete keeps the short code reserved", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		url := newURL("reserved")
+		require.NoError(t, repo.Create(ctx, url))
+		require.NoError(t, repo.Delete(ctx, url.ShortCode))
+
+		// Act
+		err := repo.Create(ctx, &model.URL{OriginalURL: "https://otra.example.com", ShortCode: url.ShortCode})
+
+		// Assert
+		assert.Equal(t, errors.ErrDuplicateKey, err)
+		_, err = repo.GetByOriginalURL(ctx, 0, url.OriginalURL)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Delete ignores URLs already in the trash", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		url := newURL("twice")
+		require.NoError(t, repo.Create(ctx, url))
+		require.NoError(t, repo.Delete(ctx, url.ShortCode))
+
+		// Act
+		err := repo.Delete(ctx, url.ShortCode)
+
+		// Assert
+		assert.Equal(t, errors.ErrURLNotFound, err)
+	})
+
+	t.Run("Restore brings the URL back", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		url := newURL("restore")
+		url.UserID = 1
+		require.NoError(t, repo.Create(ctx, url))
+		require.NoError(t, repo.AddTags(ctx, url, []string{"vuelve"}))
+		require.NoError(t, repo.Delete(ctx, url.ShortCode))
+
+		// Act
+		err := repo.Restore(ctx, url.ShortCode)
+
+		// Assert
+		require.NoError(t, err)
+		restored, err := repo.GetByShortCode(ctx, url.ShortCode)
+		require.NoError(t, err)
+		assert.False(t, restored.DeletedAt.Valid)
+		assert.Equal(t, []string{"vuelve"}, restored.TagNames())
+		_, err = repo.GetDeletedByShortCode(ctx, url.ShortCode)
+		assert.Equal(t, errors.ErrURLNotFound, err)
+	})
+
+	t.Run("Restore returns ErrURLNotFound for live or missing URLs", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		url := newURL("live")
+		require.NoError(t, repo.Create(ctx, url))
+
+		// Act & Assert
+		assert.Equal(t, errors.ErrURLNotFound, repo.Restore(ctx, url.ShortCode))
+		assert.Equal(t, errors.ErrURLNotFound, repo.Restore(ctx, "missing"))
+	})
+
+	t.Run("PurgeDeleted removes URLs trashed before the cutoff", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		live, trashed := newURL("live"), newURL("purged")
+		trashed.UserID = 1
+		require.NoError(t, repo.Create(ctx, live))
+		require.NoError(t, repo.Create(ctx, trashed))
+		require.NoError(t, repo.AddTags(ctx, trashed, []string{"fuera"}))
+		require.NoError(t, repo.Delete(ctx, trashed.ShortCode))
+
+		// Act
+		kept, errKept := repo.PurgeDeleted(ctx, time.Now().Add(-time.Hour))
+		purged, errPurged := repo.PurgeDeleted(ctx, time.Now().Add(time.Hour))
+
+		// Assert
+		require.NoError(t, errKept)
+		require.NoError(t, errPurged)
+		assert.Equal(t, int64(0), kept)
+		assert.Equal(t, int64(1), purged)
+		_, err := repo.GetDeletedByShortCode(ctx, trashed.ShortCode)
+		assert.Equal(t, errors.ErrURLNotFound, err)
+		_, err = repo.GetByShortCode(ctx, live.ShortCode)
+		assert.NoError(t, err)
+
+		// El código queda libre de nuevo
+		assert.NoError(t, repo.Create(ctx, &model.URL{OriginalURL: "https://otra.example.com", ShortCode: trashed.ShortCode}))
+	})
+}
+
+// newURL crea una URL sin persistir con datos derivados de name
+func newURL(name string) *model.URL {
+	return &model.URL{
+		OriginalURL: "https://www." + name + ".example.com",
+		ShortCode:   name,
+		Domain:      "www." + name + ".example.com",
+	}
+}
+
+// listQuery crea una consulta normalizada sin filtros con la paginación indicada
+func listQuery(limit, offset int) model.URLQuery {
+	return model.URLQuery{Limit: limit, Offset: offset}.Normalize()
+}
+
+// shortCodes devuelve los códigos cortos de las URLs en orden
+func shortCodes(urls []*model.URL) []string {
+	codes := make([]string, 0, len(urls))
+	for _, url := range urls {
+		codes = append(codes, url.ShortCode)
+	}
+	return codes
+}
@@ -0,0 +1,117 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports"
+)
+
+// UserRepositoryFactory crea un repositorio de usuarios vacío para un caso de la suite.
+// La factoría es responsable de registrar la limpieza con t.Cleanup.
+type UserRepositoryFactory func(t *testing.T) ports.UserRepository
+
+// RunUserRepositorySuite comprueba que un ports.UserRepository cumple los
+// contratos que el dominio espera de él
+func RunUserRepositorySuite(t *testing.T, factory UserRepositoryFactory) {
+	t.Run("CreateUser assigns an ID findable by every key", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		user := newUser("alice")
+
+		// Act
+		err := repo.CreateUser(ctx, user)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotZero(t, user.ID)
+
+		byID, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, user.Username, byID.Username)
+
+		byUsername, err := repo.GetByUsername(ctx, user.Username)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, byUsername.ID)
+
+		byEmail, err := repo.GetByEmail(ctx, user.Email)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, byEmail.ID)
+	})
+
+	t.Run("CreateUser rejects duplicate usernames and emails", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		require.NoError(t, repo.CreateUser(ctx, newUser("bob")))
+
+		// Act
+		sameUsername := repo.CreateUser(ctx, &model.User{Username: "bob", Email: "other@example.com", Password: "password123"})
+		sameEmail := repo.CreateUser(ctx, &model.User{Username: "other", Email: "bob@example.com", Password: "password123"})
+
+		// Assert
+		assert.Equal(t, errors.ErrDuplicateKey, sameUsername)
+		assert.Equal(t, errors.ErrDuplicateKey, sameEmail)
+	})
+
+	t.Run("lookups return ErrUserNotFound on miss", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+
+		// Act
+		_, byID := repo.GetByID(ctx, 999999)
+		_, byUsername := repo.GetByUsername(ctx, "missing")
+		_, byEmail := repo.GetByEmail(ctx, "missing@example.com")
+
+		// Assert
+		assert.Equal(t, errors.ErrUserNotFound, byID)
+		assert.Equal(t, errors.ErrUserNotFound, byUsername)
+		assert.Equal(t, errors.ErrUserNotFound, byEmail)
+	})
+
+	t.Run("UpdateUser persists changes", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		user := newUser("carol")
+		require.NoError(t, repo.CreateUser(ctx, user))
+
+		// Act
+		user.Email = "carol.new@example.com"
+		err := repo.UpdateUser(ctx, user)
+
+		// Assert
+		require.NoError(t, err)
+		retrievedUser, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "carol.new@example.com", retrievedUser.Email)
+	})
+
+	t.Run("DeleteUser removes the user", func(t *testing.T) {
+		// Arrange
+		repo, ctx := factory(t), context.Background()
+		user := newUser("dave")
+		require.NoError(t, repo.CreateUser(ctx, user))
+
+		// Act
+		err := repo.DeleteUser(ctx, user.ID)
+
+		// Assert
+		require.NoError(t, err)
+		_, err = repo.GetByID(ctx, user.ID)
+		assert.Equal(t, errors.ErrUserNotFound, err)
+		assert.Equal(t, errors.ErrUserNotFound, repo.DeleteUser(ctx, user.ID))
+	})
+}
+
+// newUser crea un usuario sin persistir con datos derivados de name
+func newUser(name string) *model.User {
+	return &model.User{
+		Username: name,
+		Email:    name + "@example.com",
+		Password: "password123",
+	}
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports"
+)
+
+// TagRepository implementa ports.TagRepository
+type TagRepository struct {
+	BaseRepository
+}
+
+// NewTagRepository crea una nueva instancia del repositorio de etiquetas
+func NewTagRepository(db *gorm.DB, opts ...Option) ports.TagRepository {
+	return &TagRepository{
+		BaseRepository: newBaseRepository(db, opts...),
+	}
+}
+
+// List obtiene las etiquetas del usuario ordenadas por nombre
+func (r *TagRepository) List(ctx context.Context, userID uint) ([]model.Tag, error) {
+	tags := []model.Tag{}
+	result := r.read(ctx, func(db *gorm.DB) *gorm.DB {
+		return db.Where("user_id = ?", userID).Order("name").Find(&tags)
+	})
+	if result.Error != nil {
+		return nil, errors.Wrap(result.Error, "error al listar etiquetas")
+	}
+	return tags, nil
+}
+
+// GetByID busca una etiqueta del usuario por su ID
+func (r *TagRepository) GetByID(ctx context.Context, userID, id uint) (*model.Tag, error) {
+	var tag model.Tag
+	err := r.findOne(ctx, &tag, "id = ? AND user_id = ?", id, userID)
+	if err := r.handleGormError(err, errors.ErrTagNotFound, "error al buscar etiqueta"); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// Create guarda una nueva etiqueta en la base de datos
+func (r *TagRepository) Create(ctx context.Context, tag *model.Tag) error {
+	err := r.create(ctx, tag)
+	return r.handleGormError(err, nil, "error al crear etiqueta")
+}
+
+// Rename cambia el nombre de una etiqueta existente
+func (r *TagRepository) Rename(ctx context.Context, tag *model.Tag) error {
+	result := r.write(ctx, func(db *gorm.DB) *gorm.DB {
+		return db.Model(tag).Where("user_id = ?", tag.UserID).Update("name", tag.Name)
+	})
+	if result.Error != nil {
+		return r.handleGormError(result.Error, nil, "error al renombrar etiqueta")
+	}
+	if result.RowsAffected == 0 {
+		return errors.ErrTagNotFound
+	}
+	return nil
+}
+
+// Delete elimina una etiqueta del usuario. Sus URLs la pierden en cascada.
+func (r *TagRepository) Delete(ctx context.Context, userID, id uint) error {
+	rowsAffected, err := r.delete(ctx, &model.Tag{}, "id = ? AND user_id = ?", id, userID)
+	if err != nil {
+		return errors.Wrap(err, "error al eliminar etiqueta")
+	}
+	if rowsAffected == 0 {
+		return errors.ErrTagNotFound
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"tiny-url/internal/domain/ports"
+)
+
+// UnitOfWork implementa ports.UnitOfWork sobre transacciones de GORM
+type UnitOfWork struct {
+	db   *gorm.DB
+	opts []Option
+}
+
+// NewUnitOfWork crea una unidad de trabajo. Las opciones se aplican a los
+// repositorios creados dentro de cada transacción.
+func NewUnitOfWork(db *gorm.DB, opts ...Option) ports.UnitOfWork {
+	return &UnitOfWork{
+		db:   db,
+		opts: opts,
+	}
+}
+
+// txRepos expone los repositorios ligados a una transacción concreta
+type txRepos struct {
+	urls    ports.URLRepository
+	users   ports.UserRepository
+	folders ports.FolderRepository
+}
+
+// URLs devuelve el repositorio de URLs de la transacción
+func (r *txRepos) URLs() ports.URLRepository {
+	return r.urls
+}
+
+// Users devuelve el repositorio de usuarios de la transacción
+func (r *txRepos) Users() ports.UserRepository {
+	return r.users
+}
+
+// Folders devuelve el repositorio de carpetas de la transacción
+func (r *txRepos) Folders() ports.FolderRepository {
+	return r.folders
+}
+
+// Do ejecuta fn dentro de una transacción. Si la unidad de trabajo ya está
+// ligada a una transacción, GORM usa un savepoint.
+func (u *UnitOfWork) Do(ctx context.Context, fn func(tx ports.Repos) error) error {
+	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&txRepos{
+			urls:    NewURLRepository(tx, u.opts...),
+			users:   NewUserRepository(tx, u.opts...),
+			folders: NewFolderRepository(tx, u.opts...),
+		})
+	})
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitOfWork_Commit(t *testing.T) {
+	// Arrange
+	tx, ctx, cleanup := setupTest(t)
+	defer cleanup()
+
+	uow := NewUnitOfWork(tx)
+	username, email := generateUniqueUserData("uow-commit", 1)
+	shortCode, originalURL := generateUniqueData("uow-commit", 1)
+
+	// Act
+	err := uow.Do(ctx, func(repos ports.Repos) error {
+		user := &model.User{Username: username, Email: email, Password: "password123"}
+		if err := repos.Users().CreateUser(ctx, user); err != nil {
+			return err
+		}
+		return repos.URLs().Create(ctx, &model.URL{OriginalURL: originalURL, ShortCode: shortCode, CreatedAt: time.Now()})
+	})
+
+	// Assert
+	require.NoError(t, err)
+
+	_, err = NewUserRepository(tx).GetByUsername(ctx, username)
+	assert.NoError(t, err)
+	_, err = NewURLRepository(tx).GetByShortCode(ctx, shortCode)
+	assert.NoError(t, err)
+}
+
+func TestUnitOfWork_Rollback(t *testing.T) {
+	// Arrange
+	tx, ctx, cleanup := setupTest(t)
+	defer cleanup()
+
+	uow := NewUnitOfWork(tx)
+	username, email := generateUniqueUserData("uow-rollback", 1)
+	shortCode, originalURL := generateUniqueData("uow-rollback", 1)
+	failure := errors.New("fallo simulado")
+
+	// Act
+	err := uow.Do(ctx, func(repos ports.Repos) error {
+		user := &model.User{Username: username, Email: email, Password: "password123"}
+		if err := repos.Users().CreateUser(ctx, user); err != nil {
+			return err
+		}
+		if err := repos.URLs().Create(ctx, &model.URL{OriginalURL: originalURL, ShortCode: shortCode, CreatedAt: time.Now()}); err != nil {
+			return err
+		}
+		return failure
+	})
+
+	// Assert
+	assert.Equal(t, failure, err)
+
+	_, err = NewUserRepository(tx).GetByUsername(ctx, username)
+	assert.Equal(t, errors.ErrUserNotFound, err)
+	_, err = NewURLRepository(tx).GetByShortCode(ctx, shortCode)
+	assert.Equal(t, errors.ErrURLNotFound, err)
+}
@@ -2,8 +2,13 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"tiny-url/internal/domain/errors"
 	"tiny-url/internal/domain/model"
@@ -16,33 +21,58 @@ type URLRepository struct {
 }
 
 // NewURLRepository crea una nueva instancia del repositorio de URL
-func NewURLRepository(db *gorm.DB) ports.URLRepository {
+func NewURLRepository(db *gorm.DB, opts ...Option) ports.URLRepository {
 	return &URLRepository{
-		BaseRepository: newBaseRepository(db),
+		BaseRepository: newBaseRepository(db, opts...),
 	}
 }
 
-// Create guarda una nueva URL en la base de datos
+// Create guarda una nueva URL en la base de datos. Las etiquetas se asignan
+// con AddTags. Dentro de una transacción se usa un savepoint para que un
+// código corto repetido no la aborte y se pueda reintentar con otro.
 func (r *URLRepository) Create(ctx context.Context, url *model.URL) error {
-	err := r.create(url)
-	return r.handleGormError(err, nil, "error al crear URL")
+	var result *gorm.DB
+	r.write(ctx, func(db *gorm.DB) *gorm.DB {
+		_ = db.Transaction(func(tx *gorm.DB) error {
+			result = tx.Omit(clause.Associations).Create(url)
+			return result.Error
+		})
+		return result
+	})
+	return r.handleGormError(result.Error, nil, "error al crear URL")
 }
 
 // GetByShortCode busca una URL por su código corto
 func (r *URLRepository) GetByShortCode(ctx context.Context, shortCode string) (*model.URL, error) {
 	var url model.URL
-	err := r.findOne(&url, "short_code = ?", shortCode)
-	if err := r.handleGormError(err, errors.ErrURLNotFound, "error al buscar URL por código corto"); err != nil {
+	result := r.read(ctx, func(db *gorm.DB) *gorm.DB {
+		return db.Scopes(preloadTags, preloadDeviceRules).Where("short_code = ?", shortCode).First(&url)
+	})
+	if err := r.handleGormError(result.Error, errors.ErrURLNotFound, "error al buscar URL por código corto"); err != nil {
 		return nil, err
 	}
 	return &url, nil
 }
 
-// GetByOriginalURL busca una URL por su URL original
-func (r *URLRepository) GetByOriginalURL(ctx context.Context, originalURL string) (*model.URL, error) {
+// GetDeletedByShortCode busca una URL de la papelera por su código corto
+func (r *URLRepository) GetDeletedByShortCode(ctx context.Context, shortCode string) (*model.URL, error) {
 	var url model.URL
-	err := r.findOne(&url, "original_url = ?", originalURL)
-	if err != nil {
+	result := r.read(ctx, func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped().Scopes(preloadTags, preloadDeviceRules).Where("short_code = ? AND deleted_at IS NOT NULL", shortCode).First(&url)
+	})
+	if err := r.handleGormError(result.Error, errors.ErrURLNotFound, "error al buscar URL en la papelera"); err != nil {
+		return nil, err
+	}
+	return &url, nil
+}
+
+// GetByOriginalURL busca la URL de un usuario por su URL original
+func (r *URLRepository) GetByOriginalURL(ctx context.Context, userID uint, originalURL string) (*model.URL, error) {
+	var url model.URL
+	result := r.read(ctx, func(db *gorm.DB) *gorm.DB {
+		return db.Scopes(preloadTags, preloadDeviceRules).Where("user_id = ? AND original_url = ?", userID, originalURL).First(&url)
+	})
+	if err := result.Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil // No es un error, simplemente no existe
 		}
@@ -51,31 +81,258 @@ func (r *URLRepository) GetByOriginalURL(ctx context.Context, originalURL string
 	return &url, nil
 }
 
-// IncrementVisits incrementa el contador de visitas de una URL
-func (r *URLRepository) IncrementVisits(ctx context.Context, shortCode string) error {
-	rowsAffected, err := r.updateColumn(&model.URL{}, "short_code = ?", "visits", gorm.Expr("visits + ?", 1), shortCode)
-	if err != nil {
-		return errors.Wrap(err, "error al incrementar visitas")
+// Update guarda el propietario, la caducidad y la carpeta de una URL existente
+func (r *URLRepository) Update(ctx context.Context, url *model.URL) error {
+	result := r.write(ctx, func(db *gorm.DB) *gorm.DB {
+		return db.Model(url).Select("user_id", "expires_at", "folder_id", "max_visits", "redirect_code",
+			"forward_query", "forward_path", "updated_at").Updates(url)
+	})
+	if result.Error != nil {
+		return r.handleGormError(result.Error, nil, "error al actualizar URL")
 	}
-	if rowsAffected == 0 {
+	if result.RowsAffected == 0 {
 		return errors.ErrURLNotFound
 	}
 	return nil
 }
 
-// List obtiene todas las URLs con paginación
-func (r *URLRepository) List(ctx context.Context, limit, offset int) ([]*model.URL, error) {
-	var urls []*model.URL
-	err := r.findAll(&urls, limit, offset)
+// AddTags añade etiquetas del propietario a la URL, creando las que falten
+func (r *URLRepository) AddTags(ctx context.Context, url *model.URL, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	var tags []model.Tag
+	result := r.write(ctx, func(db *gorm.DB) *gorm.DB {
+		created := make([]model.Tag, len(names))
+		for i, name := range names {
+			created[i] = model.Tag{UserID: url.UserID, Name: name}
+		}
+		if result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&created); result.Error != nil {
+			return result
+		}
+		if result := db.Where("user_id = ? AND name IN ?", url.UserID, names).Find(&tags); result.Error != nil {
+			return result
+		}
+
+		links := make([]model.URLTag, len(tags))
+		for i, tag := range tags {
+			links[i] = model.URLTag{URLID: url.ID, TagID: tag.ID}
+		}
+		return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&links)
+	})
+	if result.Error != nil {
+		return r.handleGormError(result.Error, nil, "error al añadir etiquetas")
+	}
+	return r.reloadTags(ctx, url)
+}
+
+// RemoveTags quita etiquetas de la URL
+func (r *URLRepository) RemoveTags(ctx context.Context, url *model.URL, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	result := r.write(ctx, func(db *gorm.DB) *gorm.DB {
+		tagIDs := db.Model(&model.Tag{}).Select("id").Where("user_id = ? AND name IN ?", url.UserID, names)
+		return db.Where("url_id = ? AND tag_id IN (?)", url.ID, tagIDs).Delete(&model.URLTag{})
+	})
+	if result.Error != nil {
+		return r.handleGormError(result.Error, nil, "error al quitar etiquetas")
+	}
+	return r.reloadTags(ctx, url)
+}
+
+// reloadTags vuelve a leer las etiquetas de la URL tras modificarlas
+func (r *URLRepository) reloadTags(ctx context.Context, url *model.URL) error {
+	url.Tags = nil
+	result := r.read(ctx, func(db *gorm.DB) *gorm.DB {
+		return db.Joins("JOIN url_tags ON url_tags.tag_id = tags.id").
+			Where("url_tags.url_id = ?", url.ID).Order("tags.name").Find(&url.Tags)
+	})
+	return r.handleGormError(result.Error, nil, "error al leer etiquetas")
+}
+
+// preloadTags carga las etiquetas de las URLs ordenadas por nombre
+func preloadTags(db *gorm.DB) *gorm.DB {
+	return db.Preload("Tags", func(db *gorm.DB) *gorm.DB {
+		return db.Order("tags.name")
+	})
+}
+
+// preloadDeviceRules carga las reglas por dispositivo de las URLs
+func preloadDeviceRules(db *gorm.DB) *gorm.DB {
+	return db.Preload("DeviceRules", func(db *gorm.DB) *gorm.DB {
+		return db.Order("url_device_rules.platform")
+	})
+}
+
+// SetDeviceRules sustituye las reglas por dispositivo de la URL en una
+// transacción, para que nunca se vea un conjunto a medias
+func (r *URLRepository) SetDeviceRules(ctx context.Context, url *model.URL, rules []model.DeviceRule) error {
+	stored := make([]model.DeviceRule, len(rules))
+	for i, rule := range rules {
+		stored[i] = model.DeviceRule{URLID: url.ID, Platform: rule.Platform, Destination: rule.Destination}
+	}
+	slices.SortFunc(stored, func(a, b model.DeviceRule) int { return strings.Compare(string(a.Platform), string(b.Platform)) })
+
+	var result *gorm.DB
+	r.write(ctx, func(db *gorm.DB) *gorm.DB {
+		_ = db.Transaction(func(tx *gorm.DB) error {
+			if result = tx.Where("url_id = ?", url.ID).Delete(&model.DeviceRule{}); result.Error != nil {
+				return result.Error
+			}
+			if len(stored) > 0 {
+				result = tx.Create(&stored)
+			}
+			return result.Error
+		})
+		return result
+	})
+	if err := r.handleGormError(result.Error, nil, "error al guardar reglas por dispositivo"); err != nil {
+		return err
+	}
+	url.DeviceRules = stored
+	return nil
+}
+
+// IncrementVisits incrementa el contador de visitas de una URL. La
+// condición sobre max_visits va en el mismo UPDATE, así que la base de datos
+// impide que visitas concurrentes superen el límite.
+func (r *URLRepository) IncrementVisits(ctx context.Context, shortCode string) (bool, error) {
+	rowsAffected, err := r.updateColumn(ctx, &model.URL{}, "short_code = ? AND (max_visits IS NULL OR visits < max_visits)",
+		"visits", gorm.Expr("visits + ?", 1), shortCode)
 	if err != nil {
-		return nil, errors.Wrap(err, "error al listar URLs")
+		return false, errors.Wrap(err, "error al incrementar visitas")
+	}
+	if rowsAffected > 0 {
+		return true, nil
+	}
+
+	// Ninguna fila cambiada: o la URL no existe o ha agotado sus visitas
+	if _, err := r.GetByShortCode(ctx, shortCode); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+// List obtiene una página de URLs filtrada y ordenada junto con el total
+func (r *URLRepository) List(ctx context.Context, query model.URLQuery) ([]*model.URL, int64, error) {
+	var total int64
+	result := r.read(ctx, func(db *gorm.DB) *gorm.DB {
+		return db.Model(&model.URL{}).Scopes(filterURLs(query.Filter)).Count(&total)
+	})
+	if result.Error != nil {
+		return nil, 0, errors.Wrap(result.Error, "error al contar URLs")
+	}
+
+	// Hacia atrás se recorre el orden inverso desde el cursor y se da la vuelta al resultado
+	backwards := query.Cursor != nil && query.Cursor.Before
+	var urls []*model.URL
+	result = r.read(ctx, func(db *gorm.DB) *gorm.DB {
+		return db.Scopes(filterURLs(query.Filter), seekURLs(query.Cursor), sortURLs(query.Sort, backwards), preloadTags, preloadDeviceRules).
+			Limit(query.Limit).Offset(query.Offset).Find(&urls)
+	})
+	if result.Error != nil {
+		return nil, 0, errors.Wrap(result.Error, "error al listar URLs")
+	}
+	if backwards {
+		slices.Reverse(urls)
+	}
+	return urls, total, nil
+}
+
+// filterURLs aplica los criterios del filtro a la consulta
+func filterURLs(filter model.URLFilter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.UserID != 0 {
+			db = db.Where("user_id = ?", filter.UserID)
+		}
+		if filter.Trashed {
+			db = db.Unscoped().Where("deleted_at IS NOT NULL")
+		}
+		if filter.CreatedFrom != nil {
+			db = db.Where("created_at >= ?", *filter.CreatedFrom)
+		}
+		if filter.CreatedTo != nil {
+			db = db.Where("created_at < ?", *filter.CreatedTo)
+		}
+		switch filter.Expiry {
+		case model.ExpiryActive:
+			db = db.Where("expires_at IS NULL OR expires_at > ?", filter.Now)
+		case model.ExpiryExpired:
+			db = db.Where("expires_at IS NOT NULL AND expires_at <= ?", filter.Now)
+		}
+		switch filter.State {
+		case model.StateScheduled:
+			db = db.Where("active_from IS NOT NULL AND active_from > ?", filter.Now)
+		case model.StateActive:
+			db = db.Where("active_from IS NULL OR active_from <= ?", filter.Now).
+				Where("active_until IS NULL OR active_until > ?", filter.Now)
+		case model.StateEnded:
+			db = db.Where("(active_from IS NULL OR active_from <= ?) AND active_until IS NOT NULL AND active_until <= ?",
+				filter.Now, filter.Now)
+		}
+		if filter.Domain != "" {
+			db = db.Where("domain = ? OR domain LIKE ? ESCAPE '\\'", filter.Domain, "%."+escapeLike(filter.Domain))
+		}
+		if filter.Tag != "" {
+			tagged := db.Session(&gorm.Session{NewDB: true}).Model(&model.URLTag{}).Select("url_tags.url_id").
+				Joins("JOIN tags ON tags.id = url_tags.tag_id").Where("tags.name = ?", filter.Tag)
+			db = db.Where("id IN (?)", tagged)
+		}
+		if filter.FolderID != nil {
+			if *filter.FolderID == 0 {
+				db = db.Where("folder_id IS NULL")
+			} else {
+				db = db.Where("folder_id = ?", *filter.FolderID)
+			}
+		}
+		if filter.Search != "" {
+			pattern := "%" + escapeLike(strings.ToLower(filter.Search)) + "%"
+			db = db.Where("LOWER(original_url) LIKE ? ESCAPE '\\' OR LOWER(short_code) LIKE ? ESCAPE '\\'", pattern, pattern)
+		}
+		return db
 	}
-	return urls, nil
 }
 
-// Delete elimina una URL por su código corto
+// seekURLs limita la consulta a las URLs posteriores a la posición del cursor,
+// o anteriores si cursor.Before, comparando la clave (campo, id)
+func seekURLs(cursor *model.URLCursor) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if cursor == nil {
+			return db
+		}
+		op := ">"
+		if cursor.Sort.Desc() != cursor.Before {
+			op = "<"
+		}
+		field := cursor.Sort.Field()
+		return db.Where(fmt.Sprintf("%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?)", field, op),
+			cursor.Value(), cursor.Value(), cursor.ID)
+	}
+}
+
+// sortURLs ordena la consulta por el campo pedido, desempatando por ID para
+// que las páginas sean estables. reverse invierte el orden.
+func sortURLs(sort model.URLSort, reverse bool) func(db *gorm.DB) *gorm.DB {
+	desc := sort.Desc() != reverse
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Order(clause.OrderByColumn{Column: clause.Column{Name: sort.Field()}, Desc: desc}).
+			Order(clause.OrderByColumn{Column: clause.Column{Name: "id"}, Desc: desc})
+	}
+}
+
+// likeEscaper escapa los comodines de LIKE para buscar el texto literal
+var likeEscaper = strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_")
+
+// escapeLike escapa los comodines de LIKE de s
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// Delete mueve una URL a la papelera por su código corto. Sus etiquetas se
+// conservan para poder restaurarla.
 func (r *URLRepository) Delete(ctx context.Context, shortCode string) error {
-	rowsAffected, err := r.delete(&model.URL{}, "short_code = ?", shortCode)
+	rowsAffected, err := r.delete(ctx, &model.URL{}, "short_code = ?", shortCode)
 	if err != nil {
 		return errors.Wrap(err, "error al eliminar URL")
 	}
@@ -84,3 +341,30 @@ func (r *URLRepository) Delete(ctx context.Context, shortCode string) error {
 	}
 	return nil
 }
+
+// Restore saca de la papelera una URL por su código corto
+func (r *URLRepository) Restore(ctx context.Context, shortCode string) error {
+	result := r.write(ctx, func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped().Model(&model.URL{}).Where("short_code = ? AND deleted_at IS NOT NULL", shortCode).
+			Update("deleted_at", nil)
+	})
+	if result.Error != nil {
+		return errors.Wrap(result.Error, "error al restaurar URL")
+	}
+	if result.RowsAffected == 0 {
+		return errors.ErrURLNotFound
+	}
+	return nil
+}
+
+// PurgeDeleted elimina definitivamente las URLs que entraron en la papelera
+// antes de before. Sus etiquetas se desvinculan en cascada.
+func (r *URLRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	result := r.write(ctx, func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", before).Delete(&model.URL{})
+	})
+	if result.Error != nil {
+		return 0, errors.Wrap(result.Error, "error al purgar la papelera")
+	}
+	return result.RowsAffected, nil
+}
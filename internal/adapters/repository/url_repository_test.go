@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"tiny-url/internal/database/migrations"
 	"tiny-url/internal/domain/errors"
 	"tiny-url/internal/domain/model"
 
@@ -66,14 +67,22 @@ func TestMain(m *testing.M) {
 
 	// Inicializar la conexión de GORM
 	var gormErr error
-	testDB, gormErr = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	testDB, gormErr = gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 	if gormErr != nil {
 		log.Fatalf("Failed to connect to database: %v", gormErr)
 	}
 
-	// Migrar los modelos
-	if err := testDB.AutoMigrate(&model.URL{}, &model.User{}); err != nil {
-		log.Fatalf("Failed to migrate models: %v", err)
+	// Aplicar las migraciones del esquema
+	sqlDB, err := testDB.DB()
+	if err != nil {
+		log.Fatalf("Failed to get database connection: %v", err)
+	}
+	migrator, err := migrations.NewMigrator(sqlDB, migrations.Postgres)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		log.Fatalf("Failed to migrate schema: %v", err)
 	}
 
 	// Ejecutar los tests
@@ -166,7 +175,7 @@ func TestURLRepository_GetByOriginalURL(t *testing.T) {
 	require.NoError(t, err)
 
 	// Act
-	retrievedURL, err := repo.GetByOriginalURL(ctx, originalURL)
+	retrievedURL, err := repo.GetByOriginalURL(ctx, 0, originalURL)
 
 	// Assert
 	assert.NoError(t, err)
@@ -195,10 +204,11 @@ func TestURLRepository_IncrementVisits(t *testing.T) {
 	require.NoError(t, err)
 
 	// Act
-	err = repo.IncrementVisits(ctx, url.ShortCode)
+	allowed, err := repo.IncrementVisits(ctx, url.ShortCode)
 
 	// Assert
 	assert.NoError(t, err)
+	assert.True(t, allowed)
 
 	// Verify the increment
 	updatedURL, err := repo.GetByShortCode(ctx, url.ShortCode)
@@ -232,11 +242,12 @@ func TestURLRepository_List(t *testing.T) {
 	// Act
 	limit := 2
 	offset := 0
-	retrievedURLs, err := repo.List(ctx, limit, offset)
+	retrievedURLs, total, err := repo.List(ctx, model.URLQuery{Limit: limit, Offset: offset}.Normalize())
 
 	// Assert
 	assert.NoError(t, err)
 	assert.Len(t, retrievedURLs, limit)
+	assert.Equal(t, int64(len(urls)), total)
 
 	// El orden es descendente por fecha de creación
 	assert.Equal(t, urls[0].ShortCode, retrievedURLs[0].ShortCode)
@@ -244,7 +255,7 @@ func TestURLRepository_List(t *testing.T) {
 
 	// Prueba paginación
 	offset = 2
-	retrievedURLs, err = repo.List(ctx, limit, offset)
+	retrievedURLs, _, err = repo.List(ctx, model.URLQuery{Limit: limit, Offset: offset}.Normalize())
 	assert.NoError(t, err)
 	assert.Len(t, retrievedURLs, 1)
 	assert.Equal(t, urls[2].ShortCode, retrievedURLs[0].ShortCode)
@@ -296,6 +307,22 @@ func TestURLRepository_GetByShortCode_NotFound(t *testing.T) {
 	assert.Equal(t, errors.ErrURLNotFound, err)
 }
 
+func TestURLRepository_Create_DuplicateShortCode(t *testing.T) {
+	// Arrange
+	tx, ctx, cleanup := setupTest(t)
+	defer cleanup()
+
+	repo := NewURLRepository(tx)
+	shortCode, originalURL := generateUniqueData("duplicate", 1)
+	require.NoError(t, repo.Create(ctx, &model.URL{OriginalURL: originalURL, ShortCode: shortCode, CreatedAt: time.Now()}))
+
+	// Act
+	err := repo.Create(ctx, &model.URL{OriginalURL: originalURL + "/otra", ShortCode: shortCode, CreatedAt: time.Now()})
+
+	// Assert
+	assert.Equal(t, errors.ErrDuplicateKey, err)
+}
+
 func TestURLRepository_Delete_NotFound(t *testing.T) {
 	// Arrange
 	tx, ctx, cleanup := setupTest(t)
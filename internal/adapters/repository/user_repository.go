@@ -16,22 +16,22 @@ type UserRepository struct {
 }
 
 // NewUserRepository crea una nueva instancia del repositorio de usuario
-func NewUserRepository(db *gorm.DB) ports.UserRepository {
+func NewUserRepository(db *gorm.DB, opts ...Option) ports.UserRepository {
 	return &UserRepository{
-		BaseRepository: newBaseRepository(db),
+		BaseRepository: newBaseRepository(db, opts...),
 	}
 }
 
 // CreateUser crea un nuevo usuario en la base de datos
-func (r *UserRepository) CreateUser(user *model.User) error {
-	err := r.create(user)
+func (r *UserRepository) CreateUser(ctx context.Context, user *model.User) error {
+	err := r.create(ctx, user)
 	return r.handleGormError(err, nil, "error al crear usuario")
 }
 
 // GetByID busca un usuario por su ID
 func (r *UserRepository) GetByID(ctx context.Context, id uint) (*model.User, error) {
 	var user model.User
-	err := r.findById(&user, id)
+	err := r.findById(ctx, &user, id)
 	if err := r.handleGormError(err, errors.ErrUserNotFound, "error al buscar usuario por ID"); err != nil {
 		return nil, err
 	}
@@ -41,7 +41,7 @@ func (r *UserRepository) GetByID(ctx context.Context, id uint) (*model.User, err
 // GetByUsername busca un usuario por su nombre de usuario
 func (r *UserRepository) GetByUsername(ctx context.Context, username string) (*model.User, error) {
 	var user model.User
-	err := r.findOne(&user, "username = ?", username)
+	err := r.findOne(ctx, &user, "username = ?", username)
 	if err := r.handleGormError(err, errors.ErrUserNotFound, "error al buscar usuario por nombre de usuario"); err != nil {
 		return nil, err
 	}
@@ -51,7 +51,7 @@ func (r *UserRepository) GetByUsername(ctx context.Context, username string) (*m
 // GetByEmail busca un usuario por su email
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*model.User, error) {
 	var user model.User
-	err := r.findOne(&user, "email = ?", email)
+	err := r.findOne(ctx, &user, "email = ?", email)
 	if err := r.handleGormError(err, errors.ErrUserNotFound, "error al buscar usuario por email"); err != nil {
 		return nil, err
 	}
@@ -59,10 +59,10 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*model.U
 }
 
 // UpdateUser actualiza un usuario existente
-func (r *UserRepository) UpdateUser(user *model.User) error {
-	rowsAffected, err := r.update(user)
-	if err != nil {
-		return errors.Wrap(err, "error al actualizar usuario")
+func (r *UserRepository) UpdateUser(ctx context.Context, user *model.User) error {
+	rowsAffected, err := r.update(ctx, user)
+	if err := r.handleGormError(err, errors.ErrUserNotFound, "error al actualizar usuario"); err != nil {
+		return err
 	}
 	if rowsAffected == 0 {
 		return errors.ErrUserNotFound
@@ -71,8 +71,8 @@ func (r *UserRepository) UpdateUser(user *model.User) error {
 }
 
 // DeleteUser elimina un usuario por su ID
-func (r *UserRepository) DeleteUser(id uint) error {
-	rowsAffected, err := r.deleteById(&model.User{}, id)
+func (r *UserRepository) DeleteUser(ctx context.Context, id uint) error {
+	rowsAffected, err := r.deleteById(ctx, &model.User{}, id)
 	if err != nil {
 		return errors.Wrap(err, "error al eliminar usuario")
 	}
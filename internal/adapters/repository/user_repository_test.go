@@ -37,7 +37,7 @@ func TestUserRepository_CreateUser_GetByID(t *testing.T) {
 	}
 
 	// Act
-	err := repo.CreateUser(user)
+	err := repo.CreateUser(ctx, user)
 	assert.NoError(t, err)
 	assert.NotZero(t, user.ID, "El ID de usuario debería haber sido generado")
 
@@ -69,7 +69,7 @@ func TestUserRepository_GetByUsername(t *testing.T) {
 		UpdatedAt: time.Now(),
 	}
 
-	err := repo.CreateUser(user)
+	err := repo.CreateUser(ctx, user)
 	require.NoError(t, err)
 
 	// Act
@@ -98,7 +98,7 @@ func TestUserRepository_GetByEmail(t *testing.T) {
 		UpdatedAt: time.Now(),
 	}
 
-	err := repo.CreateUser(user)
+	err := repo.CreateUser(ctx, user)
 	require.NoError(t, err)
 
 	// Act
@@ -127,13 +127,13 @@ func TestUserRepository_UpdateUser(t *testing.T) {
 		UpdatedAt: time.Now(),
 	}
 
-	err := repo.CreateUser(user)
+	err := repo.CreateUser(ctx, user)
 	require.NoError(t, err)
 
 	// Act - Update the user
 	updatedEmail := fmt.Sprintf("updated-%s", email)
 	user.Email = updatedEmail
-	err = repo.UpdateUser(user)
+	err = repo.UpdateUser(ctx, user)
 
 	// Assert
 	assert.NoError(t, err)
@@ -160,11 +160,11 @@ func TestUserRepository_DeleteUser(t *testing.T) {
 		UpdatedAt: time.Now(),
 	}
 
-	err := repo.CreateUser(user)
+	err := repo.CreateUser(ctx, user)
 	require.NoError(t, err)
 
 	// Act
-	err = repo.DeleteUser(user.ID)
+	err = repo.DeleteUser(ctx, user.ID)
 
 	// Assert
 	assert.NoError(t, err)
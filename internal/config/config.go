@@ -0,0 +1,196 @@
+// Package config carga la configuración de la aplicación desde variables de entorno.
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	_ "github.com/joho/godotenv/autoload"
+)
+
+// Config agrupa toda la configuración de la aplicación
+type Config struct {
+	// Port es el puerto HTTP en el que escucha el servidor
+	Port int
+
+	// Storage selecciona el backend de almacenamiento: postgres, sqlite o memory
+	Storage string
+
+	// LegacyAPISunset es la fecha prevista para retirar las rutas sin versión;
+	// cero si aún no está decidida
+	LegacyAPISunset time.Time
+
+	// IdempotencyWindow es el tiempo durante el que se repite la respuesta de
+	// una petición con Idempotency-Key
+	IdempotencyWindow time.Duration
+
+	// IdempotencyLease es el tiempo que una petición en curso retiene su
+	// Idempotency-Key; pasado ese tiempo sin respuesta la clave se libera
+	IdempotencyLease time.Duration
+
+	// CursorSecret firma los cursores de paginación; vacío genera una clave
+	// aleatoria al arrancar, así que los cursores no sobreviven a un reinicio
+	CursorSecret string
+
+	// LinkAccessSecret firma los pases de las URLs protegidas con contraseña;
+	// vacío genera una clave aleatoria al arrancar y los pases caducan con el proceso
+	LinkAccessSecret string
+
+	// LinkAccessTTL es el tiempo durante el que un navegador puede visitar una
+	// URL protegida sin volver a introducir la contraseña
+	LinkAccessTTL time.Duration
+
+	// RedirectCode es el código HTTP (301, 302, 307 o 308) de las URLs que no
+	// eligen uno propio
+	RedirectCode int
+
+	// RedirectMaxAge es el tiempo máximo que navegadores y cachés pueden
+	// reutilizar una redirección permanente; cero la hace no cacheable
+	RedirectMaxAge time.Duration
+
+	// BulkMaxItems es el número máximo de URLs por petición de creación masiva
+	BulkMaxItems int
+
+	// TrashRetention es el tiempo que una URL eliminada pasa en la papelera,
+	// con su código reservado, antes de purgarse; cero desactiva la purga
+	TrashRetention time.Duration
+
+	// TrashPurgeInterval es el tiempo entre dos purgas de la papelera
+	TrashPurgeInterval time.Duration
+
+	Database Database
+}
+
+// Backends de almacenamiento soportados
+const (
+	StoragePostgres = "postgres"
+	StorageSQLite   = "sqlite"
+	StorageMemory   = "memory"
+)
+
+// Database contiene los parámetros de conexión y del pool de la base de datos
+type Database struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	Name     string
+	Schema   string
+
+	// SQLitePath es la ruta del fichero de base de datos cuando Storage es sqlite
+	SQLitePath string
+
+	// Tamaño y ciclo de vida del pool de conexiones
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+
+	// Tiempo máximo de cada consulta de lectura y de escritura
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+
+	// SkipMigrations evita aplicar las migraciones pendientes al arrancar
+	SkipMigrations bool
+}
+
+// Load lee la configuración de las variables de entorno, aplicando valores por defecto
+func Load() Config {
+	return Config{
+		Port:               getInt("PORT", 8080),
+		Storage:            getString("STORAGE", StoragePostgres),
+		LegacyAPISunset:    getDate("API_LEGACY_SUNSET"),
+		IdempotencyWindow:  getDuration("IDEMPOTENCY_WINDOW", 24*time.Hour),
+		IdempotencyLease:   getDuration("IDEMPOTENCY_LEASE", time.Minute),
+		CursorSecret:       os.Getenv("CURSOR_SECRET"),
+		LinkAccessSecret:   os.Getenv("LINK_ACCESS_SECRET"),
+		LinkAccessTTL:      getDuration("LINK_ACCESS_TTL", time.Hour),
+		RedirectCode:       getInt("REDIRECT_CODE", 301),
+		RedirectMaxAge:     getDuration("REDIRECT_MAX_AGE", 24*time.Hour),
+		BulkMaxItems:       getInt("BULK_MAX_ITEMS", 500),
+		TrashRetention:     getDuration("TRASH_RETENTION", 30*24*time.Hour),
+		TrashPurgeInterval: getDuration("TRASH_PURGE_INTERVAL", time.Hour),
+		Database: Database{
+			Host:            os.Getenv("BLUEPRINT_DB_HOST"),
+			Port:            os.Getenv("BLUEPRINT_DB_PORT"),
+			Username:        os.Getenv("BLUEPRINT_DB_USERNAME"),
+			Password:        os.Getenv("BLUEPRINT_DB_PASSWORD"),
+			Name:            os.Getenv("BLUEPRINT_DB_DATABASE"),
+			Schema:          os.Getenv("BLUEPRINT_DB_SCHEMA"),
+			SQLitePath:      getString("BLUEPRINT_DB_SQLITE_PATH", "tiny-url.db"),
+			MaxOpenConns:    getInt("BLUEPRINT_DB_MAX_OPEN_CONNS", 25),
+			MaxIdleConns:    getInt("BLUEPRINT_DB_MAX_IDLE_CONNS", 10),
+			ConnMaxLifetime: getDuration("BLUEPRINT_DB_CONN_MAX_LIFETIME", 30*time.Minute),
+			ConnMaxIdleTime: getDuration("BLUEPRINT_DB_CONN_MAX_IDLE_TIME", 5*time.Minute),
+			ReadTimeout:     getDuration("BLUEPRINT_DB_READ_TIMEOUT", 5*time.Second),
+			WriteTimeout:    getDuration("BLUEPRINT_DB_WRITE_TIMEOUT", 10*time.Second),
+			SkipMigrations:  getBool("DB_SKIP_MIGRATIONS", false),
+		},
+	}
+}
+
+// getString lee una cadena de una variable de entorno
+func getString(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// getInt lee un entero de una variable de entorno
+func getInt(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Invalid value for %s: %q, using %d", key, value, fallback)
+		return fallback
+	}
+	return parsed
+}
+
+// getDuration lee una duración (por ejemplo "30s" o "5m") de una variable de entorno
+func getDuration(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Invalid value for %s: %q, using %s", key, value, fallback)
+		return fallback
+	}
+	return parsed
+}
+
+// getDate lee una fecha con formato AAAA-MM-DD de una variable de entorno
+func getDate(key string) time.Time {
+	value := os.Getenv(key)
+	if value == "" {
+		return time.Time{}
+	}
+	parsed, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		log.Printf("Invalid value for %s: %q, ignoring it", key, value)
+		return time.Time{}
+	}
+	return parsed
+}
+
+// getBool lee un booleano de una variable de entorno
+func getBool(key string, fallback bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Invalid value for %s: %q, using %t", key, value, fallback)
+		return fallback
+	}
+	return parsed
+}
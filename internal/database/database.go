@@ -10,48 +10,109 @@ import (
 	"time"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
-	_ "github.com/joho/godotenv/autoload"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"tiny-url/internal/config"
+	"tiny-url/internal/database/migrations"
 )
 
 // Service represents a service that interacts with a database.
+// Both the database/sql and the GORM handles share the same connection pool.
 type Service interface {
 	// Health returns a map of health status information.
 	// The keys and values in the map are service-specific.
 	Health() map[string]string
 
+	// Ping checks that the database is reachable.
+	// It returns an error if the database cannot be reached.
+	Ping(ctx context.Context) error
+
+	// SQL returns the underlying database/sql pool.
+	SQL() *sql.DB
+
+	// Gorm returns a GORM handle backed by the same pool.
+	Gorm() *gorm.DB
+
+	// Migrator returns a schema migrator bound to the pool.
+	Migrator() (*migrations.Migrator, error)
+
 	// Close terminates the database connection.
 	// It returns an error if the connection cannot be closed.
 	Close() error
 }
 
 type service struct {
-	db *sql.DB
+	db      *sql.DB
+	gorm    *gorm.DB
+	name    string
+	dialect migrations.Dialect
 }
 
-var (
-	database   = os.Getenv("BLUEPRINT_DB_DATABASE")
-	password   = os.Getenv("BLUEPRINT_DB_PASSWORD")
-	username   = os.Getenv("BLUEPRINT_DB_USERNAME")
-	port       = os.Getenv("BLUEPRINT_DB_PORT")
-	host       = os.Getenv("BLUEPRINT_DB_HOST")
-	schema     = os.Getenv("BLUEPRINT_DB_SCHEMA")
-	dbInstance *service
-)
-
-func New() Service {
-	// Reuse Connection
-	if dbInstance != nil {
-		return dbInstance
-	}
-	connStr := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable&search_path=%s", username, password, host, port, database, schema)
+// New opens a connection pool configured from cfg and wraps it with GORM.
+// The caller owns the returned service and must Close it on shutdown.
+func New(cfg config.Database) (Service, error) {
+	connStr := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable&search_path=%s",
+		cfg.Username, cfg.Password, cfg.Host, cfg.Port, cfg.Name, cfg.Schema)
 	db, err := sql.Open("pgx", connStr)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	dbInstance = &service{
-		db: db,
+
+	// Configurar el tamaño y el ciclo de vida del pool
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	// Reutilizar el pool existente en lugar de abrir uno nuevo
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), newGormConfig())
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize gorm: %w", err)
 	}
-	return dbInstance
+
+	return &service{
+		db:      db,
+		gorm:    gormDB,
+		name:    cfg.Name,
+		dialect: migrations.Postgres,
+	}, nil
+}
+
+// newGormConfig devuelve la configuración de GORM común a todos los motores
+func newGormConfig() *gorm.Config {
+	// Configurar el logger de GORM
+	newLogger := logger.New(
+		log.New(os.Stdout, "\r\n", log.LstdFlags),
+		logger.Config{
+			SlowThreshold: time.Second,
+			LogLevel:      logger.Info,
+			Colorful:      true,
+		},
+	)
+
+	return &gorm.Config{
+		Logger: newLogger,
+		// Traducir los errores del motor a errores de GORM (por ejemplo, claves duplicadas)
+		TranslateError: true,
+	}
+}
+
+// SQL returns the underlying database/sql pool.
+func (s *service) SQL() *sql.DB {
+	return s.db
+}
+
+// Gorm returns the GORM handle that shares the pool.
+func (s *service) Gorm() *gorm.DB {
+	return s.gorm
+}
+
+// Migrator returns a schema migrator bound to the pool.
+func (s *service) Migrator() (*migrations.Migrator, error) {
+	return migrations.NewMigrator(s.db, s.dialect)
 }
 
 // Health checks the health of the database connection by pinging the database.
@@ -67,7 +128,7 @@ func (s *service) Health() map[string]string {
 	if err != nil {
 		stats["status"] = "down"
 		stats["error"] = fmt.Sprintf("db down: %v", err)
-		log.Fatalf("db down: %v", err) // Log the error and terminate the program
+		log.Printf("db down: %v", err)
 		return stats
 	}
 
@@ -77,6 +138,7 @@ func (s *service) Health() map[string]string {
 
 	// Get database stats (like open connections, in use, idle, etc.)
 	dbStats := s.db.Stats()
+	stats["max_open_connections"] = strconv.Itoa(dbStats.MaxOpenConnections)
 	stats["open_connections"] = strconv.Itoa(dbStats.OpenConnections)
 	stats["in_use"] = strconv.Itoa(dbStats.InUse)
 	stats["idle"] = strconv.Itoa(dbStats.Idle)
@@ -86,7 +148,7 @@ func (s *service) Health() map[string]string {
 	stats["max_lifetime_closed"] = strconv.FormatInt(dbStats.MaxLifetimeClosed, 10)
 
 	// Evaluate stats to provide a health message
-	if dbStats.OpenConnections > 40 { // Assuming 50 is the max for this example
+	if dbStats.MaxOpenConnections > 0 && dbStats.OpenConnections > dbStats.MaxOpenConnections*4/5 {
 		stats["message"] = "The database is experiencing heavy load."
 	}
 
@@ -105,11 +167,16 @@ func (s *service) Health() map[string]string {
 	return stats
 }
 
+// Ping checks that the database is reachable within the context deadline.
+func (s *service) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
 // Close closes the database connection.
 // It logs a message indicating the disconnection from the specific database.
 // If the connection is successfully closed, it returns nil.
 // If an error occurs while closing the connection, it returns the error.
 func (s *service) Close() error {
-	log.Printf("Disconnected from database: %s", database)
+	log.Printf("Disconnected from database: %s", s.name)
 	return s.db.Close()
 }
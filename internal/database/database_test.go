@@ -9,8 +9,16 @@ import (
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
 	"github.com/testcontainers/testcontainers-go/wait"
+
+	"tiny-url/internal/config"
 )
 
+// testConfig apunta al contenedor de PostgreSQL levantado en TestMain
+var testConfig = config.Database{
+	MaxOpenConns: 5,
+	MaxIdleConns: 2,
+}
+
 func mustStartPostgresContainer() (func(context.Context, ...testcontainers.TerminateOption) error, error) {
 	var (
 		dbName = "database"
@@ -33,9 +41,9 @@ func mustStartPostgresContainer() (func(context.Context, ...testcontainers.Termi
 		return nil, err
 	}
 
-	database = dbName
-	password = dbPwd
-	username = dbUser
+	testConfig.Name = dbName
+	testConfig.Password = dbPwd
+	testConfig.Username = dbUser
 
 	dbHost, err := dbContainer.Host(context.Background())
 	if err != nil {
@@ -47,8 +55,8 @@ func mustStartPostgresContainer() (func(context.Context, ...testcontainers.Termi
 		return dbContainer.Terminate, err
 	}
 
-	host = dbHost
-	port = dbPort.Port()
+	testConfig.Host = dbHost
+	testConfig.Port = dbPort.Port()
 
 	return dbContainer.Terminate, err
 }
@@ -66,15 +74,42 @@ func TestMain(m *testing.M) {
 	}
 }
 
+// mustNew abre un servicio contra el contenedor de pruebas
+func mustNew(t *testing.T) Service {
+	srv, err := New(testConfig)
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+	return srv
+}
+
 func TestNew(t *testing.T) {
-	srv := New()
+	srv := mustNew(t)
 	if srv == nil {
 		t.Fatal("New() returned nil")
 	}
+	defer srv.Close()
+}
+
+func TestSharedPool(t *testing.T) {
+	srv := mustNew(t)
+	defer srv.Close()
+
+	gormSQL, err := srv.Gorm().DB()
+	if err != nil {
+		t.Fatalf("Gorm().DB() returned error: %v", err)
+	}
+	if gormSQL != srv.SQL() {
+		t.Fatalf("expected GORM to share the database/sql pool")
+	}
+	if srv.SQL().Stats().MaxOpenConnections != testConfig.MaxOpenConns {
+		t.Fatalf("expected max open connections to be %d, got %d", testConfig.MaxOpenConns, srv.SQL().Stats().MaxOpenConnections)
+	}
 }
 
 func TestHealth(t *testing.T) {
-	srv := New()
+	srv := mustNew(t)
+	defer srv.Close()
 
 	stats := srv.Health()
 
@@ -92,7 +127,7 @@ func TestHealth(t *testing.T) {
 }
 
 func TestClose(t *testing.T) {
-	srv := New()
+	srv := mustNew(t)
 
 	if srv.Close() != nil {
 		t.Fatalf("expected Close() to return nil")
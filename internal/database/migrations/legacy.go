@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownOwner indica que el usuario al que se quieren asignar las URLs
+// sin propietario no existe
+var ErrUnknownOwner = errors.New("unknown owner")
+
+// LegacyURLs cuenta las URLs sin propietario. La migración 0007 añadió
+// user_id con valor 0 a las URLs existentes, que desde entonces no aparecen
+// en ningún listado ni puede modificarlas ningún usuario.
+func (m *Migrator) LegacyURLs(ctx context.Context) (int64, error) {
+	var count int64
+	err := m.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM urls WHERE user_id = 0").Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("error al contar las URLs sin propietario: %w", err)
+	}
+	return count, nil
+}
+
+// ClaimLegacyURLs asigna al usuario username las URLs sin propietario,
+// incluidas las de la papelera, y devuelve cuántas se han asignado
+func (m *Migrator) ClaimLegacyURLs(ctx context.Context, username string) (int64, error) {
+	var userID int64
+	err := m.db.QueryRowContext(ctx, m.bind("SELECT id FROM users WHERE username = $1"), username).Scan(&userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownOwner, username)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error al buscar el usuario: %w", err)
+	}
+
+	result, err := m.db.ExecContext(ctx, m.bind("UPDATE urls SET user_id = $1 WHERE user_id = 0"), userID)
+	if err != nil {
+		return 0, fmt.Errorf("error al asignar las URLs sin propietario: %w", err)
+	}
+	return result.RowsAffected()
+}
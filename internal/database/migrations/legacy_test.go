@@ -0,0 +1,77 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// migrateLegacyDB deja una base de datos SQLite con una URL creada antes de
+// la migración 0007 y un usuario, y aplica el resto de migraciones
+func migrateLegacyDB(t *testing.T) *Migrator {
+	ctx := context.Background()
+	migrator, err := NewMigrator(newSQLiteDB(t), SQLite)
+	require.NoError(t, err)
+	all := migrator.migrations
+
+	migrator.migrations = all[:6]
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	_, err = migrator.db.ExecContext(ctx, "INSERT INTO urls (original_url, short_code) VALUES ('https://example.com/antigua', 'old123')")
+	require.NoError(t, err)
+	_, err = migrator.db.ExecContext(ctx, "INSERT INTO users (username, email, password) VALUES ('admin', 'admin@example.com', 'x')")
+	require.NoError(t, err)
+
+	migrator.migrations = all
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	return migrator
+}
+
+func TestMigrator_ExistingURLsBecomeLegacy(t *testing.T) {
+	// Arrange
+	migrator := migrateLegacyDB(t)
+
+	// Act
+	count, err := migrator.LegacyURLs(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestMigrator_ClaimLegacyURLs(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	migrator := migrateLegacyDB(t)
+	_, err := migrator.db.ExecContext(ctx, "INSERT INTO urls (original_url, short_code, user_id) VALUES ('https://example.com/nueva', 'new123', 7)")
+	require.NoError(t, err)
+
+	// Act
+	claimed, err := migrator.ClaimLegacyURLs(ctx, "admin")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), claimed)
+	var owner, other int64
+	require.NoError(t, migrator.db.QueryRowContext(ctx, "SELECT user_id FROM urls WHERE short_code = 'old123'").Scan(&owner))
+	require.NoError(t, migrator.db.QueryRowContext(ctx, "SELECT user_id FROM urls WHERE short_code = 'new123'").Scan(&other))
+	assert.Equal(t, int64(1), owner)
+	assert.Equal(t, int64(7), other)
+	remaining, err := migrator.LegacyURLs(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, remaining)
+}
+
+func TestMigrator_ClaimLegacyURLsUnknownOwner(t *testing.T) {
+	// Arrange
+	migrator := migrateLegacyDB(t)
+
+	// Act
+	_, err := migrator.ClaimLegacyURLs(context.Background(), "nadie")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrUnknownOwner)
+}
@@ -0,0 +1,157 @@
+// Package migrations contiene las migraciones SQL versionadas del esquema y
+// el migrador que las aplica.
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed postgres/*.sql sqlite/*.sql
+var embedded embed.FS
+
+// Dir es el directorio, relativo a la raíz del repositorio, donde viven los
+// ficheros de migración embebidos, con un subdirectorio por dialecto
+const Dir = "internal/database/migrations"
+
+// Dialect identifica el motor de base de datos al que van dirigidas las migraciones
+type Dialect string
+
+// Dialectos soportados. Cada uno tiene su propio subdirectorio de scripts.
+const (
+	Postgres Dialect = "postgres"
+	SQLite   Dialect = "sqlite"
+)
+
+// Dialects enumera todos los dialectos soportados
+var Dialects = []Dialect{Postgres, SQLite}
+
+// fileNamePattern reconoce nombres del tipo 0001_create_urls.up.sql
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// namePattern valida el nombre descriptivo de una migración nueva
+var namePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Migration representa una migración numerada con sus scripts de subida y bajada
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Load devuelve las migraciones embebidas del dialecto ordenadas por versión
+func Load(dialect Dialect) ([]Migration, error) {
+	sub, err := fs.Sub(embedded, string(dialect))
+	if err != nil {
+		return nil, err
+	}
+	return Parse(sub)
+}
+
+// Parse lee las migraciones de un sistema de ficheros. Cada versión debe tener
+// exactamente un fichero .up.sql y un fichero .down.sql.
+func Parse(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("nombre de migración inválido: %s", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("versión de migración inválida: %s", entry.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("la versión %d tiene nombres distintos: %s y %s", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if strings.TrimSpace(migration.Up) == "" || strings.TrimSpace(migration.Down) == "" {
+			return nil, fmt.Errorf("la migración %d_%s debe tener scripts up y down", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Create genera los ficheros up/down de una nueva migración en el
+// subdirectorio de cada dialecto de dir, usando la siguiente versión
+// disponible. Devuelve las rutas creadas.
+func Create(dir, name string) ([]string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("nombre de migración inválido: %q", name)
+	}
+
+	// Todos los dialectos comparten la numeración de versiones
+	var next int64 = 1
+	for _, dialect := range Dialects {
+		existing, err := Parse(os.DirFS(filepath.Join(dir, string(dialect))))
+		if err != nil {
+			return nil, err
+		}
+		if len(existing) > 0 && existing[len(existing)-1].Version >= next {
+			next = existing[len(existing)-1].Version + 1
+		}
+	}
+
+	base := fmt.Sprintf("%04d_%s", next, name)
+	header := fmt.Sprintf("-- %s\n", base)
+
+	var paths []string
+	for _, dialect := range Dialects {
+		for _, direction := range []string{"up", "down"} {
+			file := filepath.Join(dir, string(dialect), base+"."+direction+".sql")
+			if err := os.WriteFile(file, []byte(header), 0o644); err != nil {
+				return nil, err
+			}
+			paths = append(paths, file)
+		}
+	}
+
+	return paths, nil
+}
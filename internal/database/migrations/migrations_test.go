@@ -0,0 +1,121 @@
+package migrations
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_EmbeddedMigrations(t *testing.T) {
+	for _, dialect := range Dialects {
+		t.Run(string(dialect), func(t *testing.T) {
+			// Act
+			migrations, err := Load(dialect)
+
+			// Assert
+			require.NoError(t, err)
+			require.NotEmpty(t, migrations)
+			for i, migration := range migrations {
+				assert.Equal(t, int64(i+1), migration.Version, "las versiones deben ser consecutivas")
+				assert.NotEmpty(t, migration.Up)
+				assert.NotEmpty(t, migration.Down)
+			}
+		})
+	}
+}
+
+func TestLoad_DialectsInSync(t *testing.T) {
+	// Arrange
+	postgres, err := Load(Postgres)
+	require.NoError(t, err)
+
+	// Act
+	sqlite, err := Load(SQLite)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, sqlite, len(postgres), "cada dialecto debe tener las mismas migraciones")
+	for i := range postgres {
+		assert.Equal(t, postgres[i].Version, sqlite[i].Version)
+		assert.Equal(t, postgres[i].Name, sqlite[i].Name)
+	}
+}
+
+func TestParse_SortsByVersion(t *testing.T) {
+	// Arrange
+	fsys := fstest.MapFS{
+		"0002_second.up.sql":   {Data: []byte("SELECT 2;")},
+		"0002_second.down.sql": {Data: []byte("SELECT -2;")},
+		"0001_first.up.sql":    {Data: []byte("SELECT 1;")},
+		"0001_first.down.sql":  {Data: []byte("SELECT -1;")},
+		"README.md":            {Data: []byte("ignorado")},
+	}
+
+	// Act
+	migrations, err := Parse(fsys)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+	assert.Equal(t, "first", migrations[0].Name)
+	assert.Equal(t, "SELECT 1;", migrations[0].Up)
+	assert.Equal(t, "SELECT -1;", migrations[0].Down)
+	assert.Equal(t, int64(2), migrations[1].Version)
+}
+
+func TestParse_MissingDown(t *testing.T) {
+	// Arrange
+	fsys := fstest.MapFS{
+		"0001_first.up.sql": {Data: []byte("SELECT 1;")},
+	}
+
+	// Act
+	_, err := Parse(fsys)
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestParse_InvalidName(t *testing.T) {
+	// Arrange
+	fsys := fstest.MapFS{
+		"first.up.sql": {Data: []byte("SELECT 1;")},
+	}
+
+	// Act
+	_, err := Parse(fsys)
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestCreate_NextVersion(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	for _, dialect := range Dialects {
+		require.NoError(t, os.Mkdir(filepath.Join(dir, string(dialect)), 0o755))
+	}
+	postgresDir := filepath.Join(dir, string(Postgres))
+	require.NoError(t, os.WriteFile(filepath.Join(postgresDir, "0001_first.up.sql"), []byte("SELECT 1;"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(postgresDir, "0001_first.down.sql"), []byte("SELECT -1;"), 0o644))
+
+	// Act
+	paths, err := Create(dir, "Add Column")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "postgres", "0002_add_column.up.sql"),
+		filepath.Join(dir, "postgres", "0002_add_column.down.sql"),
+		filepath.Join(dir, "sqlite", "0002_add_column.up.sql"),
+		filepath.Join(dir, "sqlite", "0002_add_column.down.sql"),
+	}, paths)
+
+	migrations, err := Parse(os.DirFS(postgresDir))
+	require.NoError(t, err)
+	assert.Len(t, migrations, 2)
+}
@@ -0,0 +1,253 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+)
+
+// placeholderPattern reconoce los marcadores posicionales de PostgreSQL
+var placeholderPattern = regexp.MustCompile(`\$\d+`)
+
+// lockKey identifica el advisory lock de PostgreSQL que serializa las
+// migraciones entre réplicas. El valor es arbitrario pero debe ser estable.
+const lockKey int64 = 7263580114
+
+// Status describe el estado de una migración en la base de datos
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+// Migrator aplica y revierte migraciones sobre una base de datos
+type Migrator struct {
+	db         *sql.DB
+	dialect    Dialect
+	migrations []Migration
+}
+
+// NewMigrator crea un migrador con las migraciones embebidas del dialecto indicado
+func NewMigrator(db *sql.DB, dialect Dialect) (*Migrator, error) {
+	migrations, err := Load(dialect)
+	if err != nil {
+		return nil, fmt.Errorf("error al cargar migraciones: %w", err)
+	}
+	return &Migrator{db: db, dialect: dialect, migrations: migrations}, nil
+}
+
+// LatestVersion devuelve la versión más alta conocida por el binario
+func (m *Migrator) LatestVersion() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up aplica todas las migraciones pendientes y devuelve las aplicadas
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		versions, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := versions[migration.Version]; ok {
+				continue
+			}
+
+			err := inTx(ctx, conn, func(tx *sql.Tx) error {
+				if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+					return err
+				}
+				_, err := tx.ExecContext(ctx,
+					m.bind("INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, $3)"),
+					migration.Version, migration.Name, time.Now().UTC())
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("error al aplicar la migración %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			log.Printf("Migración aplicada: %d_%s", migration.Version, migration.Name)
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down revierte las últimas steps migraciones aplicadas y devuelve las revertidas
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps <= 0 {
+		return nil, fmt.Errorf("el número de pasos debe ser positivo: %d", steps)
+	}
+
+	var reverted []Migration
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		versions, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := versions[migration.Version]; !ok {
+				continue
+			}
+
+			err := inTx(ctx, conn, func(tx *sql.Tx) error {
+				if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+					return err
+				}
+				_, err := tx.ExecContext(ctx, m.bind("DELETE FROM schema_migrations WHERE version = $1"), migration.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("error al revertir la migración %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			log.Printf("Migración revertida: %d_%s", migration.Version, migration.Name)
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Status devuelve el estado de cada migración conocida por el binario
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := m.ensureVersionTable(ctx, conn); err != nil {
+		return nil, err
+	}
+
+	versions, err := appliedVersions(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if appliedAt, ok := versions[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Version devuelve la versión más alta aplicada en la base de datos
+func (m *Migrator) Version(ctx context.Context) (int64, error) {
+	var version sql.NullInt64
+	err := m.db.QueryRowContext(ctx, "SELECT MAX(version) FROM schema_migrations").Scan(&version)
+	if err != nil {
+		return 0, err
+	}
+	return version.Int64, nil
+}
+
+// withLock ejecuta fn sobre una conexión dedicada que mantiene el advisory lock
+// de migraciones, de modo que varias réplicas no migren a la vez. SQLite no
+// admite varias réplicas y serializa las escrituras por sí mismo.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if m.dialect == Postgres {
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+			return fmt.Errorf("error al adquirir el bloqueo de migraciones: %w", err)
+		}
+		defer func() {
+			// El contexto original puede haber expirado; el desbloqueo debe ejecutarse igualmente
+			if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey); err != nil {
+				log.Printf("Error al liberar el bloqueo de migraciones: %v", err)
+			}
+		}()
+	}
+
+	if err := m.ensureVersionTable(ctx, conn); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+// bind adapta los marcadores $N de una consulta al dialecto del migrador
+func (m *Migrator) bind(query string) string {
+	if m.dialect == SQLite {
+		return placeholderPattern.ReplaceAllString(query, "?")
+	}
+	return query
+}
+
+// ensureVersionTable crea la tabla de versiones si no existe
+func (m *Migrator) ensureVersionTable(ctx context.Context, conn *sql.Conn) error {
+	timestampType := "TIMESTAMPTZ"
+	if m.dialect == SQLite {
+		timestampType = "DATETIME"
+	}
+
+	_, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    BIGINT PRIMARY KEY,
+		name       TEXT NOT NULL,
+		applied_at `+timestampType+` NOT NULL
+	)`)
+	if err != nil {
+		return fmt.Errorf("error al crear la tabla schema_migrations: %w", err)
+	}
+	return nil
+}
+
+// appliedVersions devuelve las versiones aplicadas junto con su fecha
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[int64]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		versions[version] = appliedAt
+	}
+	return versions, rows.Err()
+}
+
+// inTx ejecuta fn dentro de una transacción sobre la conexión indicada
+func inTx(ctx context.Context, conn *sql.Conn, fn func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
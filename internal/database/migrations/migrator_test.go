@@ -0,0 +1,62 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSQLiteDB abre una base de datos SQLite en memoria para probar el migrador sin Docker
+func newSQLiteDB(t *testing.T) *sql.DB {
+	db, err := sql.Open(sqlite.DriverName, ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestMigrator_SQLiteUpAndDown(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	migrator, err := NewMigrator(newSQLiteDB(t), SQLite)
+	require.NoError(t, err)
+
+	// Act
+	applied, err := migrator.Up(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, applied, len(migrator.migrations))
+
+	version, err := migrator.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, migrator.LatestVersion(), version)
+
+	reverted, err := migrator.Down(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, reverted, 1)
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.False(t, statuses[len(statuses)-1].Applied)
+}
+
+func TestMigrator_SQLiteUpIsIdempotent(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	migrator, err := NewMigrator(newSQLiteDB(t), SQLite)
+	require.NoError(t, err)
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+
+	// Act
+	applied, err := migrator.Up(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+}
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS urls (
+    id           BIGSERIAL PRIMARY KEY,
+    original_url TEXT        NOT NULL,
+    short_code   VARCHAR(10) NOT NULL,
+    visits       BIGINT      DEFAULT 0,
+    created_at   TIMESTAMPTZ,
+    updated_at   TIMESTAMPTZ,
+    expires_at   TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_urls_short_code ON urls (short_code);
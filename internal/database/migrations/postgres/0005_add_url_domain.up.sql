@@ -0,0 +1,7 @@
+ALTER TABLE urls ADD COLUMN domain VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Rellenar el dominio de las URLs existentes a partir de la URL original
+UPDATE urls SET domain = lower(coalesce(substring(original_url from '://(?:[^/?#@]*@)?([^/?#:]+)'), ''));
+
+CREATE INDEX IF NOT EXISTS idx_urls_domain ON urls (domain);
+CREATE INDEX IF NOT EXISTS idx_urls_created_at ON urls (created_at, id);
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_urls_visits ON urls (visits, id);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS url_tags;
+DROP TABLE IF EXISTS tags;
+DROP INDEX IF EXISTS idx_urls_user_id;
+ALTER TABLE urls DROP COLUMN user_id;
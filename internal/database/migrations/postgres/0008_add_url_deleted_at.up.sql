@@ -0,0 +1,3 @@
+ALTER TABLE urls ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_urls_deleted_at ON urls (deleted_at);
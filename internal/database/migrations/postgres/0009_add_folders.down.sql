@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_urls_folder_id;
+ALTER TABLE urls DROP COLUMN folder_id;
+DROP TABLE IF EXISTS folders;
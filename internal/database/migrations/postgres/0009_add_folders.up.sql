@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS folders (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT       NOT NULL,
+    name       VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_folders_user_name ON folders (user_id, name);
+
+ALTER TABLE urls ADD COLUMN folder_id BIGINT REFERENCES folders (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_urls_folder_id ON urls (folder_id);
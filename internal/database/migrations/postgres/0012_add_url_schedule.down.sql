@@ -0,0 +1,3 @@
+ALTER TABLE urls DROP COLUMN fallback_url;
+ALTER TABLE urls DROP COLUMN active_until;
+ALTER TABLE urls DROP COLUMN active_from;
@@ -0,0 +1,3 @@
+ALTER TABLE urls ADD COLUMN active_from TIMESTAMPTZ;
+ALTER TABLE urls ADD COLUMN active_until TIMESTAMPTZ;
+ALTER TABLE urls ADD COLUMN fallback_url TEXT NOT NULL DEFAULT '';
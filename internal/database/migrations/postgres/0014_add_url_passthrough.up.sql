@@ -0,0 +1,2 @@
+ALTER TABLE urls ADD COLUMN forward_query VARCHAR(10) NOT NULL DEFAULT '';
+ALTER TABLE urls ADD COLUMN forward_path BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS url_device_rules (
+    url_id      BIGINT      NOT NULL REFERENCES urls (id) ON DELETE CASCADE,
+    platform    VARCHAR(10) NOT NULL,
+    destination TEXT        NOT NULL,
+    PRIMARY KEY (url_id, platform)
+);
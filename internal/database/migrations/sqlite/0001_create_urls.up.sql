@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS urls (
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    original_url TEXT        NOT NULL,
+    short_code   VARCHAR(10) NOT NULL,
+    visits       INTEGER     DEFAULT 0,
+    created_at   DATETIME,
+    updated_at   DATETIME,
+    expires_at   DATETIME
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_urls_short_code ON urls (short_code);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_urls_created_at;
+DROP INDEX IF EXISTS idx_urls_domain;
+ALTER TABLE urls DROP COLUMN domain;
@@ -0,0 +1,13 @@
+ALTER TABLE urls ADD COLUMN domain VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Rellenar el dominio de las URLs existentes a partir de la URL original,
+-- recortando por pasos lo que sigue al nombre de host
+UPDATE urls SET domain = lower(substr(original_url, instr(original_url, '://') + 3)) WHERE instr(original_url, '://') > 0;
+UPDATE urls SET domain = substr(domain, 1, instr(domain, '/') - 1) WHERE instr(domain, '/') > 0;
+UPDATE urls SET domain = substr(domain, 1, instr(domain, '?') - 1) WHERE instr(domain, '?') > 0;
+UPDATE urls SET domain = substr(domain, 1, instr(domain, '#') - 1) WHERE instr(domain, '#') > 0;
+UPDATE urls SET domain = substr(domain, instr(domain, '@') + 1) WHERE instr(domain, '@') > 0;
+UPDATE urls SET domain = substr(domain, 1, instr(domain, ':') - 1) WHERE instr(domain, ':') > 0;
+
+CREATE INDEX IF NOT EXISTS idx_urls_domain ON urls (domain);
+CREATE INDEX IF NOT EXISTS idx_urls_created_at ON urls (created_at, id);
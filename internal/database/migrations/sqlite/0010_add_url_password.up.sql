@@ -0,0 +1 @@
+ALTER TABLE urls ADD COLUMN password_hash VARCHAR(255) NOT NULL DEFAULT '';
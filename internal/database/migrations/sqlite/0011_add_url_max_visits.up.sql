@@ -0,0 +1 @@
+ALTER TABLE urls ADD COLUMN max_visits INTEGER;
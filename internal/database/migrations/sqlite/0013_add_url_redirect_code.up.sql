@@ -0,0 +1 @@
+ALTER TABLE urls ADD COLUMN redirect_code INTEGER NOT NULL DEFAULT 0;
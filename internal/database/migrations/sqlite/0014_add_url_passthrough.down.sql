@@ -0,0 +1,2 @@
+ALTER TABLE urls DROP COLUMN forward_path;
+ALTER TABLE urls DROP COLUMN forward_query;
@@ -0,0 +1 @@
+ALTER TABLE urls ADD COLUMN templated BOOLEAN NOT NULL DEFAULT 0;
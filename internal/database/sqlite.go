@@ -0,0 +1,61 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+
+	"tiny-url/internal/config"
+	"tiny-url/internal/database/migrations"
+)
+
+// MemorySQLitePath abre una base de datos SQLite que vive solo en memoria
+const MemorySQLitePath = ":memory:"
+
+// NewSQLite opens a SQLite database file configured from cfg and wraps it with GORM.
+// It is meant for single-node deployments where running PostgreSQL is not worth it.
+// The caller owns the returned service and must Close it on shutdown.
+func NewSQLite(cfg config.Database) (Service, error) {
+	// Claves foráneas activas, espera ante bloqueos y WAL para permitir lectores concurrentes
+	pragmas := url.Values{}
+	pragmas.Add("_pragma", "foreign_keys(1)")
+	pragmas.Add("_pragma", "busy_timeout(5000)")
+	if cfg.SQLitePath != MemorySQLitePath {
+		pragmas.Add("_pragma", "journal_mode(WAL)")
+	}
+
+	db, err := sql.Open(sqlite.DriverName, cfg.SQLitePath+"?"+pragmas.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	// Cada conexión a :memory: es una base de datos distinta, así que se usa una sola
+	if cfg.SQLitePath == MemorySQLitePath {
+		db.SetMaxOpenConns(1)
+		db.SetMaxIdleConns(1)
+		db.SetConnMaxLifetime(0)
+		db.SetConnMaxIdleTime(0)
+	} else {
+		db.SetMaxOpenConns(cfg.MaxOpenConns)
+		db.SetMaxIdleConns(cfg.MaxIdleConns)
+		db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+		db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	}
+
+	// Reutilizar el pool existente en lugar de abrir uno nuevo
+	gormDB, err := gorm.Open(&sqlite.Dialector{Conn: db}, newGormConfig())
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize gorm: %w", err)
+	}
+
+	return &service{
+		db:      db,
+		gorm:    gormDB,
+		name:    cfg.SQLitePath,
+		dialect: migrations.SQLite,
+	}, nil
+}
@@ -3,14 +3,40 @@ package errors
 
 import (
 	"errors"
+	"time"
 )
 
 // Errores comunes de la aplicación
 var (
 	// Errores del servicio de URL
-	ErrURLNotFound    = errors.New("url not found")
-	ErrInvalidURL     = errors.New("invalid url")
-	ErrGeneratingCode = errors.New("error generating short code")
+	ErrURLNotFound       = errors.New("url not found")
+	ErrInvalidURL        = errors.New("invalid url")
+	ErrGeneratingCode    = errors.New("error generating short code")
+	ErrInvalidCursor     = errors.New("invalid pagination cursor")
+	ErrInvalidBatch      = errors.New("invalid batch operation")
+	ErrBatchTooLarge     = errors.New("batch filter matches too many urls")
+	ErrURLExhausted      = errors.New("url has no visits left")
+	ErrInvalidLimit      = errors.New("invalid visit limit")
+	ErrInvalidRedirect   = errors.New("invalid redirect code")
+	ErrInvalidForwarding = errors.New("invalid query forwarding policy")
+	ErrInvalidTemplate   = errors.New("invalid destination template")
+	ErrInvalidDeviceRule = errors.New("invalid device rule")
+
+	// Errores de la programación de las URLs
+	ErrURLNotYetActive = errors.New("url is not active yet")
+	ErrURLEnded        = errors.New("url is no longer active")
+	ErrInvalidSchedule = errors.New("invalid activation window")
+
+	// Errores de las URLs protegidas con contraseña
+	ErrInvalidPassword  = errors.New("invalid link password")
+	ErrPasswordRequired = errors.New("link password required")
+	ErrWrongPassword    = errors.New("wrong link password")
+
+	// Errores de etiquetas y carpetas
+	ErrTagNotFound    = errors.New("tag not found")
+	ErrFolderNotFound = errors.New("folder not found")
+	ErrInvalidTag     = errors.New("invalid tag name")
+	ErrInvalidFolder  = errors.New("invalid folder name")
 
 	// Errores del servicio de autenticación
 	ErrInvalidCredentials = errors.New("invalid credentials")
@@ -24,12 +50,33 @@ var (
 	ErrRecordNotFound     = errors.New("record not found")
 	ErrDuplicateKey       = errors.New("duplicate key error")
 
+	// Errores de idempotencia
+	ErrInvalidIdempotencyKey    = errors.New("invalid idempotency key")
+	ErrIdempotencyKeyReused     = errors.New("idempotency key reused with a different request")
+	ErrIdempotencyKeyInProgress = errors.New("request with this idempotency key is still in progress")
+
 	// Errores generales
-	ErrInternalServer = errors.New("internal server error")
-	ErrUnauthorized   = errors.New("unauthorized")
-	ErrForbidden      = errors.New("forbidden")
+	ErrInternalServer  = errors.New("internal server error")
+	ErrUnauthorized    = errors.New("unauthorized")
+	ErrForbidden       = errors.New("forbidden")
+	ErrRequestTooLarge = errors.New("request body too large")
 )
 
+// NotYetActiveError indica que una URL empezará a redirigir en ActiveFrom.
+// Equivale a ErrURLNotYetActive para errors.Is.
+type NotYetActiveError struct {
+	ActiveFrom time.Time
+}
+
+func (e *NotYetActiveError) Error() string {
+	return ErrURLNotYetActive.Error() + " until " + e.ActiveFrom.Format(time.RFC3339)
+}
+
+// Is permite comparar el error con ErrURLNotYetActive
+func (e *NotYetActiveError) Is(target error) bool {
+	return target == ErrURLNotYetActive
+}
+
 // New crea un nuevo error con el mensaje especificado.
 func New(text string) error {
 	return errors.New(text)
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"time"
+)
+
+// BatchAction es la operación que se aplica a todas las URLs de un lote
+type BatchAction string
+
+// Operaciones soportadas sobre lotes de URLs
+const (
+	BatchDelete      BatchAction = "delete"       // Eliminar las URLs
+	BatchSetExpiry   BatchAction = "set-expiry"   // Cambiar o quitar la caducidad
+	BatchAddTags     BatchAction = "add-tags"     // Añadir etiquetas
+	BatchRemoveTags  BatchAction = "remove-tags"  // Quitar etiquetas
+	BatchChangeOwner BatchAction = "change-owner" // Transferir las URLs a otro usuario
+)
+
+// URLBatch describe una operación sobre varias URLs de un usuario. Las URLs
+// se eligen por sus códigos cortos o, si no hay códigos, con Filter.
+type URLBatch struct {
+	// UserID es el usuario que pide la operación; solo se tocan sus URLs
+	UserID uint
+	Action BatchAction
+
+	ShortCodes []string
+	Filter     *URLFilter
+	// MaxTargets es el número máximo de URLs que puede tocar un lote con
+	// filtro; cero no lo limita
+	MaxTargets int
+
+	// ExpiresAt es la nueva caducidad de set-expiry; nil la quita
+	ExpiresAt *time.Time
+	// Tags son las etiquetas de add-tags y remove-tags
+	Tags []string
+	// Owner es el nombre del nuevo propietario de change-owner
+	Owner string
+}
+
+// BatchResult es el resultado de la operación sobre una URL del lote
+type BatchResult struct {
+	ShortCode string
+	Status    BulkStatus
+	Err       error
+}
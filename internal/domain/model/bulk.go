@@ -0,0 +1,20 @@
+package model
+
+// BulkStatus indica qué ocurrió con un elemento de una operación masiva
+type BulkStatus string
+
+// Estados de los elementos de una operación masiva
+const (
+	BulkCreated  BulkStatus = "created"  // Se creó una URL nueva
+	BulkExisting BulkStatus = "existing" // Ya existía una URL para el mismo destino
+	BulkApplied  BulkStatus = "applied"  // Se aplicó la operación a una URL existente
+	BulkFailed   BulkStatus = "failed"   // El elemento no es válido o no se pudo guardar
+	BulkSkipped  BulkStatus = "skipped"  // No se aplicó porque otro elemento falló en modo atómico
+)
+
+// BulkResult es el resultado de un elemento de una operación masiva
+type BulkResult struct {
+	URL    *URL
+	Status BulkStatus
+	Err    error
+}
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Platform es el tipo de dispositivo desde el que se visita una URL,
+// deducido de su User-Agent
+type Platform string
+
+// Plataformas de las reglas por dispositivo
+const (
+	PlatformIOS     Platform = "ios"
+	PlatformAndroid Platform = "android"
+	PlatformDesktop Platform = "desktop"
+	PlatformBot     Platform = "bot"
+)
+
+// Platforms son las plataformas para las que se pueden definir reglas
+var Platforms = []Platform{PlatformIOS, PlatformAndroid, PlatformDesktop, PlatformBot}
+
+// Valid indica si la plataforma es una de las soportadas
+func (p Platform) Valid() bool {
+	return slices.Contains(Platforms, p)
+}
+
+// botMarkers son fragmentos del User-Agent de rastreadores y generadores de
+// vistas previas de enlaces
+var botMarkers = []string{"bot", "crawler", "spider", "slurp", "facebookexternalhit", "embedly", "preview"}
+
+// PlatformOf deduce la plataforma de un User-Agent. Los rastreadores se
+// reconocen antes que los móviles, porque muchos se anuncian como Android o
+// iPhone; sin User-Agent se supone un programa y no un navegador.
+func PlatformOf(userAgent string) Platform {
+	ua := strings.ToLower(userAgent)
+	switch {
+	case ua == "" || slices.ContainsFunc(botMarkers, func(marker string) bool { return strings.Contains(ua, marker) }):
+		return PlatformBot
+	case strings.Contains(ua, "iphone") || strings.Contains(ua, "ipad") || strings.Contains(ua, "ipod"):
+		return PlatformIOS
+	case strings.Contains(ua, "android"):
+		return PlatformAndroid
+	default:
+		return PlatformDesktop
+	}
+}
+
+// DeviceRule envía las visitas desde una plataforma a otro destino, como la
+// ficha de la app en su tienda o un deep link. Cada URL tiene como mucho una
+// regla por plataforma.
+type DeviceRule struct {
+	URLID       uint     `json:"-" gorm:"primaryKey"`
+	Platform    Platform `json:"platform" gorm:"type:varchar(10);primaryKey"`
+	Destination string   `json:"destination" gorm:"type:text;not null"`
+}
+
+// TableName indica la tabla de las reglas por dispositivo
+func (DeviceRule) TableName() string {
+	return "url_device_rules"
+}
+
+// unsafeSchemes son los esquemas que un destino no puede usar porque
+// ejecutan código o leen ficheros en el navegador del visitante
+var unsafeSchemes = []string{"javascript", "data", "vbscript", "file"}
+
+// ValidDeviceRules indica si las reglas son aplicables: plataformas soportadas
+// sin repetir y destinos absolutos. Los destinos admiten esquemas propios de
+// una app (miapp://producto/42) para los deep links.
+func ValidDeviceRules(rules []DeviceRule) bool {
+	seen := make(map[Platform]bool, len(rules))
+	for _, rule := range rules {
+		if !rule.Platform.Valid() || seen[rule.Platform] {
+			return false
+		}
+		seen[rule.Platform] = true
+
+		destination, err := url.Parse(rule.Destination)
+		if err != nil || destination.Scheme == "" || slices.Contains(unsafeSchemes, strings.ToLower(destination.Scheme)) {
+			return false
+		}
+		if (destination.Scheme == "http" || destination.Scheme == "https") && destination.Host == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// DeviceRuleFor devuelve la regla de la URL para la plataforma del User-Agent,
+// o nil si no tiene ninguna
+func (u *URL) DeviceRuleFor(userAgent string) *DeviceRule {
+	if len(u.DeviceRules) == 0 {
+		return nil
+	}
+	platform := PlatformOf(userAgent)
+	for i := range u.DeviceRules {
+		if u.DeviceRules[i].Platform == platform {
+			return &u.DeviceRules[i]
+		}
+	}
+	return nil
+}
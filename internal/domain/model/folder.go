@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+)
+
+// MaxFolderNameLength es la longitud máxima del nombre de una carpeta
+const MaxFolderNameLength = 100
+
+// Folder es una carpeta en la que un usuario agrupa sus URLs. Cada URL está
+// como mucho en una carpeta y cada usuario tiene las suyas, con nombres únicos.
+type Folder struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint      `json:"-" gorm:"not null;uniqueIndex:idx_folders_user_name"`
+	Name      string    `json:"name" gorm:"type:varchar(100);not null;uniqueIndex:idx_folders_user_name"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
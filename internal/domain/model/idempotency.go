@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+)
+
+// IdempotencyRecord guarda la primera respuesta a una petición enviada con la
+// cabecera Idempotency-Key, para repetirla ante los reintentos del cliente
+type IdempotencyRecord struct {
+	ID          uint      `gorm:"primaryKey"`
+	UserID      uint      `gorm:"not null;uniqueIndex:idx_idempotency_keys_user_key"`
+	Key         string    `gorm:"column:idempotency_key;type:varchar(255);not null;uniqueIndex:idx_idempotency_keys_user_key"`
+	RequestHash string    `gorm:"type:varchar(64);not null"` // Huella del método, la ruta y el cuerpo
+	StatusCode  int       `gorm:"not null;default:0"`        // Cero mientras la petición original sigue en curso
+	ContentType string    `gorm:"type:varchar(255);not null;default:''"`
+	Body        []byte    `gorm:"not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	ExpiresAt   time.Time `gorm:"not null;index"`
+}
+
+// TableName indica la tabla de los registros de idempotencia
+func (IdempotencyRecord) TableName() string {
+	return "idempotency_keys"
+}
+
+// Completed indica si ya se conoce la respuesta de la petición original
+func (r *IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
+
+// Expired indica si el registro ha superado su ventana de validez en now
+func (r *IdempotencyRecord) Expired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
@@ -0,0 +1,126 @@
+package model
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// RedirectCodes son los códigos HTTP con los que una URL puede redirigir
+var RedirectCodes = []int{
+	http.StatusMovedPermanently,
+	http.StatusFound,
+	http.StatusTemporaryRedirect,
+	http.StatusPermanentRedirect,
+}
+
+// ValidRedirectCode indica si code es uno de los RedirectCodes
+func ValidRedirectCode(code int) bool {
+	return slices.Contains(RedirectCodes, code)
+}
+
+// PermanentRedirect indica si los navegadores pueden recordar la redirección
+// con code en lugar de volver a pedirla
+func PermanentRedirect(code int) bool {
+	return code == http.StatusMovedPermanently || code == http.StatusPermanentRedirect
+}
+
+// Redirect es el destino al que se envía una visita
+type Redirect struct {
+	Location string
+	Status   int
+
+	// MaxAge es el tiempo que navegadores y cachés pueden reutilizar la
+	// redirección; cero obliga a pedirla en cada visita
+	MaxAge time.Duration
+}
+
+// QueryForwarding indica cómo se combina la query string de una visita con
+// los parámetros propios del destino
+type QueryForwarding string
+
+// Políticas de paso de la query string
+const (
+	QueryNotForwarded QueryForwarding = ""         // La query string de la visita se descarta
+	QueryKeep         QueryForwarding = "keep"     // Ante un conflicto gana el parámetro del destino
+	QueryOverride     QueryForwarding = "override" // Ante un conflicto gana el parámetro de la visita
+	QueryAppend       QueryForwarding = "append"   // Se conservan los valores de ambos
+)
+
+// Valid indica si la política es una de las soportadas
+func (f QueryForwarding) Valid() bool {
+	switch f {
+	case QueryNotForwarded, QueryKeep, QueryOverride, QueryAppend:
+		return true
+	default:
+		return false
+	}
+}
+
+// Destination devuelve el destino de una visita a la URL: el de la regla de
+// su plataforma o, sin regla, la URL original o su plantilla rellenada con la
+// visita; en todos los casos con la ruta y la query string de la visita si la
+// URL las pasa. La ruta se limpia de "." y "..", así que nunca sale de la
+// ruta del destino.
+func (u *URL) Destination(visit Visit) string {
+	target := u.OriginalURL
+	if rule := u.DeviceRuleFor(visit.Header.Get("User-Agent")); rule != nil {
+		target = rule.Destination
+	} else if u.Templated {
+		template, err := ParseTemplate(u.OriginalURL)
+		if err != nil {
+			return u.OriginalURL
+		}
+		target = template.Render(visit)
+	}
+
+	forwardPath := u.ForwardPath && strings.Trim(visit.Path, "/") != ""
+	forwardQuery := u.ForwardQuery != QueryNotForwarded && visit.RawQuery != ""
+	if !forwardPath && !forwardQuery {
+		return target
+	}
+	destination, err := url.Parse(target)
+	if err != nil {
+		return target
+	}
+
+	if forwardPath {
+		// Limpiar la ruta por separado para que ".." no suba por encima de la raíz
+		rest := path.Clean("/" + visit.Path)
+		if strings.HasSuffix(visit.Path, "/") {
+			rest += "/"
+		}
+		destination = destination.JoinPath(rest)
+	}
+	if forwardQuery {
+		incoming, _ := url.ParseQuery(visit.RawQuery)
+		query := destination.Query()
+		for key, values := range incoming {
+			switch {
+			case u.ForwardQuery == QueryAppend:
+				query[key] = append(query[key], values...)
+			case u.ForwardQuery == QueryOverride || !query.Has(key):
+				query[key] = values
+			}
+		}
+		destination.RawQuery = query.Encode()
+	}
+	return destination.String()
+}
+
+// VariesByHeader indica si el destino depende de las cabeceras de la visita,
+// incluido el User-Agent de las reglas por dispositivo, de modo que la
+// redirección no puede cachearse
+func (u *URL) VariesByHeader() bool {
+	if len(u.DeviceRules) > 0 {
+		return true
+	}
+	if !u.Templated {
+		return false
+	}
+	template, err := ParseTemplate(u.OriginalURL)
+	return err == nil && template.UsesHeaders()
+}
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+)
+
+// MaxTagLength es la longitud máxima del nombre de una etiqueta
+const MaxTagLength = 50
+
+// Tag es una etiqueta con la que un usuario clasifica sus URLs. Cada usuario
+// tiene sus propias etiquetas, con nombres únicos.
+type Tag struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint      `json:"-" gorm:"not null;uniqueIndex:idx_tags_user_name"`
+	Name      string    `json:"name" gorm:"type:varchar(50);not null;uniqueIndex:idx_tags_user_name"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// URLTag relaciona una URL con una de sus etiquetas
+type URLTag struct {
+	URLID uint `gorm:"primaryKey"`
+	TagID uint `gorm:"primaryKey"`
+}
+
+// TableName indica la tabla que relaciona URLs y etiquetas
+func (URLTag) TableName() string {
+	return "url_tags"
+}
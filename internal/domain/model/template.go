@@ -0,0 +1,187 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// Marcadores de una plantilla de destino. {path} es la ruta que sigue al
+// código corto, {query.nombre} un parámetro de la query string de la visita y
+// {header.Nombre} una de sus cabeceras. Todos admiten un valor por defecto
+// tras "|", por ejemplo {query.lang|es}.
+const (
+	placeholderPath   = "path"
+	placeholderQuery  = "query."
+	placeholderHeader = "header."
+)
+
+// secretHeaders son las cabeceras que una plantilla no puede pasar al
+// destino, porque llevan las credenciales del visitante
+var secretHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization"}
+
+// templatePart es la parte de la URL de destino en la que está un marcador,
+// que decide cómo se codifica su valor
+type templatePart int
+
+const (
+	partAuthority templatePart = iota // Esquema y host; no admite marcadores
+	partPath
+	partQuery
+	partFragment
+)
+
+// templateSegment es un trozo de la plantilla: texto literal o un marcador
+type templateSegment struct {
+	literal  string
+	source   string // "path", "query" o "header"; vacío si es literal
+	name     string // Parámetro o cabecera del marcador
+	fallback string // Valor si la visita no lo trae
+	part     templatePart
+}
+
+// Template es una plantilla de destino ya comprobada
+type Template struct {
+	segments []templateSegment
+}
+
+// ParseTemplate comprueba una plantilla de destino. Los marcadores solo
+// pueden ir en la ruta, la query string o el fragmento, nunca en el esquema
+// ni en el host, y el destino con los valores por defecto debe ser una URL
+// http o https válida.
+func ParseTemplate(raw string) (*Template, error) {
+	template := &Template{}
+	var literals strings.Builder
+	for rest := raw; rest != ""; {
+		start := strings.IndexAny(rest, "{}")
+		if start < 0 {
+			template.segments = append(template.segments, templateSegment{literal: rest})
+			literals.WriteString(rest)
+			break
+		}
+		if rest[start] == '}' {
+			return nil, errors.New("unexpected '}'")
+		}
+		if start > 0 {
+			template.segments = append(template.segments, templateSegment{literal: rest[:start]})
+			literals.WriteString(rest[:start])
+		}
+		end := strings.IndexAny(rest[start+1:], "{}")
+		if end < 0 || rest[start+1+end] != '}' {
+			return nil, errors.New("unclosed placeholder")
+		}
+		segment, err := parsePlaceholder(rest[start+1 : start+1+end])
+		if err != nil {
+			return nil, err
+		}
+		segment.part = partOf(literals.String())
+		if segment.part == partAuthority {
+			return nil, fmt.Errorf("placeholder {%s} outside the path, query or fragment", rest[start+1:start+1+end])
+		}
+		template.segments = append(template.segments, segment)
+		rest = rest[start+1+end+1:]
+	}
+
+	destination, err := url.Parse(template.Render(Visit{}))
+	if err != nil {
+		return nil, err
+	}
+	if (destination.Scheme != "http" && destination.Scheme != "https") || destination.Host == "" {
+		return nil, errors.New("destination is not an absolute http url")
+	}
+	return template, nil
+}
+
+// parsePlaceholder interpreta el interior de un marcador, sin las llaves
+func parsePlaceholder(inner string) (templateSegment, error) {
+	name, fallback, _ := strings.Cut(inner, "|")
+	switch {
+	case name == placeholderPath:
+		return templateSegment{source: placeholderPath, fallback: fallback}, nil
+	case strings.HasPrefix(name, placeholderQuery) && len(name) > len(placeholderQuery):
+		return templateSegment{source: "query", name: strings.TrimPrefix(name, placeholderQuery), fallback: fallback}, nil
+	case strings.HasPrefix(name, placeholderHeader) && len(name) > len(placeholderHeader):
+		header := http.CanonicalHeaderKey(strings.TrimPrefix(name, placeholderHeader))
+		for _, secret := range secretHeaders {
+			if header == secret {
+				return templateSegment{}, fmt.Errorf("header %s cannot be forwarded", header)
+			}
+		}
+		return templateSegment{source: "header", name: header, fallback: fallback}, nil
+	default:
+		return templateSegment{}, fmt.Errorf("unknown placeholder {%s}", inner)
+	}
+}
+
+// partOf devuelve la parte de la URL en la que termina el texto prefix
+func partOf(prefix string) templatePart {
+	switch {
+	case strings.Contains(prefix, "#"):
+		return partFragment
+	case strings.Contains(prefix, "?"):
+		return partQuery
+	}
+	_, afterScheme, found := strings.Cut(prefix, "://")
+	if found && strings.Contains(afterScheme, "/") {
+		return partPath
+	}
+	return partAuthority
+}
+
+// UsesHeaders indica si el destino depende de las cabeceras de la visita
+func (t *Template) UsesHeaders() bool {
+	for _, segment := range t.segments {
+		if segment.source == "header" {
+			return true
+		}
+	}
+	return false
+}
+
+// Render devuelve el destino de visit, con el valor de cada marcador
+// codificado para la parte de la URL en la que está
+func (t *Template) Render(visit Visit) string {
+	query, _ := url.ParseQuery(visit.RawQuery)
+	var destination strings.Builder
+	for _, segment := range t.segments {
+		if segment.source == "" {
+			destination.WriteString(segment.literal)
+			continue
+		}
+
+		var value string
+		switch segment.source {
+		case placeholderPath:
+			value = strings.TrimPrefix(path.Clean("/"+visit.Path), "/")
+		case "query":
+			value = query.Get(segment.name)
+		case "header":
+			value = visit.Header.Get(segment.name)
+		}
+		if value == "" {
+			value = segment.fallback
+		}
+		destination.WriteString(segment.escape(value))
+	}
+	return destination.String()
+}
+
+// escape codifica value para la parte de la URL del marcador. La ruta de
+// {path} conserva sus "/"; cualquier otro valor se codifica entero.
+func (s templateSegment) escape(value string) string {
+	switch {
+	case s.part == partQuery:
+		return url.QueryEscape(value)
+	case s.part == partPath && s.source == placeholderPath:
+		segments := strings.Split(value, "/")
+		for i, segment := range segments {
+			segments[i] = url.PathEscape(segment)
+		}
+		return strings.Join(segments, "/")
+	default:
+		return url.PathEscape(value)
+	}
+}
@@ -1,16 +1,82 @@
 package model
 
 import (
-"time"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 // URL representa la entidad principal de nuestro dominio para el acortador de URLs
 type URL struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	OriginalURL string   `json:"original_url" gorm:"type:text;not null"`
-	ShortCode  string   `json:"short_code" gorm:"type:varchar(10);uniqueIndex;not null"`
-	Visits     int      `json:"visits" gorm:"default:0"`
-	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt  time.Time `json:"updated_at" gorm:"autoUpdateTime"`
-	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	ID           uint            `json:"id" gorm:"primaryKey"`
+	UserID       uint            `json:"user_id" gorm:"not null;default:0;index"` // Propietario; cero en las URLs anteriores a la autoría
+	OriginalURL  string          `json:"original_url" gorm:"type:text;not null"`
+	ShortCode    string          `json:"short_code" gorm:"type:varchar(10);uniqueIndex;not null"`
+	Domain       string          `json:"domain" gorm:"type:varchar(255);not null;default:'';index"` // Dominio de destino, para filtrar
+	Visits       int             `json:"visits" gorm:"default:0"`
+	CreatedAt    time.Time       `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time       `json:"updated_at" gorm:"autoUpdateTime"`
+	ExpiresAt    *time.Time      `json:"expires_at,omitempty"`
+	DeletedAt    gorm.DeletedAt  `json:"deleted_at" gorm:"index" swaggertype:"string" format:"date-time"` // Fecha en que pasó a la papelera
+	FolderID     *uint           `json:"folder_id" gorm:"index"`                                          // Carpeta del propietario; nil si no está en ninguna
+	PasswordHash string          `json:"-" gorm:"type:varchar(255);not null;default:''"`                  // Hash bcrypt de la contraseña; vacío si la URL es pública
+	MaxVisits    *int            `json:"max_visits"`                                                      // Visitas permitidas; nil si no hay límite
+	ActiveFrom   *time.Time      `json:"active_from"`                                                     // Momento en que empieza a redirigir; nil si desde su creación
+	ActiveUntil  *time.Time      `json:"active_until"`                                                    // Momento en que deja de redirigir; nil si no termina
+	FallbackURL  string          `json:"fallback_url" gorm:"type:text;not null;default:''"`               // Destino mientras no está activa; vacío muestra una página
+	RedirectCode int             `json:"redirect_code" gorm:"not null;default:0"`                         // Código de la redirección; cero usa el del servidor
+	ForwardQuery QueryForwarding `json:"forward_query" gorm:"type:varchar(10);not null;default:''"`       // Cómo se pasa al destino la query string de la visita
+	ForwardPath  bool            `json:"forward_path" gorm:"not null;default:false"`                      // Añade al destino la ruta que sigue al código
+	Templated    bool            `json:"template" gorm:"not null;default:false"`                          // OriginalURL es una plantilla con marcadores
+	Tags         []Tag           `json:"tags" gorm:"many2many:url_tags"`
+	DeviceRules  []DeviceRule    `json:"device_rules" gorm:"foreignKey:URLID"` // Destinos por plataforma; sin regla se usa OriginalURL
+}
+
+// Protected indica si la URL pide una contraseña antes de redirigir
+func (u *URL) Protected() bool {
+	return u.PasswordHash != ""
+}
+
+// Exhausted indica si la URL ya ha recibido todas las visitas permitidas
+func (u *URL) Exhausted() bool {
+	return u.MaxVisits != nil && u.Visits >= *u.MaxVisits
+}
+
+// Expired indica si la URL ha superado su caducidad en now
+func (u *URL) Expired(now time.Time) bool {
+	return u.ExpiresAt != nil && !now.Before(*u.ExpiresAt)
+}
+
+// StateAt devuelve el estado de la programación de la URL en el instante now
+func (u *URL) StateAt(now time.Time) URLState {
+	switch {
+	case u.ActiveFrom != nil && now.Before(*u.ActiveFrom):
+		return StateScheduled
+	case u.ActiveUntil != nil && !now.Before(*u.ActiveUntil):
+		return StateEnded
+	default:
+		return StateActive
+	}
+}
+
+// HasAccessRules indica si la URL limita quién, cuándo o cuántas veces puede
+// visitarse, o si caduca, de modo que no sirve como URL pública para el mismo
+// destino
+func (u *URL) HasAccessRules() bool {
+	return u.Protected() || u.MaxVisits != nil || u.ActiveFrom != nil || u.ActiveUntil != nil || u.ExpiresAt != nil
+}
+
+// Forwards indica si el destino de la URL depende de la visita: de su ruta,
+// su query string, su dispositivo o, en las plantillas, sus cabeceras
+func (u *URL) Forwards() bool {
+	return u.ForwardQuery != QueryNotForwarded || u.ForwardPath || u.Templated || len(u.DeviceRules) > 0
+}
+
+// TagNames devuelve los nombres de las etiquetas de la URL
+func (u *URL) TagNames() []string {
+	names := make([]string, len(u.Tags))
+	for i, tag := range u.Tags {
+		names[i] = tag.Name
+	}
+	return names
 }
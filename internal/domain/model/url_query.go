@@ -0,0 +1,263 @@
+package model
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Límites del tamaño de página de los listados
+const (
+	DefaultListLimit = 10
+	MaxListLimit     = 100
+)
+
+// URLSort indica el campo por el que se ordena un listado de URLs. Un guion
+// delante del campo ordena de forma descendente.
+type URLSort string
+
+// Ordenaciones soportadas del listado de URLs
+const (
+	SortCreatedAtAsc  URLSort = "created_at"
+	SortCreatedAtDesc URLSort = "-created_at"
+	SortVisitsAsc     URLSort = "visits"
+	SortVisitsDesc    URLSort = "-visits"
+)
+
+// DefaultURLSort muestra primero las URLs más recientes
+const DefaultURLSort = SortCreatedAtDesc
+
+// Field devuelve la columna de la ordenación; cualquier valor desconocido
+// ordena por fecha de creación
+func (s URLSort) Field() string {
+	if strings.TrimPrefix(string(s), "-") == "visits" {
+		return "visits"
+	}
+	return "created_at"
+}
+
+// Desc indica si la ordenación es descendente
+func (s URLSort) Desc() bool {
+	return strings.HasPrefix(string(s), "-")
+}
+
+// ExpiryState filtra las URLs según su caducidad
+type ExpiryState string
+
+// Estados de caducidad soportados por el filtro
+const (
+	ExpiryActive  ExpiryState = "active"  // Sin caducidad o con caducidad futura
+	ExpiryExpired ExpiryState = "expired" // Con caducidad ya pasada
+)
+
+// URLState es el estado de una URL según su periodo de actividad
+type URLState string
+
+// Estados de la programación de una URL
+const (
+	StateScheduled URLState = "scheduled" // Aún no ha empezado a redirigir
+	StateActive    URLState = "active"    // Dentro de su periodo de actividad
+	StateEnded     URLState = "ended"     // Su periodo de actividad ya terminó
+)
+
+// URLFilter agrupa los criterios de filtrado de un listado de URLs. Los
+// campos vacíos no filtran.
+type URLFilter struct {
+	// UserID limita el listado a las URLs de un propietario
+	UserID uint
+
+	// Trashed lista las URLs de la papelera en lugar de las activas
+	Trashed bool
+
+	// CreatedFrom y CreatedTo limitan la fecha de creación a [CreatedFrom, CreatedTo)
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+
+	// Expiry filtra por el estado de caducidad en el instante Now
+	Expiry ExpiryState
+	Now    time.Time
+
+	// State filtra por el estado de la programación en el instante Now
+	State URLState
+
+	// Domain filtra por el dominio de destino, incluidos sus subdominios
+	Domain string
+
+	// Search busca el texto, sin distinguir mayúsculas, en la URL original y el código
+	Search string
+
+	// Tag limita el listado a las URLs con la etiqueta de ese nombre
+	Tag string
+
+	// FolderID limita el listado a las URLs de una carpeta; cero lista las
+	// que no están en ninguna
+	FolderID *uint
+}
+
+// URLQuery describe una página de un listado de URLs. Con Cursor la página
+// empieza en la posición del cursor y Offset se ignora.
+type URLQuery struct {
+	Filter URLFilter
+	Sort   URLSort
+	Cursor *URLCursor
+	Limit  int
+	Offset int
+}
+
+// URLCursor marca la posición de una URL en un listado ordenado, para pedir
+// la página siguiente o la anterior sin depender de desplazamientos
+type URLCursor struct {
+	Sort      URLSort
+	CreatedAt time.Time
+	Visits    int
+	ID        uint
+
+	// Before pide la página anterior a la posición en lugar de la siguiente
+	Before bool
+}
+
+// CursorAt devuelve el cursor que señala la posición de u en un listado
+// ordenado por sort
+func CursorAt(u *URL, sort URLSort, before bool) *URLCursor {
+	return &URLCursor{
+		Sort:      sort,
+		CreatedAt: u.CreatedAt,
+		Visits:    u.Visits,
+		ID:        u.ID,
+		Before:    before,
+	}
+}
+
+// Value devuelve el valor del campo de ordenación en la posición del cursor
+func (c URLCursor) Value() interface{} {
+	if c.Sort.Field() == "visits" {
+		return c.Visits
+	}
+	return c.CreatedAt
+}
+
+// Compare indica si u va antes (-1) o después (1) de la posición del cursor
+// en el orden del listado, o 0 si es la misma URL. El ID desempata.
+func (c URLCursor) Compare(u *URL) int {
+	cmp := 0
+	switch c.Sort.Field() {
+	case "visits":
+		cmp = compareInts(u.Visits, c.Visits)
+	default:
+		cmp = u.CreatedAt.Compare(c.CreatedAt)
+	}
+	if cmp == 0 {
+		cmp = compareInts(int(u.ID), int(c.ID))
+	}
+	if c.Sort.Desc() {
+		return -cmp
+	}
+	return cmp
+}
+
+// compareInts compara dos enteros devolviendo -1, 0 o 1
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// URLPage es una página de un listado de URLs
+type URLPage struct {
+	URLs  []*URL
+	Total int64
+
+	// Next y Prev señalan las páginas siguiente y anterior; nil si no las hay
+	Next *URLCursor
+	Prev *URLCursor
+}
+
+// Normalize aplica los valores por defecto y limita el tamaño de página
+func (q URLQuery) Normalize() URLQuery {
+	if q.Limit <= 0 {
+		q.Limit = DefaultListLimit
+	}
+	if q.Limit > MaxListLimit {
+		q.Limit = MaxListLimit
+	}
+	if q.Offset < 0 || q.Cursor != nil {
+		q.Offset = 0
+	}
+	if q.Sort == "" {
+		q.Sort = DefaultURLSort
+	}
+	if q.Cursor != nil {
+		q.Sort = q.Cursor.Sort
+	}
+	if (q.Filter.Expiry != "" || q.Filter.State != "") && q.Filter.Now.IsZero() {
+		q.Filter.Now = time.Now()
+	}
+	q.Filter.Domain = strings.ToLower(q.Filter.Domain)
+	return q
+}
+
+// Matches indica si la URL cumple el filtro
+func (f URLFilter) Matches(u *URL) bool {
+	if f.UserID != 0 && u.UserID != f.UserID {
+		return false
+	}
+	if u.DeletedAt.Valid != f.Trashed {
+		return false
+	}
+	if f.CreatedFrom != nil && u.CreatedAt.Before(*f.CreatedFrom) {
+		return false
+	}
+	if f.CreatedTo != nil && !u.CreatedAt.Before(*f.CreatedTo) {
+		return false
+	}
+	switch f.Expiry {
+	case ExpiryActive:
+		if u.ExpiresAt != nil && !u.ExpiresAt.After(f.Now) {
+			return false
+		}
+	case ExpiryExpired:
+		if u.ExpiresAt == nil || u.ExpiresAt.After(f.Now) {
+			return false
+		}
+	}
+	if f.State != "" && u.StateAt(f.Now) != f.State {
+		return false
+	}
+	if f.Domain != "" && u.Domain != f.Domain && !strings.HasSuffix(u.Domain, "."+f.Domain) {
+		return false
+	}
+	if f.Tag != "" && !slices.ContainsFunc(u.Tags, func(t Tag) bool { return t.Name == f.Tag }) {
+		return false
+	}
+	if f.FolderID != nil {
+		if *f.FolderID == 0 && u.FolderID != nil {
+			return false
+		}
+		if *f.FolderID != 0 && (u.FolderID == nil || *u.FolderID != *f.FolderID) {
+			return false
+		}
+	}
+	if f.Search != "" {
+		search := strings.ToLower(f.Search)
+		if !strings.Contains(strings.ToLower(u.OriginalURL), search) &&
+			!strings.Contains(strings.ToLower(u.ShortCode), search) {
+			return false
+		}
+	}
+	return true
+}
+
+// DomainOf devuelve el dominio en minúsculas de una URL, o vacío si no lo tiene
+func DomainOf(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(parsed.Hostname())
+}
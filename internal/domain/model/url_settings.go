@@ -0,0 +1,73 @@
+package model
+
+import "time"
+
+// URLSettings son los ajustes opcionales con los que se crea una URL
+type URLSettings struct {
+	// Tags son los nombres de las etiquetas de la URL; las que no existen se crean
+	Tags []string
+
+	// FolderID es la carpeta del propietario en la que se guarda la URL
+	FolderID *uint
+
+	// Password protege la URL: los visitantes deben introducirla antes de
+	// ser redirigidos. Solo se guarda su hash.
+	Password string
+
+	// MaxVisits es el número de visitas tras el que la URL deja de redirigir
+	MaxVisits *int
+
+	// ActiveFrom y ActiveUntil limitan el periodo en que la URL redirige a
+	// su destino; fuera de él redirige a FallbackURL, si la hay
+	ActiveFrom  *time.Time
+	ActiveUntil *time.Time
+	FallbackURL string
+
+	// RedirectCode es el código con el que redirige la URL; cero usa el del servidor
+	RedirectCode int
+
+	// ForwardQuery y ForwardPath pasan al destino la query string y la ruta
+	// que siguen al código corto en cada visita
+	ForwardQuery QueryForwarding
+	ForwardPath  bool
+
+	// Template indica que la URL original es una plantilla de destino con
+	// marcadores, como https://docs.example.com/{path}?lang={query.lang|es}
+	Template bool
+
+	// DeviceRules envían a otro destino las visitas desde ciertas plataformas
+	DeviceRules []DeviceRule
+}
+
+// IsZero indica si no se ha pedido ningún ajuste
+func (s URLSettings) IsZero() bool {
+	return len(s.Tags) == 0 && s.FolderID == nil && s.Password == "" && s.MaxVisits == nil &&
+		s.ActiveFrom == nil && s.ActiveUntil == nil && s.FallbackURL == "" && s.RedirectCode == 0 &&
+		s.ForwardQuery == "" && !s.ForwardPath && !s.Template &&
+		len(s.DeviceRules) == 0
+}
+
+// URLChanges describe la modificación de una URL existente. Los campos nil
+// se dejan como están.
+type URLChanges struct {
+	// Tags sustituye todas las etiquetas de la URL; vacío las quita todas
+	Tags *[]string
+
+	// FolderID mueve la URL a otra carpeta del propietario; cero la saca de
+	// la carpeta en la que esté
+	FolderID *uint
+
+	// MaxVisits cambia el número de visitas permitidas; cero quita el límite
+	MaxVisits *int
+
+	// RedirectCode cambia el código de la redirección; cero vuelve al del servidor
+	RedirectCode *int
+
+	// ForwardQuery y ForwardPath cambian qué partes de la visita se pasan al
+	// destino; QueryNotForwarded deja de pasar la query string
+	ForwardQuery *QueryForwarding
+	ForwardPath  *bool
+
+	// DeviceRules sustituye todas las reglas por dispositivo; vacío las quita
+	DeviceRules *[]DeviceRule
+}
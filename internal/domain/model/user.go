@@ -12,13 +12,19 @@ type User struct {
 	ID        uint      `json:"id" gorm:"primaryKey"`
 	Username  string    `json:"username" gorm:"type:varchar(100);unique;not null"`
 	Email     string    `json:"email" gorm:"type:varchar(255);unique;not null"`
-	Password  string    `json:"-" gorm:"type:varchar(255);not null"` // No exponer la contraseña en JSON
+	Password  string    `json:"-" gorm:"type:varchar(255);not null"`                 // No exponer la contraseña en JSON
+	Language  string    `json:"language" gorm:"type:varchar(5);not null;default:''"` // Idioma preferido; vacío sigue a Accept-Language
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
-// BeforeSave se ejecuta antes de guardar el usuario para cifrar la contraseña
+// BeforeSave se ejecuta antes de guardar el usuario para cifrar la contraseña.
+// Las contraseñas que ya son un hash de bcrypt se guardan tal cual, de modo que
+// actualizar otros campos no vuelve a cifrarlas.
 func (u *User) BeforeSave(tx *gorm.DB) error {
+	if _, err := bcrypt.Cost([]byte(u.Password)); err == nil {
+		return nil
+	}
 	if len(u.Password) > 0 {
 		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(u.Password), bcrypt.DefaultCost)
 		if err != nil {
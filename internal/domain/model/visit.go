@@ -0,0 +1,30 @@
+package model
+
+import (
+	"net/http"
+	"time"
+)
+
+// Visit describe la visita de un navegador a una URL corta
+type Visit struct {
+	// AccessToken es el pase emitido por UnlockURL en una visita anterior;
+	// vacío si el visitante aún no ha introducido la contraseña
+	AccessToken string
+
+	// Path es la parte de la ruta que sigue al código corto, por ejemplo
+	// "/docs/intro"; vacía si la visita es a la URL corta sin más
+	Path string
+
+	// RawQuery es la query string de la visita, sin el "?"
+	RawQuery string
+
+	// Header son las cabeceras de la petición, para las plantillas de destino
+	Header http.Header
+}
+
+// URLAccess es el pase que permite visitar una URL protegida sin volver a
+// introducir la contraseña hasta que caduca
+type URLAccess struct {
+	Token     string
+	ExpiresAt time.Time
+}
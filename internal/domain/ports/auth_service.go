@@ -11,11 +11,14 @@ type AuthService interface {
 	Register(ctx context.Context, username, email, password string) (*model.User, string, error)
 
 	// Login autentica a un usuario
-	Login(username, password string) (string, error)
+	Login(ctx context.Context, username, password string) (string, error)
 
 	// ValidateToken valida un token JWT y devuelve el ID del usuario
 	ValidateToken(token string) (uint, error)
 	GenerateToken(id uint) (string, error)
 	// GetUser obtiene un usuario por su ID
 	GetUser(ctx context.Context, id uint) (*model.User, error)
+
+	// SetLanguage guarda el idioma preferido del usuario; vacío sigue a Accept-Language
+	SetLanguage(ctx context.Context, id uint, language string) (*model.User, error)
 }
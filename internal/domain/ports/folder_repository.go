@@ -0,0 +1,27 @@
+package ports
+
+import (
+	"context"
+
+	"tiny-url/internal/domain/model"
+)
+
+// FolderRepository define las operaciones de persistencia de las carpetas de
+// cada usuario. Las carpetas de otros usuarios se tratan como inexistentes.
+type FolderRepository interface {
+	// List devuelve las carpetas del usuario ordenadas por nombre
+	List(ctx context.Context, userID uint) ([]model.Folder, error)
+
+	// GetByID recupera una carpeta del usuario por su ID
+	GetByID(ctx context.Context, userID, id uint) (*model.Folder, error)
+
+	// Create guarda una nueva carpeta. El nombre debe ser único para el usuario.
+	Create(ctx context.Context, folder *model.Folder) error
+
+	// Rename cambia el nombre de una carpeta
+	Rename(ctx context.Context, folder *model.Folder) error
+
+	// Delete elimina una carpeta del usuario; sus URLs se conservan fuera de
+	// cualquier carpeta
+	Delete(ctx context.Context, userID, id uint) error
+}
@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+
+	"tiny-url/internal/domain/model"
+)
+
+// FolderService define la gestión de las carpetas de un usuario
+type FolderService interface {
+	// ListFolders devuelve las carpetas del usuario ordenadas por nombre
+	ListFolders(ctx context.Context, userID uint) ([]model.Folder, error)
+
+	// CreateFolder crea una carpeta del usuario
+	CreateFolder(ctx context.Context, userID uint, name string) (*model.Folder, error)
+
+	// RenameFolder cambia el nombre de una carpeta del usuario
+	RenameFolder(ctx context.Context, userID, id uint, name string) (*model.Folder, error)
+
+	// DeleteFolder elimina una carpeta del usuario; sus URLs quedan fuera de
+	// cualquier carpeta
+	DeleteFolder(ctx context.Context, userID, id uint) error
+}
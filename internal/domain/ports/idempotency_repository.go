@@ -0,0 +1,27 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"tiny-url/internal/domain/model"
+)
+
+// IdempotencyRepository guarda las respuestas de las peticiones con Idempotency-Key
+type IdempotencyRepository interface {
+	// Create reserva la clave de un usuario. Devuelve errors.ErrDuplicateKey si
+	// el usuario ya tiene un registro con esa clave.
+	Create(ctx context.Context, record *model.IdempotencyRecord) error
+
+	// Get recupera el registro de un usuario por su clave, o errors.ErrRecordNotFound
+	Get(ctx context.Context, userID uint, key string) (*model.IdempotencyRecord, error)
+
+	// Update guarda la respuesta de un registro ya reservado y su nueva caducidad
+	Update(ctx context.Context, record *model.IdempotencyRecord) error
+
+	// Delete libera la clave de un usuario; no falla si no existe
+	Delete(ctx context.Context, userID uint, key string) error
+
+	// DeleteExpired elimina los registros caducados en now y devuelve cuántos había
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
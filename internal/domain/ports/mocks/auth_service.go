@@ -38,6 +38,60 @@ func (_m *MockAuthService) EXPECT() *MockAuthService_Expecter {
 	return &MockAuthService_Expecter{mock: &_m.Mock}
 }
 
+// GenerateToken provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) GenerateToken(id uint) (string, error) {
+	ret := _mock.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateToken")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uint) (string, error)); ok {
+		return returnFunc(id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uint) string); ok {
+		r0 = returnFunc(id)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = returnFunc(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthService_GenerateToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateToken'
+type MockAuthService_GenerateToken_Call struct {
+	*mock.Call
+}
+
+// GenerateToken is a helper method to define mock.On call
+//   - id
+func (_e *MockAuthService_Expecter) GenerateToken(id interface{}) *MockAuthService_GenerateToken_Call {
+	return &MockAuthService_GenerateToken_Call{Call: _e.mock.On("GenerateToken", id)}
+}
+
+func (_c *MockAuthService_GenerateToken_Call) Run(run func(id uint)) *MockAuthService_GenerateToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockAuthService_GenerateToken_Call) Return(s string, err error) *MockAuthService_GenerateToken_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockAuthService_GenerateToken_Call) RunAndReturn(run func(id uint) (string, error)) *MockAuthService_GenerateToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetUser provides a mock function for the type MockAuthService
 func (_mock *MockAuthService) GetUser(ctx context.Context, id uint) (*model.User, error) {
 	ret := _mock.Called(ctx, id)
@@ -96,8 +150,8 @@ func (_c *MockAuthService_GetUser_Call) RunAndReturn(run func(ctx context.Contex
 }
 
 // Login provides a mock function for the type MockAuthService
-func (_mock *MockAuthService) Login(username string, password string) (string, error) {
-	ret := _mock.Called(username, password)
+func (_mock *MockAuthService) Login(ctx context.Context, username string, password string) (string, error) {
+	ret := _mock.Called(ctx, username, password)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Login")
@@ -105,16 +159,16 @@ func (_mock *MockAuthService) Login(username string, password string) (string, e
 
 	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string, string) (string, error)); ok {
-		return returnFunc(username, password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return returnFunc(ctx, username, password)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string, string) string); ok {
-		r0 = returnFunc(username, password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = returnFunc(ctx, username, password)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = returnFunc(username, password)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, username, password)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -127,15 +181,16 @@ type MockAuthService_Login_Call struct {
 }
 
 // Login is a helper method to define mock.On call
+//   - ctx
 //   - username
 //   - password
-func (_e *MockAuthService_Expecter) Login(username interface{}, password interface{}) *MockAuthService_Login_Call {
-	return &MockAuthService_Login_Call{Call: _e.mock.On("Login", username, password)}
+func (_e *MockAuthService_Expecter) Login(ctx interface{}, username interface{}, password interface{}) *MockAuthService_Login_Call {
+	return &MockAuthService_Login_Call{Call: _e.mock.On("Login", ctx, username, password)}
 }
 
-func (_c *MockAuthService_Login_Call) Run(run func(username string, password string)) *MockAuthService_Login_Call {
+func (_c *MockAuthService_Login_Call) Run(run func(ctx context.Context, username string, password string)) *MockAuthService_Login_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -145,7 +200,7 @@ func (_c *MockAuthService_Login_Call) Return(s string, err error) *MockAuthServi
 	return _c
 }
 
-func (_c *MockAuthService_Login_Call) RunAndReturn(run func(username string, password string) (string, error)) *MockAuthService_Login_Call {
+func (_c *MockAuthService_Login_Call) RunAndReturn(run func(ctx context.Context, username string, password string) (string, error)) *MockAuthService_Login_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -215,6 +270,64 @@ func (_c *MockAuthService_Register_Call) RunAndReturn(run func(ctx context.Conte
 	return _c
 }
 
+// SetLanguage provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) SetLanguage(ctx context.Context, id uint, language string) (*model.User, error) {
+	ret := _mock.Called(ctx, id, language)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLanguage")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, string) (*model.User, error)); ok {
+		return returnFunc(ctx, id, language)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, string) *model.User); ok {
+		r0 = returnFunc(ctx, id, language)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint, string) error); ok {
+		r1 = returnFunc(ctx, id, language)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthService_SetLanguage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLanguage'
+type MockAuthService_SetLanguage_Call struct {
+	*mock.Call
+}
+
+// SetLanguage is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - language
+func (_e *MockAuthService_Expecter) SetLanguage(ctx interface{}, id interface{}, language interface{}) *MockAuthService_SetLanguage_Call {
+	return &MockAuthService_SetLanguage_Call{Call: _e.mock.On("SetLanguage", ctx, id, language)}
+}
+
+func (_c *MockAuthService_SetLanguage_Call) Run(run func(ctx context.Context, id uint, language string)) *MockAuthService_SetLanguage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockAuthService_SetLanguage_Call) Return(user *model.User, err error) *MockAuthService_SetLanguage_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockAuthService_SetLanguage_Call) RunAndReturn(run func(ctx context.Context, id uint, language string) (*model.User, error)) *MockAuthService_SetLanguage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ValidateToken provides a mock function for the type MockAuthService
 func (_mock *MockAuthService) ValidateToken(token string) (uint, error) {
 	ret := _mock.Called(token)
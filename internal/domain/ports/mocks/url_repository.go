@@ -6,6 +6,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 	"tiny-url/internal/domain/model"
 
 	mock "github.com/stretchr/testify/mock"
@@ -38,6 +39,53 @@ func (_m *MockURLRepository) EXPECT() *MockURLRepository_Expecter {
 	return &MockURLRepository_Expecter{mock: &_m.Mock}
 }
 
+// AddTags provides a mock function for the type MockURLRepository
+func (_mock *MockURLRepository) AddTags(ctx context.Context, url *model.URL, names []string) error {
+	ret := _mock.Called(ctx, url, names)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTags")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.URL, []string) error); ok {
+		r0 = returnFunc(ctx, url, names)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockURLRepository_AddTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddTags'
+type MockURLRepository_AddTags_Call struct {
+	*mock.Call
+}
+
+// AddTags is a helper method to define mock.On call
+//   - ctx
+//   - url
+//   - names
+func (_e *MockURLRepository_Expecter) AddTags(ctx interface{}, url interface{}, names interface{}) *MockURLRepository_AddTags_Call {
+	return &MockURLRepository_AddTags_Call{Call: _e.mock.On("AddTags", ctx, url, names)}
+}
+
+func (_c *MockURLRepository_AddTags_Call) Run(run func(ctx context.Context, url *model.URL, names []string)) *MockURLRepository_AddTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.URL), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_AddTags_Call) Return(err error) *MockURLRepository_AddTags_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockURLRepository_AddTags_Call) RunAndReturn(run func(ctx context.Context, url *model.URL, names []string) error) *MockURLRepository_AddTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function for the type MockURLRepository
 func (_mock *MockURLRepository) Create(ctx context.Context, url *model.URL) error {
 	ret := _mock.Called(ctx, url)
@@ -131,8 +179,8 @@ func (_c *MockURLRepository_Delete_Call) RunAndReturn(run func(ctx context.Conte
 }
 
 // GetByOriginalURL provides a mock function for the type MockURLRepository
-func (_mock *MockURLRepository) GetByOriginalURL(ctx context.Context, originalURL string) (*model.URL, error) {
-	ret := _mock.Called(ctx, originalURL)
+func (_mock *MockURLRepository) GetByOriginalURL(ctx context.Context, userID uint, originalURL string) (*model.URL, error) {
+	ret := _mock.Called(ctx, userID, originalURL)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetByOriginalURL")
@@ -140,18 +188,18 @@ func (_mock *MockURLRepository) GetByOriginalURL(ctx context.Context, originalUR
 
 	var r0 *model.URL
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.URL, error)); ok {
-		return returnFunc(ctx, originalURL)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, string) (*model.URL, error)); ok {
+		return returnFunc(ctx, userID, originalURL)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.URL); ok {
-		r0 = returnFunc(ctx, originalURL)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, string) *model.URL); ok {
+		r0 = returnFunc(ctx, userID, originalURL)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.URL)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, originalURL)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint, string) error); ok {
+		r1 = returnFunc(ctx, userID, originalURL)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -165,14 +213,15 @@ type MockURLRepository_GetByOriginalURL_Call struct {
 
 // GetByOriginalURL is a helper method to define mock.On call
 //   - ctx
+//   - userID
 //   - originalURL
-func (_e *MockURLRepository_Expecter) GetByOriginalURL(ctx interface{}, originalURL interface{}) *MockURLRepository_GetByOriginalURL_Call {
-	return &MockURLRepository_GetByOriginalURL_Call{Call: _e.mock.On("GetByOriginalURL", ctx, originalURL)}
+func (_e *MockURLRepository_Expecter) GetByOriginalURL(ctx interface{}, userID interface{}, originalURL interface{}) *MockURLRepository_GetByOriginalURL_Call {
+	return &MockURLRepository_GetByOriginalURL_Call{Call: _e.mock.On("GetByOriginalURL", ctx, userID, originalURL)}
 }
 
-func (_c *MockURLRepository_GetByOriginalURL_Call) Run(run func(ctx context.Context, originalURL string)) *MockURLRepository_GetByOriginalURL_Call {
+func (_c *MockURLRepository_GetByOriginalURL_Call) Run(run func(ctx context.Context, userID uint, originalURL string)) *MockURLRepository_GetByOriginalURL_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(uint), args[2].(string))
 	})
 	return _c
 }
@@ -182,7 +231,7 @@ func (_c *MockURLRepository_GetByOriginalURL_Call) Return(uRL *model.URL, err er
 	return _c
 }
 
-func (_c *MockURLRepository_GetByOriginalURL_Call) RunAndReturn(run func(ctx context.Context, originalURL string) (*model.URL, error)) *MockURLRepository_GetByOriginalURL_Call {
+func (_c *MockURLRepository_GetByOriginalURL_Call) RunAndReturn(run func(ctx context.Context, userID uint, originalURL string) (*model.URL, error)) *MockURLRepository_GetByOriginalURL_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -244,21 +293,87 @@ func (_c *MockURLRepository_GetByShortCode_Call) RunAndReturn(run func(ctx conte
 	return _c
 }
 
+// GetDeletedByShortCode provides a mock function for the type MockURLRepository
+func (_mock *MockURLRepository) GetDeletedByShortCode(ctx context.Context, shortCode string) (*model.URL, error) {
+	ret := _mock.Called(ctx, shortCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedByShortCode")
+	}
+
+	var r0 *model.URL
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.URL, error)); ok {
+		return returnFunc(ctx, shortCode)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.URL); ok {
+		r0 = returnFunc(ctx, shortCode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.URL)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, shortCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockURLRepository_GetDeletedByShortCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletedByShortCode'
+type MockURLRepository_GetDeletedByShortCode_Call struct {
+	*mock.Call
+}
+
+// GetDeletedByShortCode is a helper method to define mock.On call
+//   - ctx
+//   - shortCode
+func (_e *MockURLRepository_Expecter) GetDeletedByShortCode(ctx interface{}, shortCode interface{}) *MockURLRepository_GetDeletedByShortCode_Call {
+	return &MockURLRepository_GetDeletedByShortCode_Call{Call: _e.mock.On("GetDeletedByShortCode", ctx, shortCode)}
+}
+
+func (_c *MockURLRepository_GetDeletedByShortCode_Call) Run(run func(ctx context.Context, shortCode string)) *MockURLRepository_GetDeletedByShortCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_GetDeletedByShortCode_Call) Return(uRL *model.URL, err error) *MockURLRepository_GetDeletedByShortCode_Call {
+	_c.Call.Return(uRL, err)
+	return _c
+}
+
+func (_c *MockURLRepository_GetDeletedByShortCode_Call) RunAndReturn(run func(ctx context.Context, shortCode string) (*model.URL, error)) *MockURLRepository_GetDeletedByShortCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IncrementVisits provides a mock function for the type MockURLRepository
-func (_mock *MockURLRepository) IncrementVisits(ctx context.Context, shortCode string) error {
+func (_mock *MockURLRepository) IncrementVisits(ctx context.Context, shortCode string) (bool, error) {
 	ret := _mock.Called(ctx, shortCode)
 
 	if len(ret) == 0 {
 		panic("no return value specified for IncrementVisits")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return returnFunc(ctx, shortCode)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
 		r0 = returnFunc(ctx, shortCode)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, shortCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
 // MockURLRepository_IncrementVisits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementVisits'
@@ -280,42 +395,48 @@ func (_c *MockURLRepository_IncrementVisits_Call) Run(run func(ctx context.Conte
 	return _c
 }
 
-func (_c *MockURLRepository_IncrementVisits_Call) Return(err error) *MockURLRepository_IncrementVisits_Call {
-	_c.Call.Return(err)
+func (_c *MockURLRepository_IncrementVisits_Call) Return(b bool, err error) *MockURLRepository_IncrementVisits_Call {
+	_c.Call.Return(b, err)
 	return _c
 }
 
-func (_c *MockURLRepository_IncrementVisits_Call) RunAndReturn(run func(ctx context.Context, shortCode string) error) *MockURLRepository_IncrementVisits_Call {
+func (_c *MockURLRepository_IncrementVisits_Call) RunAndReturn(run func(ctx context.Context, shortCode string) (bool, error)) *MockURLRepository_IncrementVisits_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // List provides a mock function for the type MockURLRepository
-func (_mock *MockURLRepository) List(ctx context.Context, limit int, offset int) ([]*model.URL, error) {
-	ret := _mock.Called(ctx, limit, offset)
+func (_mock *MockURLRepository) List(ctx context.Context, query model.URLQuery) ([]*model.URL, int64, error) {
+	ret := _mock.Called(ctx, query)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
 	}
 
 	var r0 []*model.URL
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) ([]*model.URL, error)); ok {
-		return returnFunc(ctx, limit, offset)
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.URLQuery) ([]*model.URL, int64, error)); ok {
+		return returnFunc(ctx, query)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) []*model.URL); ok {
-		r0 = returnFunc(ctx, limit, offset)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.URLQuery) []*model.URL); ok {
+		r0 = returnFunc(ctx, query)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.URL)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
-		r1 = returnFunc(ctx, limit, offset)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.URLQuery) int64); ok {
+		r1 = returnFunc(ctx, query)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int64)
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, model.URLQuery) error); ok {
+		r2 = returnFunc(ctx, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
 // MockURLRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
@@ -325,25 +446,265 @@ type MockURLRepository_List_Call struct {
 
 // List is a helper method to define mock.On call
 //   - ctx
-//   - limit
-//   - offset
-func (_e *MockURLRepository_Expecter) List(ctx interface{}, limit interface{}, offset interface{}) *MockURLRepository_List_Call {
-	return &MockURLRepository_List_Call{Call: _e.mock.On("List", ctx, limit, offset)}
+//   - query
+func (_e *MockURLRepository_Expecter) List(ctx interface{}, query interface{}) *MockURLRepository_List_Call {
+	return &MockURLRepository_List_Call{Call: _e.mock.On("List", ctx, query)}
+}
+
+func (_c *MockURLRepository_List_Call) Run(run func(ctx context.Context, query model.URLQuery)) *MockURLRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.URLQuery))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_List_Call) Return(uRLs []*model.URL, n int64, err error) *MockURLRepository_List_Call {
+	_c.Call.Return(uRLs, n, err)
+	return _c
+}
+
+func (_c *MockURLRepository_List_Call) RunAndReturn(run func(ctx context.Context, query model.URLQuery) ([]*model.URL, int64, error)) *MockURLRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeDeleted provides a mock function for the type MockURLRepository
+func (_mock *MockURLRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	ret := _mock.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeleted")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, before)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = returnFunc(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockURLRepository_PurgeDeleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeDeleted'
+type MockURLRepository_PurgeDeleted_Call struct {
+	*mock.Call
+}
+
+// PurgeDeleted is a helper method to define mock.On call
+//   - ctx
+//   - before
+func (_e *MockURLRepository_Expecter) PurgeDeleted(ctx interface{}, before interface{}) *MockURLRepository_PurgeDeleted_Call {
+	return &MockURLRepository_PurgeDeleted_Call{Call: _e.mock.On("PurgeDeleted", ctx, before)}
 }
 
-func (_c *MockURLRepository_List_Call) Run(run func(ctx context.Context, limit int, offset int)) *MockURLRepository_List_Call {
+func (_c *MockURLRepository_PurgeDeleted_Call) Run(run func(ctx context.Context, before time.Time)) *MockURLRepository_PurgeDeleted_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int), args[2].(int))
+		run(args[0].(context.Context), args[1].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockURLRepository_List_Call) Return(uRLs []*model.URL, err error) *MockURLRepository_List_Call {
-	_c.Call.Return(uRLs, err)
+func (_c *MockURLRepository_PurgeDeleted_Call) Return(n int64, err error) *MockURLRepository_PurgeDeleted_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockURLRepository_PurgeDeleted_Call) RunAndReturn(run func(ctx context.Context, before time.Time) (int64, error)) *MockURLRepository_PurgeDeleted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveTags provides a mock function for the type MockURLRepository
+func (_mock *MockURLRepository) RemoveTags(ctx context.Context, url *model.URL, names []string) error {
+	ret := _mock.Called(ctx, url, names)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveTags")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.URL, []string) error); ok {
+		r0 = returnFunc(ctx, url, names)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockURLRepository_RemoveTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveTags'
+type MockURLRepository_RemoveTags_Call struct {
+	*mock.Call
+}
+
+// RemoveTags is a helper method to define mock.On call
+//   - ctx
+//   - url
+//   - names
+func (_e *MockURLRepository_Expecter) RemoveTags(ctx interface{}, url interface{}, names interface{}) *MockURLRepository_RemoveTags_Call {
+	return &MockURLRepository_RemoveTags_Call{Call: _e.mock.On("RemoveTags", ctx, url, names)}
+}
+
+func (_c *MockURLRepository_RemoveTags_Call) Run(run func(ctx context.Context, url *model.URL, names []string)) *MockURLRepository_RemoveTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.URL), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_RemoveTags_Call) Return(err error) *MockURLRepository_RemoveTags_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockURLRepository_RemoveTags_Call) RunAndReturn(run func(ctx context.Context, url *model.URL, names []string) error) *MockURLRepository_RemoveTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function for the type MockURLRepository
+func (_mock *MockURLRepository) Restore(ctx context.Context, shortCode string) error {
+	ret := _mock.Called(ctx, shortCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, shortCode)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockURLRepository_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type MockURLRepository_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx
+//   - shortCode
+func (_e *MockURLRepository_Expecter) Restore(ctx interface{}, shortCode interface{}) *MockURLRepository_Restore_Call {
+	return &MockURLRepository_Restore_Call{Call: _e.mock.On("Restore", ctx, shortCode)}
+}
+
+func (_c *MockURLRepository_Restore_Call) Run(run func(ctx context.Context, shortCode string)) *MockURLRepository_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_Restore_Call) Return(err error) *MockURLRepository_Restore_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockURLRepository_Restore_Call) RunAndReturn(run func(ctx context.Context, shortCode string) error) *MockURLRepository_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetDeviceRules provides a mock function for the type MockURLRepository
+func (_mock *MockURLRepository) SetDeviceRules(ctx context.Context, url *model.URL, rules []model.DeviceRule) error {
+	ret := _mock.Called(ctx, url, rules)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDeviceRules")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.URL, []model.DeviceRule) error); ok {
+		r0 = returnFunc(ctx, url, rules)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockURLRepository_SetDeviceRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDeviceRules'
+type MockURLRepository_SetDeviceRules_Call struct {
+	*mock.Call
+}
+
+// SetDeviceRules is a helper method to define mock.On call
+//   - ctx
+//   - url
+//   - rules
+func (_e *MockURLRepository_Expecter) SetDeviceRules(ctx interface{}, url interface{}, rules interface{}) *MockURLRepository_SetDeviceRules_Call {
+	return &MockURLRepository_SetDeviceRules_Call{Call: _e.mock.On("SetDeviceRules", ctx, url, rules)}
+}
+
+func (_c *MockURLRepository_SetDeviceRules_Call) Run(run func(ctx context.Context, url *model.URL, rules []model.DeviceRule)) *MockURLRepository_SetDeviceRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.URL), args[2].([]model.DeviceRule))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_SetDeviceRules_Call) Return(err error) *MockURLRepository_SetDeviceRules_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockURLRepository_SetDeviceRules_Call) RunAndReturn(run func(ctx context.Context, url *model.URL, rules []model.DeviceRule) error) *MockURLRepository_SetDeviceRules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockURLRepository
+func (_mock *MockURLRepository) Update(ctx context.Context, url *model.URL) error {
+	ret := _mock.Called(ctx, url)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.URL) error); ok {
+		r0 = returnFunc(ctx, url)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockURLRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockURLRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx
+//   - url
+func (_e *MockURLRepository_Expecter) Update(ctx interface{}, url interface{}) *MockURLRepository_Update_Call {
+	return &MockURLRepository_Update_Call{Call: _e.mock.On("Update", ctx, url)}
+}
+
+func (_c *MockURLRepository_Update_Call) Run(run func(ctx context.Context, url *model.URL)) *MockURLRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.URL))
+	})
+	return _c
+}
+
+func (_c *MockURLRepository_Update_Call) Return(err error) *MockURLRepository_Update_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockURLRepository_List_Call) RunAndReturn(run func(ctx context.Context, limit int, offset int) ([]*model.URL, error)) *MockURLRepository_List_Call {
+func (_c *MockURLRepository_Update_Call) RunAndReturn(run func(ctx context.Context, url *model.URL) error) *MockURLRepository_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
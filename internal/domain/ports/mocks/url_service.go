@@ -6,6 +6,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 	"tiny-url/internal/domain/model"
 
 	mock "github.com/stretchr/testify/mock"
@@ -38,17 +39,133 @@ func (_m *MockURLService) EXPECT() *MockURLService_Expecter {
 	return &MockURLService_Expecter{mock: &_m.Mock}
 }
 
+// BatchURLs provides a mock function for the type MockURLService
+func (_mock *MockURLService) BatchURLs(ctx context.Context, batch model.URLBatch) ([]model.BatchResult, error) {
+	ret := _mock.Called(ctx, batch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BatchURLs")
+	}
+
+	var r0 []model.BatchResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.URLBatch) ([]model.BatchResult, error)); ok {
+		return returnFunc(ctx, batch)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.URLBatch) []model.BatchResult); ok {
+		r0 = returnFunc(ctx, batch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.BatchResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.URLBatch) error); ok {
+		r1 = returnFunc(ctx, batch)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockURLService_BatchURLs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BatchURLs'
+type MockURLService_BatchURLs_Call struct {
+	*mock.Call
+}
+
+// BatchURLs is a helper method to define mock.On call
+//   - ctx
+//   - batch
+func (_e *MockURLService_Expecter) BatchURLs(ctx interface{}, batch interface{}) *MockURLService_BatchURLs_Call {
+	return &MockURLService_BatchURLs_Call{Call: _e.mock.On("BatchURLs", ctx, batch)}
+}
+
+func (_c *MockURLService_BatchURLs_Call) Run(run func(ctx context.Context, batch model.URLBatch)) *MockURLService_BatchURLs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.URLBatch))
+	})
+	return _c
+}
+
+func (_c *MockURLService_BatchURLs_Call) Return(batchResults []model.BatchResult, err error) *MockURLService_BatchURLs_Call {
+	_c.Call.Return(batchResults, err)
+	return _c
+}
+
+func (_c *MockURLService_BatchURLs_Call) RunAndReturn(run func(ctx context.Context, batch model.URLBatch) ([]model.BatchResult, error)) *MockURLService_BatchURLs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BulkShortenURLs provides a mock function for the type MockURLService
+func (_mock *MockURLService) BulkShortenURLs(ctx context.Context, userID uint, originalURLs []string, atomic bool) ([]model.BulkResult, error) {
+	ret := _mock.Called(ctx, userID, originalURLs, atomic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkShortenURLs")
+	}
+
+	var r0 []model.BulkResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, []string, bool) ([]model.BulkResult, error)); ok {
+		return returnFunc(ctx, userID, originalURLs, atomic)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, []string, bool) []model.BulkResult); ok {
+		r0 = returnFunc(ctx, userID, originalURLs, atomic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.BulkResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint, []string, bool) error); ok {
+		r1 = returnFunc(ctx, userID, originalURLs, atomic)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockURLService_BulkShortenURLs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkShortenURLs'
+type MockURLService_BulkShortenURLs_Call struct {
+	*mock.Call
+}
+
+// BulkShortenURLs is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - originalURLs
+//   - atomic
+func (_e *MockURLService_Expecter) BulkShortenURLs(ctx interface{}, userID interface{}, originalURLs interface{}, atomic interface{}) *MockURLService_BulkShortenURLs_Call {
+	return &MockURLService_BulkShortenURLs_Call{Call: _e.mock.On("BulkShortenURLs", ctx, userID, originalURLs, atomic)}
+}
+
+func (_c *MockURLService_BulkShortenURLs_Call) Run(run func(ctx context.Context, userID uint, originalURLs []string, atomic bool)) *MockURLService_BulkShortenURLs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].([]string), args[3].(bool))
+	})
+	return _c
+}
+
+func (_c *MockURLService_BulkShortenURLs_Call) Return(bulkResults []model.BulkResult, err error) *MockURLService_BulkShortenURLs_Call {
+	_c.Call.Return(bulkResults, err)
+	return _c
+}
+
+func (_c *MockURLService_BulkShortenURLs_Call) RunAndReturn(run func(ctx context.Context, userID uint, originalURLs []string, atomic bool) ([]model.BulkResult, error)) *MockURLService_BulkShortenURLs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteURL provides a mock function for the type MockURLService
-func (_mock *MockURLService) DeleteURL(ctx context.Context, shortCode string) error {
-	ret := _mock.Called(ctx, shortCode)
+func (_mock *MockURLService) DeleteURL(ctx context.Context, userID uint, shortCode string) error {
+	ret := _mock.Called(ctx, userID, shortCode)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteURL")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, shortCode)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, string) error); ok {
+		r0 = returnFunc(ctx, userID, shortCode)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -62,14 +179,15 @@ type MockURLService_DeleteURL_Call struct {
 
 // DeleteURL is a helper method to define mock.On call
 //   - ctx
+//   - userID
 //   - shortCode
-func (_e *MockURLService_Expecter) DeleteURL(ctx interface{}, shortCode interface{}) *MockURLService_DeleteURL_Call {
-	return &MockURLService_DeleteURL_Call{Call: _e.mock.On("DeleteURL", ctx, shortCode)}
+func (_e *MockURLService_Expecter) DeleteURL(ctx interface{}, userID interface{}, shortCode interface{}) *MockURLService_DeleteURL_Call {
+	return &MockURLService_DeleteURL_Call{Call: _e.mock.On("DeleteURL", ctx, userID, shortCode)}
 }
 
-func (_c *MockURLService_DeleteURL_Call) Run(run func(ctx context.Context, shortCode string)) *MockURLService_DeleteURL_Call {
+func (_c *MockURLService_DeleteURL_Call) Run(run func(ctx context.Context, userID uint, shortCode string)) *MockURLService_DeleteURL_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(uint), args[2].(string))
 	})
 	return _c
 }
@@ -79,7 +197,7 @@ func (_c *MockURLService_DeleteURL_Call) Return(err error) *MockURLService_Delet
 	return _c
 }
 
-func (_c *MockURLService_DeleteURL_Call) RunAndReturn(run func(ctx context.Context, shortCode string) error) *MockURLService_DeleteURL_Call {
+func (_c *MockURLService_DeleteURL_Call) RunAndReturn(run func(ctx context.Context, userID uint, shortCode string) error) *MockURLService_DeleteURL_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -142,27 +260,27 @@ func (_c *MockURLService_GetURL_Call) RunAndReturn(run func(ctx context.Context,
 }
 
 // ListURLs provides a mock function for the type MockURLService
-func (_mock *MockURLService) ListURLs(ctx context.Context, limit int, offset int) ([]*model.URL, error) {
-	ret := _mock.Called(ctx, limit, offset)
+func (_mock *MockURLService) ListURLs(ctx context.Context, query model.URLQuery) (*model.URLPage, error) {
+	ret := _mock.Called(ctx, query)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListURLs")
 	}
 
-	var r0 []*model.URL
+	var r0 *model.URLPage
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) ([]*model.URL, error)); ok {
-		return returnFunc(ctx, limit, offset)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.URLQuery) (*model.URLPage, error)); ok {
+		return returnFunc(ctx, query)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) []*model.URL); ok {
-		r0 = returnFunc(ctx, limit, offset)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.URLQuery) *model.URLPage); ok {
+		r0 = returnFunc(ctx, query)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.URL)
+			r0 = ret.Get(0).(*model.URLPage)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
-		r1 = returnFunc(ctx, limit, offset)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.URLQuery) error); ok {
+		r1 = returnFunc(ctx, query)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -176,49 +294,105 @@ type MockURLService_ListURLs_Call struct {
 
 // ListURLs is a helper method to define mock.On call
 //   - ctx
-//   - limit
-//   - offset
-func (_e *MockURLService_Expecter) ListURLs(ctx interface{}, limit interface{}, offset interface{}) *MockURLService_ListURLs_Call {
-	return &MockURLService_ListURLs_Call{Call: _e.mock.On("ListURLs", ctx, limit, offset)}
+//   - query
+func (_e *MockURLService_Expecter) ListURLs(ctx interface{}, query interface{}) *MockURLService_ListURLs_Call {
+	return &MockURLService_ListURLs_Call{Call: _e.mock.On("ListURLs", ctx, query)}
+}
+
+func (_c *MockURLService_ListURLs_Call) Run(run func(ctx context.Context, query model.URLQuery)) *MockURLService_ListURLs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.URLQuery))
+	})
+	return _c
+}
+
+func (_c *MockURLService_ListURLs_Call) Return(uRLPage *model.URLPage, err error) *MockURLService_ListURLs_Call {
+	_c.Call.Return(uRLPage, err)
+	return _c
+}
+
+func (_c *MockURLService_ListURLs_Call) RunAndReturn(run func(ctx context.Context, query model.URLQuery) (*model.URLPage, error)) *MockURLService_ListURLs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeDeletedURLs provides a mock function for the type MockURLService
+func (_mock *MockURLService) PurgeDeletedURLs(ctx context.Context, before time.Time) (int64, error) {
+	ret := _mock.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeletedURLs")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, before)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = returnFunc(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockURLService_PurgeDeletedURLs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeDeletedURLs'
+type MockURLService_PurgeDeletedURLs_Call struct {
+	*mock.Call
+}
+
+// PurgeDeletedURLs is a helper method to define mock.On call
+//   - ctx
+//   - before
+func (_e *MockURLService_Expecter) PurgeDeletedURLs(ctx interface{}, before interface{}) *MockURLService_PurgeDeletedURLs_Call {
+	return &MockURLService_PurgeDeletedURLs_Call{Call: _e.mock.On("PurgeDeletedURLs", ctx, before)}
 }
 
-func (_c *MockURLService_ListURLs_Call) Run(run func(ctx context.Context, limit int, offset int)) *MockURLService_ListURLs_Call {
+func (_c *MockURLService_PurgeDeletedURLs_Call) Run(run func(ctx context.Context, before time.Time)) *MockURLService_PurgeDeletedURLs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int), args[2].(int))
+		run(args[0].(context.Context), args[1].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockURLService_ListURLs_Call) Return(uRLs []*model.URL, err error) *MockURLService_ListURLs_Call {
-	_c.Call.Return(uRLs, err)
+func (_c *MockURLService_PurgeDeletedURLs_Call) Return(n int64, err error) *MockURLService_PurgeDeletedURLs_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockURLService_ListURLs_Call) RunAndReturn(run func(ctx context.Context, limit int, offset int) ([]*model.URL, error)) *MockURLService_ListURLs_Call {
+func (_c *MockURLService_PurgeDeletedURLs_Call) RunAndReturn(run func(ctx context.Context, before time.Time) (int64, error)) *MockURLService_PurgeDeletedURLs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // RedirectURL provides a mock function for the type MockURLService
-func (_mock *MockURLService) RedirectURL(ctx context.Context, shortCode string) (string, error) {
-	ret := _mock.Called(ctx, shortCode)
+func (_mock *MockURLService) RedirectURL(ctx context.Context, shortCode string, visit model.Visit) (*model.Redirect, error) {
+	ret := _mock.Called(ctx, shortCode, visit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RedirectURL")
 	}
 
-	var r0 string
+	var r0 *model.Redirect
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
-		return returnFunc(ctx, shortCode)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.Visit) (*model.Redirect, error)); ok {
+		return returnFunc(ctx, shortCode, visit)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = returnFunc(ctx, shortCode)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.Visit) *model.Redirect); ok {
+		r0 = returnFunc(ctx, shortCode, visit)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Redirect)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, shortCode)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, model.Visit) error); ok {
+		r1 = returnFunc(ctx, shortCode, visit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -233,30 +407,89 @@ type MockURLService_RedirectURL_Call struct {
 // RedirectURL is a helper method to define mock.On call
 //   - ctx
 //   - shortCode
-func (_e *MockURLService_Expecter) RedirectURL(ctx interface{}, shortCode interface{}) *MockURLService_RedirectURL_Call {
-	return &MockURLService_RedirectURL_Call{Call: _e.mock.On("RedirectURL", ctx, shortCode)}
+//   - visit
+func (_e *MockURLService_Expecter) RedirectURL(ctx interface{}, shortCode interface{}, visit interface{}) *MockURLService_RedirectURL_Call {
+	return &MockURLService_RedirectURL_Call{Call: _e.mock.On("RedirectURL", ctx, shortCode, visit)}
 }
 
-func (_c *MockURLService_RedirectURL_Call) Run(run func(ctx context.Context, shortCode string)) *MockURLService_RedirectURL_Call {
+func (_c *MockURLService_RedirectURL_Call) Run(run func(ctx context.Context, shortCode string, visit model.Visit)) *MockURLService_RedirectURL_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(model.Visit))
+	})
+	return _c
+}
+
+func (_c *MockURLService_RedirectURL_Call) Return(redirect *model.Redirect, err error) *MockURLService_RedirectURL_Call {
+	_c.Call.Return(redirect, err)
+	return _c
+}
+
+func (_c *MockURLService_RedirectURL_Call) RunAndReturn(run func(ctx context.Context, shortCode string, visit model.Visit) (*model.Redirect, error)) *MockURLService_RedirectURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreURL provides a mock function for the type MockURLService
+func (_mock *MockURLService) RestoreURL(ctx context.Context, userID uint, shortCode string) (*model.URL, error) {
+	ret := _mock.Called(ctx, userID, shortCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreURL")
+	}
+
+	var r0 *model.URL
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, string) (*model.URL, error)); ok {
+		return returnFunc(ctx, userID, shortCode)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, string) *model.URL); ok {
+		r0 = returnFunc(ctx, userID, shortCode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.URL)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint, string) error); ok {
+		r1 = returnFunc(ctx, userID, shortCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockURLService_RestoreURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreURL'
+type MockURLService_RestoreURL_Call struct {
+	*mock.Call
+}
+
+// RestoreURL is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - shortCode
+func (_e *MockURLService_Expecter) RestoreURL(ctx interface{}, userID interface{}, shortCode interface{}) *MockURLService_RestoreURL_Call {
+	return &MockURLService_RestoreURL_Call{Call: _e.mock.On("RestoreURL", ctx, userID, shortCode)}
+}
+
+func (_c *MockURLService_RestoreURL_Call) Run(run func(ctx context.Context, userID uint, shortCode string)) *MockURLService_RestoreURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *MockURLService_RedirectURL_Call) Return(s string, err error) *MockURLService_RedirectURL_Call {
-	_c.Call.Return(s, err)
+func (_c *MockURLService_RestoreURL_Call) Return(uRL *model.URL, err error) *MockURLService_RestoreURL_Call {
+	_c.Call.Return(uRL, err)
 	return _c
 }
 
-func (_c *MockURLService_RedirectURL_Call) RunAndReturn(run func(ctx context.Context, shortCode string) (string, error)) *MockURLService_RedirectURL_Call {
+func (_c *MockURLService_RestoreURL_Call) RunAndReturn(run func(ctx context.Context, userID uint, shortCode string) (*model.URL, error)) *MockURLService_RestoreURL_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // ShortenURL provides a mock function for the type MockURLService
-func (_mock *MockURLService) ShortenURL(ctx context.Context, originalURL string) (*model.URL, error) {
-	ret := _mock.Called(ctx, originalURL)
+func (_mock *MockURLService) ShortenURL(ctx context.Context, userID uint, originalURL string, settings model.URLSettings) (*model.URL, error) {
+	ret := _mock.Called(ctx, userID, originalURL, settings)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ShortenURL")
@@ -264,18 +497,18 @@ func (_mock *MockURLService) ShortenURL(ctx context.Context, originalURL string)
 
 	var r0 *model.URL
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.URL, error)); ok {
-		return returnFunc(ctx, originalURL)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, string, model.URLSettings) (*model.URL, error)); ok {
+		return returnFunc(ctx, userID, originalURL, settings)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.URL); ok {
-		r0 = returnFunc(ctx, originalURL)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, string, model.URLSettings) *model.URL); ok {
+		r0 = returnFunc(ctx, userID, originalURL, settings)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.URL)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, originalURL)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint, string, model.URLSettings) error); ok {
+		r1 = returnFunc(ctx, userID, originalURL, settings)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -289,14 +522,16 @@ type MockURLService_ShortenURL_Call struct {
 
 // ShortenURL is a helper method to define mock.On call
 //   - ctx
+//   - userID
 //   - originalURL
-func (_e *MockURLService_Expecter) ShortenURL(ctx interface{}, originalURL interface{}) *MockURLService_ShortenURL_Call {
-	return &MockURLService_ShortenURL_Call{Call: _e.mock.On("ShortenURL", ctx, originalURL)}
+//   - settings
+func (_e *MockURLService_Expecter) ShortenURL(ctx interface{}, userID interface{}, originalURL interface{}, settings interface{}) *MockURLService_ShortenURL_Call {
+	return &MockURLService_ShortenURL_Call{Call: _e.mock.On("ShortenURL", ctx, userID, originalURL, settings)}
 }
 
-func (_c *MockURLService_ShortenURL_Call) Run(run func(ctx context.Context, originalURL string)) *MockURLService_ShortenURL_Call {
+func (_c *MockURLService_ShortenURL_Call) Run(run func(ctx context.Context, userID uint, originalURL string, settings model.URLSettings)) *MockURLService_ShortenURL_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(uint), args[2].(string), args[3].(model.URLSettings))
 	})
 	return _c
 }
@@ -306,7 +541,124 @@ func (_c *MockURLService_ShortenURL_Call) Return(uRL *model.URL, err error) *Moc
 	return _c
 }
 
-func (_c *MockURLService_ShortenURL_Call) RunAndReturn(run func(ctx context.Context, originalURL string) (*model.URL, error)) *MockURLService_ShortenURL_Call {
+func (_c *MockURLService_ShortenURL_Call) RunAndReturn(run func(ctx context.Context, userID uint, originalURL string, settings model.URLSettings) (*model.URL, error)) *MockURLService_ShortenURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnlockURL provides a mock function for the type MockURLService
+func (_mock *MockURLService) UnlockURL(ctx context.Context, shortCode string, password string) (*model.URLAccess, error) {
+	ret := _mock.Called(ctx, shortCode, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnlockURL")
+	}
+
+	var r0 *model.URLAccess
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*model.URLAccess, error)); ok {
+		return returnFunc(ctx, shortCode, password)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *model.URLAccess); ok {
+		r0 = returnFunc(ctx, shortCode, password)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.URLAccess)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, shortCode, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockURLService_UnlockURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnlockURL'
+type MockURLService_UnlockURL_Call struct {
+	*mock.Call
+}
+
+// UnlockURL is a helper method to define mock.On call
+//   - ctx
+//   - shortCode
+//   - password
+func (_e *MockURLService_Expecter) UnlockURL(ctx interface{}, shortCode interface{}, password interface{}) *MockURLService_UnlockURL_Call {
+	return &MockURLService_UnlockURL_Call{Call: _e.mock.On("UnlockURL", ctx, shortCode, password)}
+}
+
+func (_c *MockURLService_UnlockURL_Call) Run(run func(ctx context.Context, shortCode string, password string)) *MockURLService_UnlockURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockURLService_UnlockURL_Call) Return(uRLAccess *model.URLAccess, err error) *MockURLService_UnlockURL_Call {
+	_c.Call.Return(uRLAccess, err)
+	return _c
+}
+
+func (_c *MockURLService_UnlockURL_Call) RunAndReturn(run func(ctx context.Context, shortCode string, password string) (*model.URLAccess, error)) *MockURLService_UnlockURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateURL provides a mock function for the type MockURLService
+func (_mock *MockURLService) UpdateURL(ctx context.Context, userID uint, shortCode string, changes model.URLChanges) (*model.URL, error) {
+	ret := _mock.Called(ctx, userID, shortCode, changes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateURL")
+	}
+
+	var r0 *model.URL
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, string, model.URLChanges) (*model.URL, error)); ok {
+		return returnFunc(ctx, userID, shortCode, changes)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, string, model.URLChanges) *model.URL); ok {
+		r0 = returnFunc(ctx, userID, shortCode, changes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.URL)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint, string, model.URLChanges) error); ok {
+		r1 = returnFunc(ctx, userID, shortCode, changes)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockURLService_UpdateURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateURL'
+type MockURLService_UpdateURL_Call struct {
+	*mock.Call
+}
+
+// UpdateURL is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - shortCode
+//   - changes
+func (_e *MockURLService_Expecter) UpdateURL(ctx interface{}, userID interface{}, shortCode interface{}, changes interface{}) *MockURLService_UpdateURL_Call {
+	return &MockURLService_UpdateURL_Call{Call: _e.mock.On("UpdateURL", ctx, userID, shortCode, changes)}
+}
+
+func (_c *MockURLService_UpdateURL_Call) Run(run func(ctx context.Context, userID uint, shortCode string, changes model.URLChanges)) *MockURLService_UpdateURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(string), args[3].(model.URLChanges))
+	})
+	return _c
+}
+
+func (_c *MockURLService_UpdateURL_Call) Return(uRL *model.URL, err error) *MockURLService_UpdateURL_Call {
+	_c.Call.Return(uRL, err)
+	return _c
+}
+
+func (_c *MockURLService_UpdateURL_Call) RunAndReturn(run func(ctx context.Context, userID uint, shortCode string, changes model.URLChanges) (*model.URL, error)) *MockURLService_UpdateURL_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -39,16 +39,16 @@ func (_m *MockUserRepository) EXPECT() *MockUserRepository_Expecter {
 }
 
 // CreateUser provides a mock function for the type MockUserRepository
-func (_mock *MockUserRepository) CreateUser(user *model.User) error {
-	ret := _mock.Called(user)
+func (_mock *MockUserRepository) CreateUser(ctx context.Context, user *model.User) error {
+	ret := _mock.Called(ctx, user)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateUser")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(*model.User) error); ok {
-		r0 = returnFunc(user)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.User) error); ok {
+		r0 = returnFunc(ctx, user)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -61,14 +61,15 @@ type MockUserRepository_CreateUser_Call struct {
 }
 
 // CreateUser is a helper method to define mock.On call
+//   - ctx
 //   - user
-func (_e *MockUserRepository_Expecter) CreateUser(user interface{}) *MockUserRepository_CreateUser_Call {
-	return &MockUserRepository_CreateUser_Call{Call: _e.mock.On("CreateUser", user)}
+func (_e *MockUserRepository_Expecter) CreateUser(ctx interface{}, user interface{}) *MockUserRepository_CreateUser_Call {
+	return &MockUserRepository_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, user)}
 }
 
-func (_c *MockUserRepository_CreateUser_Call) Run(run func(user *model.User)) *MockUserRepository_CreateUser_Call {
+func (_c *MockUserRepository_CreateUser_Call) Run(run func(ctx context.Context, user *model.User)) *MockUserRepository_CreateUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*model.User))
+		run(args[0].(context.Context), args[1].(*model.User))
 	})
 	return _c
 }
@@ -78,22 +79,22 @@ func (_c *MockUserRepository_CreateUser_Call) Return(err error) *MockUserReposit
 	return _c
 }
 
-func (_c *MockUserRepository_CreateUser_Call) RunAndReturn(run func(user *model.User) error) *MockUserRepository_CreateUser_Call {
+func (_c *MockUserRepository_CreateUser_Call) RunAndReturn(run func(ctx context.Context, user *model.User) error) *MockUserRepository_CreateUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // DeleteUser provides a mock function for the type MockUserRepository
-func (_mock *MockUserRepository) DeleteUser(id uint) error {
-	ret := _mock.Called(id)
+func (_mock *MockUserRepository) DeleteUser(ctx context.Context, id uint) error {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteUser")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(uint) error); ok {
-		r0 = returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -106,14 +107,15 @@ type MockUserRepository_DeleteUser_Call struct {
 }
 
 // DeleteUser is a helper method to define mock.On call
+//   - ctx
 //   - id
-func (_e *MockUserRepository_Expecter) DeleteUser(id interface{}) *MockUserRepository_DeleteUser_Call {
-	return &MockUserRepository_DeleteUser_Call{Call: _e.mock.On("DeleteUser", id)}
+func (_e *MockUserRepository_Expecter) DeleteUser(ctx interface{}, id interface{}) *MockUserRepository_DeleteUser_Call {
+	return &MockUserRepository_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, id)}
 }
 
-func (_c *MockUserRepository_DeleteUser_Call) Run(run func(id uint)) *MockUserRepository_DeleteUser_Call {
+func (_c *MockUserRepository_DeleteUser_Call) Run(run func(ctx context.Context, id uint)) *MockUserRepository_DeleteUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint))
+		run(args[0].(context.Context), args[1].(uint))
 	})
 	return _c
 }
@@ -123,7 +125,7 @@ func (_c *MockUserRepository_DeleteUser_Call) Return(err error) *MockUserReposit
 	return _c
 }
 
-func (_c *MockUserRepository_DeleteUser_Call) RunAndReturn(run func(id uint) error) *MockUserRepository_DeleteUser_Call {
+func (_c *MockUserRepository_DeleteUser_Call) RunAndReturn(run func(ctx context.Context, id uint) error) *MockUserRepository_DeleteUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -300,16 +302,16 @@ func (_c *MockUserRepository_GetByUsername_Call) RunAndReturn(run func(ctx conte
 }
 
 // UpdateUser provides a mock function for the type MockUserRepository
-func (_mock *MockUserRepository) UpdateUser(user *model.User) error {
-	ret := _mock.Called(user)
+func (_mock *MockUserRepository) UpdateUser(ctx context.Context, user *model.User) error {
+	ret := _mock.Called(ctx, user)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateUser")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(*model.User) error); ok {
-		r0 = returnFunc(user)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.User) error); ok {
+		r0 = returnFunc(ctx, user)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -322,14 +324,15 @@ type MockUserRepository_UpdateUser_Call struct {
 }
 
 // UpdateUser is a helper method to define mock.On call
+//   - ctx
 //   - user
-func (_e *MockUserRepository_Expecter) UpdateUser(user interface{}) *MockUserRepository_UpdateUser_Call {
-	return &MockUserRepository_UpdateUser_Call{Call: _e.mock.On("UpdateUser", user)}
+func (_e *MockUserRepository_Expecter) UpdateUser(ctx interface{}, user interface{}) *MockUserRepository_UpdateUser_Call {
+	return &MockUserRepository_UpdateUser_Call{Call: _e.mock.On("UpdateUser", ctx, user)}
 }
 
-func (_c *MockUserRepository_UpdateUser_Call) Run(run func(user *model.User)) *MockUserRepository_UpdateUser_Call {
+func (_c *MockUserRepository_UpdateUser_Call) Run(run func(ctx context.Context, user *model.User)) *MockUserRepository_UpdateUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*model.User))
+		run(args[0].(context.Context), args[1].(*model.User))
 	})
 	return _c
 }
@@ -339,7 +342,7 @@ func (_c *MockUserRepository_UpdateUser_Call) Return(err error) *MockUserReposit
 	return _c
 }
 
-func (_c *MockUserRepository_UpdateUser_Call) RunAndReturn(run func(user *model.User) error) *MockUserRepository_UpdateUser_Call {
+func (_c *MockUserRepository_UpdateUser_Call) RunAndReturn(run func(ctx context.Context, user *model.User) error) *MockUserRepository_UpdateUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
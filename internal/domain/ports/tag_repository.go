@@ -0,0 +1,27 @@
+package ports
+
+import (
+	"context"
+
+	"tiny-url/internal/domain/model"
+)
+
+// TagRepository define las operaciones de persistencia de las etiquetas de
+// cada usuario. Las etiquetas de otros usuarios se tratan como inexistentes.
+type TagRepository interface {
+	// List devuelve las etiquetas del usuario ordenadas por nombre
+	List(ctx context.Context, userID uint) ([]model.Tag, error)
+
+	// GetByID recupera una etiqueta del usuario por su ID
+	GetByID(ctx context.Context, userID, id uint) (*model.Tag, error)
+
+	// Create guarda una nueva etiqueta. El nombre debe ser único para el usuario.
+	Create(ctx context.Context, tag *model.Tag) error
+
+	// Rename cambia el nombre de una etiqueta; las URLs que la llevan la
+	// muestran con el nombre nuevo
+	Rename(ctx context.Context, tag *model.Tag) error
+
+	// Delete elimina una etiqueta del usuario y la quita de sus URLs
+	Delete(ctx context.Context, userID, id uint) error
+}
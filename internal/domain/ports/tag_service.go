@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+
+	"tiny-url/internal/domain/model"
+)
+
+// TagService define la gestión de las etiquetas de un usuario
+type TagService interface {
+	// ListTags devuelve las etiquetas del usuario ordenadas por nombre
+	ListTags(ctx context.Context, userID uint) ([]model.Tag, error)
+
+	// CreateTag crea una etiqueta del usuario
+	CreateTag(ctx context.Context, userID uint, name string) (*model.Tag, error)
+
+	// RenameTag cambia el nombre de una etiqueta del usuario
+	RenameTag(ctx context.Context, userID, id uint, name string) (*model.Tag, error)
+
+	// DeleteTag elimina una etiqueta del usuario y la quita de sus URLs
+	DeleteTag(ctx context.Context, userID, id uint) error
+}
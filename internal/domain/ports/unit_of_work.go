@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+)
+
+// Repos agrupa los repositorios ligados a una misma transacción
+type Repos interface {
+	// URLs devuelve el repositorio de URLs de la transacción
+	URLs() URLRepository
+
+	// Users devuelve el repositorio de usuarios de la transacción
+	Users() UserRepository
+
+	// Folders devuelve el repositorio de carpetas de la transacción
+	Folders() FolderRepository
+}
+
+// UnitOfWork ejecuta varias operaciones de repositorio de forma atómica
+type UnitOfWork interface {
+	// Do ejecuta fn dentro de una transacción. Si fn devuelve un error o entra
+	// en pánico se revierten todos los cambios; en otro caso se confirman.
+	Do(ctx context.Context, fn func(tx Repos) error) error
+}
@@ -1,28 +1,61 @@
 package ports
 
 import (
-"context"
+	"context"
+	"time"
 
-"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/model"
 )
 
 // URLRepository define las operaciones que debe implementar cualquier repositorio para las URLs
 type URLRepository interface {
 	// Create guarda una nueva URL en el repositorio
 	Create(ctx context.Context, url *model.URL) error
-	
-	// GetByShortCode recupera una URL por su código corto
+
+	// GetByShortCode recupera una URL por su código corto. Las URLs de la
+	// papelera no se encuentran.
 	GetByShortCode(ctx context.Context, shortCode string) (*model.URL, error)
-	
-	// GetByOriginalURL recupera una URL por su URL original
-	GetByOriginalURL(ctx context.Context, originalURL string) (*model.URL, error)
-	
-	// IncrementVisits incrementa el contador de visitas para una URL
-	IncrementVisits(ctx context.Context, shortCode string) error
-	
-	// List recupera todas las URLs con opciones de paginación
-	List(ctx context.Context, limit, offset int) ([]*model.URL, error)
-	
-	// Delete elimina una URL por su código corto
+
+	// GetDeletedByShortCode recupera una URL de la papelera por su código corto
+	GetDeletedByShortCode(ctx context.Context, shortCode string) (*model.URL, error)
+
+	// GetByOriginalURL recupera la URL de un usuario por su URL original
+	GetByOriginalURL(ctx context.Context, userID uint, originalURL string) (*model.URL, error)
+
+	// Update guarda los cambios de una URL existente, salvo sus etiquetas y
+	// sus reglas por dispositivo
+	Update(ctx context.Context, url *model.URL) error
+
+	// AddTags añade a la URL las etiquetas indicadas de su propietario,
+	// creando las que no existan. Las que ya tiene se ignoran.
+	AddTags(ctx context.Context, url *model.URL, names []string) error
+
+	// RemoveTags quita de la URL las etiquetas indicadas. Las que no tiene se ignoran.
+	RemoveTags(ctx context.Context, url *model.URL, names []string) error
+
+	// SetDeviceRules sustituye las reglas por dispositivo de la URL por rules
+	SetDeviceRules(ctx context.Context, url *model.URL, rules []model.DeviceRule) error
+
+	// IncrementVisits cuenta una visita a la URL si aún no ha alcanzado su
+	// límite de visitas, de forma atómica frente a visitas concurrentes.
+	// Devuelve false, sin contarla, si la URL ya no admite más visitas.
+	IncrementVisits(ctx context.Context, shortCode string) (bool, error)
+
+	// List recupera una página de URLs que cumplen el filtro, en el orden
+	// pedido, junto con el total de URLs que cumplen el filtro. Con cursor
+	// devuelve las URLs inmediatamente posteriores a su posición, o las
+	// anteriores si Cursor.Before, siempre en el orden pedido. La consulta
+	// debe llegar normalizada.
+	List(ctx context.Context, query model.URLQuery) ([]*model.URL, int64, error)
+
+	// Delete mueve una URL a la papelera por su código corto. Su código sigue
+	// reservado hasta que se purga.
 	Delete(ctx context.Context, shortCode string) error
+
+	// Restore saca de la papelera una URL por su código corto
+	Restore(ctx context.Context, shortCode string) error
+
+	// PurgeDeleted elimina definitivamente las URLs que entraron en la
+	// papelera antes de before y devuelve cuántas se han eliminado
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
 }
@@ -1,25 +1,59 @@
 package ports
 
 import (
-"context"
+	"context"
+	"time"
 
-"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/model"
 )
 
 // URLService define las operaciones de negocio para el acortador de URLs
 type URLService interface {
-	// ShortenURL crea una URL acortada para una URL original
-	ShortenURL(ctx context.Context, originalURL string) (*model.URL, error)
-	
+	// ShortenURL crea una URL acortada del usuario para una URL original, o
+	// devuelve la que el usuario ya tiene para ella. Con ajustes siempre se
+	// crea una URL nueva.
+	ShortenURL(ctx context.Context, userID uint, originalURL string, settings model.URLSettings) (*model.URL, error)
+
+	// BulkShortenURLs acorta varias URLs y devuelve un resultado por cada una,
+	// en el mismo orden. Con atomic se crean todas o ninguna; sin él cada URL
+	// se crea por separado. El error solo indica fallos de la operación completa.
+	BulkShortenURLs(ctx context.Context, userID uint, originalURLs []string, atomic bool) ([]model.BulkResult, error)
+
+	// BatchURLs aplica una operación a varias URLs del usuario en una sola
+	// transacción y devuelve un resultado por URL. Si falla alguna no se
+	// aplica a ninguna; el error solo indica fallos de la operación completa.
+	BatchURLs(ctx context.Context, batch model.URLBatch) ([]model.BatchResult, error)
+
 	// GetURL recupera la URL original a partir del código corto
 	GetURL(ctx context.Context, shortCode string) (*model.URL, error)
-	
-	// RedirectURL recupera la URL original y actualiza el contador de visitas
-	RedirectURL(ctx context.Context, shortCode string) (string, error)
-	
-	// ListURLs recupera todas las URLs con opciones de paginación
-	ListURLs(ctx context.Context, limit, offset int) ([]*model.URL, error)
-	
-	// DeleteURL elimina una URL por su código corto
-	DeleteURL(ctx context.Context, shortCode string) error
+
+	// RedirectURL devuelve el destino de una visita y actualiza el contador de
+	// visitas. Si la URL tiene contraseña y la visita no trae un pase vigente
+	// devuelve errors.ErrPasswordRequired, y si ha agotado sus visitas
+	// errors.ErrURLExhausted. Fuera de su periodo de actividad envía a la URL
+	// alternativa o devuelve errors.NotYetActiveError o errors.ErrURLEnded.
+	RedirectURL(ctx context.Context, shortCode string, visit model.Visit) (*model.Redirect, error)
+
+	// UnlockURL comprueba la contraseña de una URL protegida y emite un pase
+	// temporal para visitarla; devuelve errors.ErrWrongPassword si no coincide
+	UnlockURL(ctx context.Context, shortCode, password string) (*model.URLAccess, error)
+
+	// ListURLs recupera una página de URLs filtrada y ordenada, con el total
+	// de URLs que cumplen el filtro y los cursores de las páginas vecinas. El
+	// tamaño de página se limita a model.MaxListLimit.
+	ListURLs(ctx context.Context, query model.URLQuery) (*model.URLPage, error)
+
+	// UpdateURL modifica las etiquetas, la carpeta o los ajustes de
+	// redirección de una URL del usuario
+	UpdateURL(ctx context.Context, userID uint, shortCode string, changes model.URLChanges) (*model.URL, error)
+
+	// DeleteURL mueve a la papelera una URL del usuario por su código corto
+	DeleteURL(ctx context.Context, userID uint, shortCode string) error
+
+	// RestoreURL saca de la papelera una URL del usuario por su código corto
+	RestoreURL(ctx context.Context, userID uint, shortCode string) (*model.URL, error)
+
+	// PurgeDeletedURLs elimina definitivamente las URLs que entraron en la
+	// papelera antes de before, liberando sus códigos
+	PurgeDeletedURLs(ctx context.Context, before time.Time) (int64, error)
 }
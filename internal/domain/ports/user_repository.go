@@ -8,7 +8,7 @@ import (
 // UserRepository define las operaciones para el repositorio de usuarios
 type UserRepository interface {
 	// CreateUser crea un nuevo usuario en la base de datos
-	CreateUser(user *model.User) error
+	CreateUser(ctx context.Context, user *model.User) error
 
 	// GetByID obtiene un usuario por su ID
 	GetByID(ctx context.Context, id uint) (*model.User, error)
@@ -20,8 +20,8 @@ type UserRepository interface {
 	GetByEmail(ctx context.Context, email string) (*model.User, error)
 
 	// UpdateUser actualiza la información de un usuario
-	UpdateUser(user *model.User) error
+	UpdateUser(ctx context.Context, user *model.User) error
 
 	// DeleteUser elimina un usuario de la base de datos
-	DeleteUser(id uint) error
+	DeleteUser(ctx context.Context, id uint) error
 }
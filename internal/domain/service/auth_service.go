@@ -14,33 +14,23 @@ import (
 
 type authService struct {
 	userRepo ports.UserRepository
+	uow      ports.UnitOfWork
 	jwtKey   []byte
 }
 
 // NewAuthService crea una nueva instancia del servicio de autenticación
-func NewAuthService(userRepo ports.UserRepository) ports.AuthService {
+func NewAuthService(userRepo ports.UserRepository, uow ports.UnitOfWork) ports.AuthService {
 	// En un entorno real, esta clave sería obtenida de variables de entorno o un servicio de secretos
 	jwtKey := []byte("mi_clave_secreta_muy_segura")
 	return &authService{
 		userRepo: userRepo,
+		uow:      uow,
 		jwtKey:   jwtKey,
 	}
 }
 
 // Register registra un nuevo usuario en el sistema
 func (s *authService) Register(ctx context.Context, username, email, password string) (*model.User, string, error) {
-	// Comprobar si el usuario ya existe
-	existingUser, _ := s.userRepo.GetByUsername(ctx, username)
-	if existingUser != nil {
-		return nil, "", errors.ErrUserAlreadyExists
-	}
-
-	// Comprobar si el email ya existe
-	existingEmail, _ := s.userRepo.GetByEmail(ctx, email)
-	if existingEmail != nil {
-		return nil, "", errors.ErrUserAlreadyExists
-	}
-
 	// Hash de la contraseña
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
@@ -54,8 +44,26 @@ func (s *authService) Register(ctx context.Context, username, email, password st
 		Password: string(hashedPassword),
 	}
 
-	// Guardar el usuario en la base de datos
-	if err := s.userRepo.CreateUser(user); err != nil {
+	// Comprobar duplicados y guardar el usuario en una misma transacción
+	err = s.uow.Do(ctx, func(tx ports.Repos) error {
+		users := tx.Users()
+
+		// Comprobar si el usuario ya existe
+		existingUser, _ := users.GetByUsername(ctx, username)
+		if existingUser != nil {
+			return errors.ErrUserAlreadyExists
+		}
+
+		// Comprobar si el email ya existe
+		existingEmail, _ := users.GetByEmail(ctx, email)
+		if existingEmail != nil {
+			return errors.ErrUserAlreadyExists
+		}
+
+		// Guardar el usuario en la base de datos
+		return users.CreateUser(ctx, user)
+	})
+	if err != nil {
 		return nil, "", err
 	}
 
@@ -69,9 +77,9 @@ func (s *authService) Register(ctx context.Context, username, email, password st
 }
 
 // Login autentica a un usuario y devuelve un token JWT
-func (s *authService) Login(username, password string) (string, error) {
+func (s *authService) Login(ctx context.Context, username, password string) (string, error) {
 	// Buscar al usuario por nombre de usuario
-	user, err := s.userRepo.GetByUsername(context.Background(), username)
+	user, err := s.userRepo.GetByUsername(ctx, username)
 	if err != nil {
 		return "", errors.ErrInvalidCredentials
 	}
@@ -106,6 +114,20 @@ func (s *authService) GetUser(ctx context.Context, userID uint) (*model.User, er
 	return user, nil
 }
 
+// SetLanguage guarda el idioma preferido del usuario
+func (s *authService) SetLanguage(ctx context.Context, userID uint, language string) (*model.User, error) {
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Language = language
+	if err := s.userRepo.UpdateUser(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
 // ValidateToken valida un token JWT y devuelve el ID del usuario
 func (s *authService) ValidateToken(tokenString string) (uint, error) {
 	claims := &struct {
@@ -7,6 +7,7 @@ import (
 
 	domainErrors "tiny-url/internal/domain/errors"
 	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports"
 	"tiny-url/internal/domain/ports/mocks"
 
 	"github.com/stretchr/testify/assert"
@@ -14,10 +15,24 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// newUnitOfWorkMock devuelve una unidad de trabajo simulada que ejecuta la
+// función recibida con el repositorio de usuarios indicado
+func newUnitOfWorkMock(t *testing.T, userRepo ports.UserRepository) *mocks.MockUnitOfWork {
+	repos := mocks.NewMockRepos(t)
+	repos.EXPECT().Users().Return(userRepo).Maybe()
+
+	uow := mocks.NewMockUnitOfWork(t)
+	uow.EXPECT().Do(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, fn func(tx ports.Repos) error) error {
+		return fn(repos)
+	}).Maybe()
+
+	return uow
+}
+
 func TestRegister_Success(t *testing.T) {
 	// Arrange
 	mockRepo := mocks.NewMockUserRepository(t)
-	service := NewAuthService(mockRepo)
+	service := NewAuthService(mockRepo, newUnitOfWorkMock(t, mockRepo))
 
 	username := "testuser"
 	email := "test@example.com"
@@ -27,7 +42,7 @@ func TestRegister_Success(t *testing.T) {
 	// Configurar el comportamiento del mock
 	mockRepo.EXPECT().GetByUsername(ctx, username).Return(nil, domainErrors.ErrUserNotFound)
 	mockRepo.EXPECT().GetByEmail(ctx, email).Return(nil, domainErrors.ErrUserNotFound)
-	mockRepo.EXPECT().CreateUser(mock.AnythingOfType("*model.User")).Return(nil)
+	mockRepo.EXPECT().CreateUser(ctx, mock.AnythingOfType("*model.User")).Return(nil)
 
 	// Act
 	user, token, err := service.Register(ctx, username, email, password)
@@ -44,7 +59,7 @@ func TestRegister_Success(t *testing.T) {
 func TestRegister_UsernameExists(t *testing.T) {
 	// Arrange
 	mockRepo := mocks.NewMockUserRepository(t)
-	service := NewAuthService(mockRepo)
+	service := NewAuthService(mockRepo, newUnitOfWorkMock(t, mockRepo))
 
 	username := "existinguser"
 	email := "new@example.com"
@@ -73,10 +88,35 @@ func TestRegister_UsernameExists(t *testing.T) {
 	assert.Empty(t, token)
 }
 
+func TestRegister_CreateFails(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockUserRepository(t)
+	service := NewAuthService(mockRepo, newUnitOfWorkMock(t, mockRepo))
+
+	username := "newuser"
+	email := "new@example.com"
+	password := "password123"
+	ctx := context.Background()
+
+	// Configurar el comportamiento del mock
+	mockRepo.EXPECT().GetByUsername(ctx, username).Return(nil, domainErrors.ErrUserNotFound)
+	mockRepo.EXPECT().GetByEmail(ctx, email).Return(nil, domainErrors.ErrUserNotFound)
+	mockRepo.EXPECT().CreateUser(ctx, mock.AnythingOfType("*model.User")).Return(domainErrors.ErrDuplicateKey)
+
+	// Act
+	user, token, err := service.Register(ctx, username, email, password)
+
+	// Assert
+	assert.Error(t, err)
+	assert.True(t, domainErrors.Is(err, domainErrors.ErrDuplicateKey))
+	assert.Nil(t, user)
+	assert.Empty(t, token)
+}
+
 func TestRegister_EmailExists(t *testing.T) {
 	// Arrange
 	mockRepo := mocks.NewMockUserRepository(t)
-	service := NewAuthService(mockRepo)
+	service := NewAuthService(mockRepo, newUnitOfWorkMock(t, mockRepo))
 
 	username := "newuser"
 	email := "existing@example.com"
@@ -109,7 +149,7 @@ func TestRegister_EmailExists(t *testing.T) {
 func TestLogin_Success(t *testing.T) {
 	// Arrange
 	mockRepo := mocks.NewMockUserRepository(t)
-	service := NewAuthService(mockRepo)
+	service := NewAuthService(mockRepo, newUnitOfWorkMock(t, mockRepo))
 
 	username := "testuser"
 	password := "password123"
@@ -128,7 +168,7 @@ func TestLogin_Success(t *testing.T) {
 	mockRepo.EXPECT().GetByUsername(ctx, username).Return(user, nil)
 
 	// Act
-	token, err := service.Login(username, password)
+	token, err := service.Login(ctx, username, password)
 
 	// Assert
 	assert.NoError(t, err)
@@ -138,7 +178,7 @@ func TestLogin_Success(t *testing.T) {
 func TestLogin_InvalidCredentials(t *testing.T) {
 	// Arrange
 	mockRepo := mocks.NewMockUserRepository(t)
-	service := NewAuthService(mockRepo)
+	service := NewAuthService(mockRepo, newUnitOfWorkMock(t, mockRepo))
 
 	username := "testuser"
 	correctPassword := "correctpassword"
@@ -157,7 +197,7 @@ func TestLogin_InvalidCredentials(t *testing.T) {
 	mockRepo.EXPECT().GetByUsername(ctx, username).Return(user, nil)
 
 	// Act - Intentar login con contraseña incorrecta
-	token, err := service.Login(username, wrongPassword)
+	token, err := service.Login(ctx, username, wrongPassword)
 
 	// Assert
 	assert.Error(t, err)
@@ -168,7 +208,7 @@ func TestLogin_InvalidCredentials(t *testing.T) {
 func TestLogin_UserNotFound(t *testing.T) {
 	// Arrange
 	mockRepo := mocks.NewMockUserRepository(t)
-	service := NewAuthService(mockRepo)
+	service := NewAuthService(mockRepo, newUnitOfWorkMock(t, mockRepo))
 
 	username := "nonexistentuser"
 	password := "password123"
@@ -178,7 +218,7 @@ func TestLogin_UserNotFound(t *testing.T) {
 	mockRepo.EXPECT().GetByUsername(ctx, username).Return(nil, domainErrors.ErrUserNotFound)
 
 	// Act
-	token, err := service.Login(username, password)
+	token, err := service.Login(ctx, username, password)
 
 	// Assert
 	assert.Error(t, err)
@@ -189,7 +229,7 @@ func TestLogin_UserNotFound(t *testing.T) {
 func TestGetUser_Success(t *testing.T) {
 	// Arrange
 	mockRepo := mocks.NewMockUserRepository(t)
-	service := NewAuthService(mockRepo)
+	service := NewAuthService(mockRepo, newUnitOfWorkMock(t, mockRepo))
 
 	userID := uint(1)
 	ctx := context.Background()
@@ -213,7 +253,7 @@ func TestGetUser_Success(t *testing.T) {
 func TestGetUser_NotFound(t *testing.T) {
 	// Arrange
 	mockRepo := mocks.NewMockUserRepository(t)
-	service := NewAuthService(mockRepo)
+	service := NewAuthService(mockRepo, newUnitOfWorkMock(t, mockRepo))
 
 	userID := uint(999)
 	ctx := context.Background()
@@ -230,10 +270,52 @@ func TestGetUser_NotFound(t *testing.T) {
 	assert.Nil(t, user)
 }
 
+func TestSetLanguage_Success(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockUserRepository(t)
+	service := NewAuthService(mockRepo, newUnitOfWorkMock(t, mockRepo))
+
+	userID := uint(1)
+	ctx := context.Background()
+	existingUser := &model.User{ID: userID, Username: "testuser", Email: "test@example.com"}
+
+	// Configurar el comportamiento del mock
+	mockRepo.EXPECT().GetByID(ctx, userID).Return(existingUser, nil)
+	mockRepo.EXPECT().UpdateUser(ctx, mock.MatchedBy(func(user *model.User) bool {
+		return user.ID == userID && user.Language == "en"
+	})).Return(nil)
+
+	// Act
+	user, err := service.SetLanguage(ctx, userID, "en")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "en", user.Language)
+}
+
+func TestSetLanguage_UserNotFound(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockUserRepository(t)
+	service := NewAuthService(mockRepo, newUnitOfWorkMock(t, mockRepo))
+
+	userID := uint(999)
+	ctx := context.Background()
+
+	// Configurar el comportamiento del mock
+	mockRepo.EXPECT().GetByID(ctx, userID).Return(nil, domainErrors.ErrUserNotFound)
+
+	// Act
+	user, err := service.SetLanguage(ctx, userID, "en")
+
+	// Assert
+	assert.Nil(t, user)
+	assert.True(t, domainErrors.Is(err, domainErrors.ErrUserNotFound))
+}
+
 func TestValidateToken_Success(t *testing.T) {
 	// Arrange
 	mockRepo := mocks.NewMockUserRepository(t)
-	service := NewAuthService(mockRepo)
+	service := NewAuthService(mockRepo, newUnitOfWorkMock(t, mockRepo))
 
 	userID := uint(1)
 
@@ -252,7 +334,7 @@ func TestValidateToken_Success(t *testing.T) {
 func TestValidateToken_Invalid(t *testing.T) {
 	// Arrange
 	mockRepo := mocks.NewMockUserRepository(t)
-	service := NewAuthService(mockRepo)
+	service := NewAuthService(mockRepo, newUnitOfWorkMock(t, mockRepo))
 
 	// Act
 	userID, err := service.ValidateToken("invalid.token.string")
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports"
+)
+
+type folderService struct {
+	repo ports.FolderRepository
+}
+
+// NewFolderService crea una nueva instancia del servicio de carpetas
+func NewFolderService(repo ports.FolderRepository) ports.FolderService {
+	return &folderService{repo: repo}
+}
+
+// ListFolders devuelve las carpetas del usuario ordenadas por nombre
+func (s *folderService) ListFolders(ctx context.Context, userID uint) ([]model.Folder, error) {
+	return s.repo.List(ctx, userID)
+}
+
+// CreateFolder crea una carpeta del usuario. Los nombres repetidos devuelven
+// errors.ErrDuplicateKey.
+func (s *folderService) CreateFolder(ctx context.Context, userID uint, name string) (*model.Folder, error) {
+	name, err := validFolderName(name)
+	if err != nil {
+		return nil, err
+	}
+	folder := &model.Folder{UserID: userID, Name: name}
+	if err := s.repo.Create(ctx, folder); err != nil {
+		return nil, err
+	}
+	return folder, nil
+}
+
+// RenameFolder cambia el nombre de una carpeta del usuario
+func (s *folderService) RenameFolder(ctx context.Context, userID, id uint, name string) (*model.Folder, error) {
+	name, err := validFolderName(name)
+	if err != nil {
+		return nil, err
+	}
+	folder, err := s.repo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	folder.Name = name
+	if err := s.repo.Rename(ctx, folder); err != nil {
+		return nil, err
+	}
+	return folder, nil
+}
+
+// DeleteFolder elimina una carpeta del usuario; sus URLs quedan fuera de
+// cualquier carpeta
+func (s *folderService) DeleteFolder(ctx context.Context, userID, id uint) error {
+	return s.repo.Delete(ctx, userID, id)
+}
+
+// validFolderName recorta el nombre de una carpeta y comprueba que no está
+// vacío ni supera model.MaxFolderNameLength
+func validFolderName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len([]rune(name)) > model.MaxFolderNameLength {
+		return "", errors.ErrInvalidFolder
+	}
+	return name, nil
+}
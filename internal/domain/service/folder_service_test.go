@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	domainErrors "tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateFolder_Success(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockFolderRepository(t)
+	service := NewFolderService(mockRepo)
+	ctx := context.Background()
+
+	mockRepo.EXPECT().Create(ctx, mock.MatchedBy(func(folder *model.Folder) bool {
+		return folder.UserID == 7 && folder.Name == "Campañas 2026"
+	})).Return(nil)
+
+	// Act
+	folder, err := service.CreateFolder(ctx, 7, " Campañas 2026")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Campañas 2026", folder.Name)
+}
+
+func TestCreateFolder_InvalidName(t *testing.T) {
+	// Arrange
+	service := NewFolderService(mocks.NewMockFolderRepository(t))
+
+	// Act
+	folder, err := service.CreateFolder(context.Background(), 7, strings.Repeat("a", model.MaxFolderNameLength+1))
+
+	// Assert
+	assert.Nil(t, folder)
+	assert.Equal(t, domainErrors.ErrInvalidFolder, err)
+}
+
+func TestDeleteFolder_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockFolderRepository(t)
+	service := NewFolderService(mockRepo)
+	ctx := context.Background()
+
+	mockRepo.EXPECT().Delete(ctx, uint(7), uint(3)).Return(domainErrors.ErrFolderNotFound)
+
+	// Act
+	err := service.DeleteFolder(ctx, 7, 3)
+
+	// Assert
+	assert.Equal(t, domainErrors.ErrFolderNotFound, err)
+}
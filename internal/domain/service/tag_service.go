@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports"
+)
+
+type tagService struct {
+	repo ports.TagRepository
+}
+
+// NewTagService crea una nueva instancia del servicio de etiquetas
+func NewTagService(repo ports.TagRepository) ports.TagService {
+	return &tagService{repo: repo}
+}
+
+// ListTags devuelve las etiquetas del usuario ordenadas por nombre
+func (s *tagService) ListTags(ctx context.Context, userID uint) ([]model.Tag, error) {
+	return s.repo.List(ctx, userID)
+}
+
+// CreateTag crea una etiqueta del usuario. Los nombres repetidos devuelven
+// errors.ErrDuplicateKey.
+func (s *tagService) CreateTag(ctx context.Context, userID uint, name string) (*model.Tag, error) {
+	name, err := validTagName(name)
+	if err != nil {
+		return nil, err
+	}
+	tag := &model.Tag{UserID: userID, Name: name}
+	if err := s.repo.Create(ctx, tag); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+// RenameTag cambia el nombre de una etiqueta del usuario
+func (s *tagService) RenameTag(ctx context.Context, userID, id uint, name string) (*model.Tag, error) {
+	name, err := validTagName(name)
+	if err != nil {
+		return nil, err
+	}
+	tag, err := s.repo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	tag.Name = name
+	if err := s.repo.Rename(ctx, tag); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+// DeleteTag elimina una etiqueta del usuario y la quita de sus URLs
+func (s *tagService) DeleteTag(ctx context.Context, userID, id uint) error {
+	return s.repo.Delete(ctx, userID, id)
+}
+
+// validTagName recorta el nombre de una etiqueta y comprueba que no está
+// vacío ni supera model.MaxTagLength
+func validTagName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len([]rune(name)) > model.MaxTagLength {
+		return "", errors.ErrInvalidTag
+	}
+	return name, nil
+}
+
+// validTags normaliza los nombres de etiquetas de una URL y comprueba su longitud
+func validTags(names []string) ([]string, error) {
+	tags := normalizeTags(names)
+	for _, tag := range tags {
+		if _, err := validTagName(tag); err != nil {
+			return nil, err
+		}
+	}
+	return tags, nil
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	domainErrors "tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateTag_TrimsName(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockTagRepository(t)
+	service := NewTagService(mockRepo)
+	ctx := context.Background()
+
+	mockRepo.EXPECT().Create(ctx, mock.MatchedBy(func(tag *model.Tag) bool {
+		return tag.UserID == 7 && tag.Name == "verano"
+	})).Return(nil)
+
+	// Act
+	tag, err := service.CreateTag(ctx, 7, "  verano ")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "verano", tag.Name)
+}
+
+func TestCreateTag_InvalidName(t *testing.T) {
+	// Arrange
+	service := NewTagService(mocks.NewMockTagRepository(t))
+
+	for _, name := range []string{"   ", strings.Repeat("a", model.MaxTagLength+1)} {
+		// Act
+		tag, err := service.CreateTag(context.Background(), 7, name)
+
+		// Assert
+		assert.Nil(t, tag)
+		assert.Equal(t, domainErrors.ErrInvalidTag, err)
+	}
+}
+
+func TestRenameTag_Success(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockTagRepository(t)
+	service := NewTagService(mockRepo)
+	ctx := context.Background()
+
+	mockRepo.EXPECT().GetByID(ctx, uint(7), uint(3)).Return(&model.Tag{ID: 3, UserID: 7, Name: "verano"}, nil)
+	mockRepo.EXPECT().Rename(ctx, &model.Tag{ID: 3, UserID: 7, Name: "agosto"}).Return(nil)
+
+	// Act
+	tag, err := service.RenameTag(ctx, 7, 3, "agosto")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "agosto", tag.Name)
+}
+
+func TestRenameTag_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockTagRepository(t)
+	service := NewTagService(mockRepo)
+	ctx := context.Background()
+
+	mockRepo.EXPECT().GetByID(ctx, uint(7), uint(3)).Return(nil, domainErrors.ErrTagNotFound)
+
+	// Act
+	tag, err := service.RenameTag(ctx, 7, 3, "agosto")
+
+	// Assert
+	assert.Nil(t, tag)
+	assert.Equal(t, domainErrors.ErrTagNotFound, err)
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+)
+
+// DefaultAccessTTL es la vigencia de los pases de las URLs protegidas cuando
+// no se configura otra
+const DefaultAccessTTL = time.Hour
+
+// MaxLinkPasswordLength es la longitud máxima en bytes de la contraseña de
+// una URL; bcrypt ignora lo que pase de ahí
+const MaxLinkPasswordLength = 72
+
+// WithAccessSecret establece la clave con la que se firman los pases de las
+// URLs protegidas. Todas las instancias deben compartirla; sin ella se usa
+// una clave aleatoria por proceso.
+func WithAccessSecret(secret []byte) URLServiceOption {
+	return func(s *urlService) {
+		s.access.secret = secret
+	}
+}
+
+// WithAccessTTL establece la vigencia de los pases de las URLs protegidas
+func WithAccessTTL(ttl time.Duration) URLServiceOption {
+	return func(s *urlService) {
+		if ttl > 0 {
+			s.access.ttl = ttl
+		}
+	}
+}
+
+// UnlockURL comprueba la contraseña de una URL y emite un pase que caduca
+// pasado el TTL configurado
+func (s *urlService) UnlockURL(ctx context.Context, shortCode, password string) (*model.URLAccess, error) {
+	url, err := s.repo.GetByShortCode(ctx, shortCode)
+	if err != nil {
+		return nil, err
+	}
+	if url == nil {
+		return nil, errors.ErrURLNotFound
+	}
+	if url.Protected() {
+		if err := bcrypt.CompareHashAndPassword([]byte(url.PasswordHash), []byte(password)); err != nil {
+			return nil, errors.ErrWrongPassword
+		}
+	}
+	return s.access.issue(url, s.now()), nil
+}
+
+// hashLinkPassword devuelve el hash bcrypt de la contraseña de una URL, o
+// vacío si no se ha pedido ninguna
+func hashLinkPassword(password string) (string, error) {
+	if password == "" {
+		return "", nil
+	}
+	if len(password) > MaxLinkPasswordLength {
+		return "", errors.ErrInvalidPassword
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// accessSigner emite y comprueba los pases de las URLs protegidas. La firma
+// cubre el hash de la contraseña, así que cambiarla invalida los pases ya
+// emitidos.
+type accessSigner struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// newAccessSigner crea un firmante con la clave indicada o, si está vacía,
+// con una clave aleatoria válida mientras viva el proceso
+func newAccessSigner(secret []byte, ttl time.Duration) accessSigner {
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			panic("access secret: " + err.Error())
+		}
+	}
+	return accessSigner{secret: secret, ttl: ttl}
+}
+
+// issue emite un pase para url válido desde now hasta now más el TTL
+func (a accessSigner) issue(url *model.URL, now time.Time) *model.URLAccess {
+	expiresAt := now.Add(a.ttl).Truncate(time.Second)
+	expiry := strconv.FormatInt(expiresAt.Unix(), 10)
+	return &model.URLAccess{
+		Token:     expiry + "." + base64.RawURLEncoding.EncodeToString(a.sign(url, expiry)),
+		ExpiresAt: expiresAt,
+	}
+}
+
+// verify indica si token es un pase de url emitido por este firmante y sin caducar en now
+func (a accessSigner) verify(url *model.URL, token string, now time.Time) bool {
+	expiry, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+	seconds, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil || !now.Before(time.Unix(seconds, 0)) {
+		return false
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	return err == nil && hmac.Equal(mac, a.sign(url, expiry))
+}
+
+// sign calcula la firma del pase de url que caduca en expiry
+func (a accessSigner) sign(url *model.URL, expiry string) []byte {
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write([]byte(url.ShortCode + "|" + expiry + "|" + url.PasswordHash))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	domainErrors "tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// protectedURL devuelve una URL protegida con la contraseña indicada
+func protectedURL(t *testing.T, password string) *model.URL {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+	return &model.URL{ShortCode: "abc123", OriginalURL: "https://example.com", PasswordHash: string(hash)}
+}
+
+func TestShortenURL_WithPasswordStoresHash(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, newFolderUnitOfWorkMock(t, mockRepo, mocks.NewMockFolderRepository(t)))
+	ctx := context.Background()
+
+	mockRepo.EXPECT().Create(ctx, mock.AnythingOfType("*model.URL")).Return(nil)
+	mockRepo.EXPECT().AddTags(ctx, mock.AnythingOfType("*model.URL"), mock.Anything).Return(nil)
+
+	// Act
+	url, err := service.ShortenURL(ctx, 7, "https://example.com", model.URLSettings{Password: "s3creto"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, url.Protected())
+	assert.NotEqual(t, "s3creto", url.PasswordHash)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(url.PasswordHash), []byte("s3creto")))
+}
+
+func TestShortenURL_PasswordTooLong(t *testing.T) {
+	// Arrange
+	service := NewURLService(mocks.NewMockURLRepository(t), nil)
+
+	// Act
+	url, err := service.ShortenURL(context.Background(), 7, "https://example.com", model.URLSettings{
+		Password: strings.Repeat("x", MaxLinkPasswordLength+1),
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidPassword)
+	assert.Nil(t, url)
+}
+
+func TestRedirectURL_ProtectedRequiresAccess(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, nil)
+	ctx := context.Background()
+
+	// Sin pase no se cuenta la visita
+	mockRepo.EXPECT().GetByShortCode(ctx, "abc123").Return(protectedURL(t, "s3creto"), nil)
+
+	// Act
+	redirect, err := service.RedirectURL(ctx, "abc123", model.Visit{AccessToken: "1.forged"})
+
+	// Assert
+	assert.ErrorIs(t, err, domainErrors.ErrPasswordRequired)
+	assert.Nil(t, redirect)
+}
+
+func TestUnlockURL_WrongPassword(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, nil)
+	ctx := context.Background()
+
+	mockRepo.EXPECT().GetByShortCode(ctx, "abc123").Return(protectedURL(t, "s3creto"), nil)
+
+	// Act
+	access, err := service.UnlockURL(ctx, "abc123", "otra")
+
+	// Assert
+	assert.ErrorIs(t, err, domainErrors.ErrWrongPassword)
+	assert.Nil(t, access)
+}
+
+func TestUnlockURL_AccessAllowsRedirectUntilExpiry(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, nil, WithAccessSecret([]byte("secret")), WithAccessTTL(time.Minute)).(*urlService)
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+	ctx := context.Background()
+	url := protectedURL(t, "s3creto")
+
+	mockRepo.EXPECT().GetByShortCode(ctx, "abc123").Return(url, nil)
+	mockRepo.EXPECT().IncrementVisits(ctx, "abc123").Return(true, nil).Once()
+
+	// Act
+	access, err := service.UnlockURL(ctx, "abc123", "s3creto")
+	require.NoError(t, err)
+	redirect, redirectErr := service.RedirectURL(ctx, "abc123", model.Visit{AccessToken: access.Token})
+	now = now.Add(time.Minute)
+	_, expiredErr := service.RedirectURL(ctx, "abc123", model.Visit{AccessToken: access.Token})
+
+	// Assert
+	assert.Equal(t, now, access.ExpiresAt)
+	assert.NoError(t, redirectErr)
+	// Las URLs protegidas no se cachean aunque la redirección sea permanente
+	assert.Equal(t, &model.Redirect{Location: "https://example.com", Status: http.StatusMovedPermanently}, redirect)
+	assert.ErrorIs(t, expiredErr, domainErrors.ErrPasswordRequired)
+}
+
+func TestUnlockURL_PasswordChangeRevokesAccess(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, nil)
+	ctx := context.Background()
+	url := protectedURL(t, "s3creto")
+
+	mockRepo.EXPECT().GetByShortCode(ctx, "abc123").Return(url, nil)
+	access, err := service.UnlockURL(ctx, "abc123", "s3creto")
+	require.NoError(t, err)
+	url.PasswordHash = protectedURL(t, "nueva").PasswordHash
+
+	// Act
+	_, err = service.RedirectURL(ctx, "abc123", model.Visit{AccessToken: access.Token})
+
+	// Assert
+	assert.ErrorIs(t, err, domainErrors.ErrPasswordRequired)
+}
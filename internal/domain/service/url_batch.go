@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports"
+)
+
+// errBatchRollback revierte la transacción de un lote con algún fallo
+var errBatchRollback = errors.New("batch rolled back")
+
+// batchOperation aplica la operación de un lote a una URL
+type batchOperation func(ctx context.Context, url *model.URL) error
+
+// BatchURLs aplica una operación a varias URLs del usuario en una transacción
+func (s *urlService) BatchURLs(ctx context.Context, batch model.URLBatch) ([]model.BatchResult, error) {
+	tags := normalizeTags(batch.Tags)
+	if err := validateBatch(batch, tags); err != nil {
+		return nil, err
+	}
+
+	var results []model.BatchResult
+	err := s.uow.Do(ctx, func(tx ports.Repos) error {
+		operation, err := batchOperationFor(ctx, tx, batch, tags)
+		if err != nil {
+			return err
+		}
+		targets, err := batchTargets(ctx, tx.URLs(), batch)
+		if err != nil {
+			return err
+		}
+
+		results = make([]model.BatchResult, len(targets))
+		failed := false
+		for i, target := range targets {
+			results[i] = model.BatchResult{ShortCode: target.code, Status: model.BulkApplied}
+			err := errors.ErrURLNotFound
+			if target.url != nil {
+				err = operation(ctx, target.url)
+			}
+			if err != nil {
+				results[i].Status = model.BulkFailed
+				results[i].Err = err
+				failed = true
+			}
+		}
+		if failed {
+			return errBatchRollback
+		}
+		return nil
+	})
+	if err == nil {
+		return results, nil
+	}
+	if !errors.Is(err, errBatchRollback) {
+		return nil, err
+	}
+
+	// Nada se ha guardado: solo las URLs que fallaron conservan su resultado
+	for i := range results {
+		if results[i].Status != model.BulkFailed {
+			results[i].Status = model.BulkSkipped
+		}
+	}
+	return results, nil
+}
+
+// validateBatch comprueba que el lote tiene destino y los datos de su operación
+func validateBatch(batch model.URLBatch, tags []string) error {
+	if len(batch.ShortCodes) == 0 && batch.Filter == nil {
+		return errors.ErrInvalidBatch
+	}
+	switch batch.Action {
+	case model.BatchDelete, model.BatchSetExpiry:
+		return nil
+	case model.BatchAddTags, model.BatchRemoveTags:
+		if len(tags) == 0 {
+			return errors.ErrInvalidBatch
+		}
+		for _, tag := range tags {
+			if len([]rune(tag)) > model.MaxTagLength {
+				return errors.ErrInvalidBatch
+			}
+		}
+		return nil
+	case model.BatchChangeOwner:
+		if batch.Owner == "" {
+			return errors.ErrInvalidBatch
+		}
+		return nil
+	default:
+		return errors.ErrInvalidBatch
+	}
+}
+
+// batchOperationFor prepara la operación del lote sobre los repositorios de la transacción
+func batchOperationFor(ctx context.Context, tx ports.Repos, batch model.URLBatch, tags []string) (batchOperation, error) {
+	urls := tx.URLs()
+	switch batch.Action {
+	case model.BatchDelete:
+		return func(ctx context.Context, url *model.URL) error {
+			return urls.Delete(ctx, url.ShortCode)
+		}, nil
+	case model.BatchSetExpiry:
+		return func(ctx context.Context, url *model.URL) error {
+			url.ExpiresAt = batch.ExpiresAt
+			return urls.Update(ctx, url)
+		}, nil
+	case model.BatchAddTags:
+		return func(ctx context.Context, url *model.URL) error {
+			return urls.AddTags(ctx, url, tags)
+		}, nil
+	case model.BatchRemoveTags:
+		return func(ctx context.Context, url *model.URL) error {
+			return urls.RemoveTags(ctx, url, tags)
+		}, nil
+	default:
+		owner, err := tx.Users().GetByUsername(ctx, batch.Owner)
+		if err != nil {
+			return nil, err
+		}
+
+		// Las etiquetas son de cada usuario: la URL pasa a llevar las
+		// etiquetas del nuevo propietario con los mismos nombres
+		return func(ctx context.Context, url *model.URL) error {
+			names := url.TagNames()
+			if err := urls.RemoveTags(ctx, url, names); err != nil {
+				return err
+			}
+			// Las carpetas también son de cada usuario: la URL sale de la suya
+			url.UserID = owner.ID
+			url.FolderID = nil
+			if err := urls.Update(ctx, url); err != nil {
+				return err
+			}
+			return urls.AddTags(ctx, url, names)
+		}, nil
+	}
+}
+
+// batchTarget es una URL pedida en un lote; url es nil si no existe o no es del usuario
+type batchTarget struct {
+	code string
+	url  *model.URL
+}
+
+// batchTargets busca las URLs del lote: las de los códigos indicados, sin
+// repetir, o todas las del usuario que cumplen el filtro. Un filtro que
+// incluye más de MaxTargets URLs rechaza el lote entero.
+func batchTargets(ctx context.Context, repo ports.URLRepository, batch model.URLBatch) ([]batchTarget, error) {
+	if len(batch.ShortCodes) == 0 {
+		filter := *batch.Filter
+		filter.UserID = batch.UserID
+		query := model.URLQuery{Filter: filter, Sort: model.SortCreatedAtAsc, Limit: -1}
+		if batch.MaxTargets > 0 {
+			// Basta una URL de más para saber que el filtro se pasa del límite
+			query.Limit = batch.MaxTargets + 1
+		}
+		urls, _, err := repo.List(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		if batch.MaxTargets > 0 && len(urls) > batch.MaxTargets {
+			return nil, errors.ErrBatchTooLarge
+		}
+		targets := make([]batchTarget, len(urls))
+		for i, url := range urls {
+			targets[i] = batchTarget{code: url.ShortCode, url: url}
+		}
+		return targets, nil
+	}
+
+	var targets []batchTarget
+	for _, code := range batch.ShortCodes {
+		if slices.ContainsFunc(targets, func(t batchTarget) bool { return t.code == code }) {
+			continue
+		}
+		url, err := repo.GetByShortCode(ctx, code)
+		if err != nil && !errors.Is(err, errors.ErrURLNotFound) {
+			return nil, err
+		}
+		// Las URLs de otros usuarios se tratan como inexistentes
+		if url != nil && url.UserID != batch.UserID {
+			url = nil
+		}
+		targets = append(targets, batchTarget{code: code, url: url})
+	}
+	return targets, nil
+}
+
+// normalizeTags recorta los nombres de las etiquetas y quita vacíos y repetidos
+func normalizeTags(names []string) []string {
+	tags := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name != "" && !slices.Contains(tags, name) {
+			tags = append(tags, name)
+		}
+	}
+	return tags
+}
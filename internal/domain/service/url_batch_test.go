@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports"
+	"tiny-url/internal/domain/ports/mocks"
+)
+
+// newBatchUnitOfWorkMock devuelve una unidad de trabajo simulada que ejecuta
+// la función recibida con los repositorios indicados y devuelve su error
+func newBatchUnitOfWorkMock(t *testing.T, urlRepo ports.URLRepository, userRepo ports.UserRepository) *mocks.MockUnitOfWork {
+	repos := mocks.NewMockRepos(t)
+	repos.EXPECT().URLs().Return(urlRepo).Maybe()
+	repos.EXPECT().Users().Return(userRepo).Maybe()
+
+	uow := mocks.NewMockUnitOfWork(t)
+	uow.EXPECT().Do(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, fn func(tx ports.Repos) error) error {
+		return fn(repos)
+	}).Maybe()
+
+	return uow
+}
+
+func TestBatchURLs_DeletesOwnURLs(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, newBatchUnitOfWorkMock(t, mockRepo, nil))
+	ctx := context.Background()
+
+	mockRepo.EXPECT().GetByShortCode(ctx, "abc123").Return(&model.URL{ID: 1, UserID: 1, ShortCode: "abc123"}, nil)
+	mockRepo.EXPECT().GetByShortCode(ctx, "def456").Return(&model.URL{ID: 2, UserID: 1, ShortCode: "def456"}, nil)
+	mockRepo.EXPECT().Delete(ctx, "abc123").Return(nil)
+	mockRepo.EXPECT().Delete(ctx, "def456").Return(nil)
+
+	// Act
+	results, err := service.BatchURLs(ctx, model.URLBatch{
+		UserID:     1,
+		Action:     model.BatchDelete,
+		ShortCodes: []string{"abc123", "def456", "abc123"},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, model.BatchResult{ShortCode: "abc123", Status: model.BulkApplied}, results[0])
+	assert.Equal(t, model.BatchResult{ShortCode: "def456", Status: model.BulkApplied}, results[1])
+}
+
+func TestBatchURLs_RollsBackWhenAnyURLFails(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, newBatchUnitOfWorkMock(t, mockRepo, nil))
+	ctx := context.Background()
+	expiresAt := time.Now().Add(time.Hour)
+
+	mockRepo.EXPECT().GetByShortCode(ctx, "mine").Return(&model.URL{ID: 1, UserID: 1, ShortCode: "mine"}, nil)
+	mockRepo.EXPECT().GetByShortCode(ctx, "theirs").Return(&model.URL{ID: 2, UserID: 2, ShortCode: "theirs"}, nil)
+	mockRepo.EXPECT().GetByShortCode(ctx, "missing").Return(nil, domainErrors.ErrURLNotFound)
+	mockRepo.EXPECT().Update(ctx, mock.MatchedBy(func(url *model.URL) bool { return url.ExpiresAt == &expiresAt })).Return(nil)
+
+	// Act
+	results, err := service.BatchURLs(ctx, model.URLBatch{
+		UserID:     1,
+		Action:     model.BatchSetExpiry,
+		ShortCodes: []string{"mine", "theirs", "missing"},
+		ExpiresAt:  &expiresAt,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, model.BulkSkipped, results[0].Status)
+	assert.Equal(t, model.BulkFailed, results[1].Status)
+	assert.True(t, domainErrors.Is(results[1].Err, domainErrors.ErrURLNotFound), "las URLs de otros usuarios no se revelan")
+	assert.Equal(t, model.BulkFailed, results[2].Status)
+}
+
+func TestBatchURLs_FilterIsScopedToTheUser(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, newBatchUnitOfWorkMock(t, mockRepo, nil))
+	ctx := context.Background()
+	url := &model.URL{ID: 1, UserID: 1, ShortCode: "abc123"}
+
+	mockRepo.EXPECT().List(ctx, mock.MatchedBy(func(query model.URLQuery) bool {
+		return query.Filter.UserID == 1 && query.Filter.Domain == "ejemplo.com" && query.Limit < 0
+	})).Return([]*model.URL{url}, 1, nil)
+	mockRepo.EXPECT().AddTags(ctx, url, []string{"verano"}).Return(nil)
+
+	// Act
+	results, err := service.BatchURLs(ctx, model.URLBatch{
+		UserID: 1,
+		Action: model.BatchAddTags,
+		Filter: &model.URLFilter{UserID: 2, Domain: "ejemplo.com"},
+		Tags:   []string{" verano", "verano", ""},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []model.BatchResult{{ShortCode: "abc123", Status: model.BulkApplied}}, results)
+}
+
+func TestBatchURLs_FilterOverMaxTargets(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, newBatchUnitOfWorkMock(t, mockRepo, nil))
+	ctx := context.Background()
+	urls := []*model.URL{
+		{ID: 1, UserID: 1, ShortCode: "abc123"},
+		{ID: 2, UserID: 1, ShortCode: "def456"},
+		{ID: 3, UserID: 1, ShortCode: "ghi789"},
+	}
+
+	// Se piden MaxTargets+1 URLs; ninguna se borra
+	mockRepo.EXPECT().List(ctx, mock.MatchedBy(func(query model.URLQuery) bool {
+		return query.Limit == 3
+	})).Return(urls, 3, nil)
+
+	// Act
+	results, err := service.BatchURLs(ctx, model.URLBatch{
+		UserID:     1,
+		Action:     model.BatchDelete,
+		Filter:     &model.URLFilter{Domain: "ejemplo.com"},
+		MaxTargets: 2,
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domainErrors.ErrBatchTooLarge)
+	assert.Nil(t, results)
+}
+
+func TestBatchURLs_ChangeOwnerKeepsTagNames(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	mockUsers := mocks.NewMockUserRepository(t)
+	service := NewURLService(mockRepo, newBatchUnitOfWorkMock(t, mockRepo, mockUsers))
+	ctx := context.Background()
+	url := &model.URL{ID: 1, UserID: 1, ShortCode: "abc123", Tags: []model.Tag{{ID: 3, UserID: 1, Name: "verano"}}}
+
+	mockUsers.EXPECT().GetByUsername(ctx, "nuevo").Return(&model.User{ID: 2, Username: "nuevo"}, nil)
+	mockRepo.EXPECT().GetByShortCode(ctx, "abc123").Return(url, nil)
+	mockRepo.EXPECT().RemoveTags(ctx, url, []string{"verano"}).Return(nil)
+	mockRepo.EXPECT().Update(ctx, url).Return(nil)
+	mockRepo.EXPECT().AddTags(ctx, url, []string{"verano"}).Return(nil)
+
+	// Act
+	results, err := service.BatchURLs(ctx, model.URLBatch{
+		UserID:     1,
+		Action:     model.BatchChangeOwner,
+		ShortCodes: []string{"abc123"},
+		Owner:      "nuevo",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, model.BulkApplied, results[0].Status)
+	assert.Equal(t, uint(2), url.UserID)
+}
+
+func TestBatchURLs_UnknownOwner(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	mockUsers := mocks.NewMockUserRepository(t)
+	service := NewURLService(mockRepo, newBatchUnitOfWorkMock(t, mockRepo, mockUsers))
+	ctx := context.Background()
+
+	mockUsers.EXPECT().GetByUsername(ctx, "nadie").Return(nil, domainErrors.ErrUserNotFound)
+
+	// Act
+	results, err := service.BatchURLs(ctx, model.URLBatch{
+		UserID:     1,
+		Action:     model.BatchChangeOwner,
+		ShortCodes: []string{"abc123"},
+		Owner:      "nadie",
+	})
+
+	// Assert
+	assert.Nil(t, results)
+	assert.True(t, domainErrors.Is(err, domainErrors.ErrUserNotFound))
+}
+
+func TestBatchURLs_Invalid(t *testing.T) {
+	// Arrange
+	service := NewURLService(mocks.NewMockURLRepository(t), nil)
+	ctx := context.Background()
+
+	// Act & Assert
+	for name, batch := range map[string]model.URLBatch{
+		"without targets":    {UserID: 1, Action: model.BatchDelete},
+		"unknown action":     {UserID: 1, Action: "archive", ShortCodes: []string{"abc123"}},
+		"add without tags":   {UserID: 1, Action: model.BatchAddTags, ShortCodes: []string{"abc123"}, Tags: []string{" "}},
+		"owner without name": {UserID: 1, Action: model.BatchChangeOwner, ShortCodes: []string{"abc123"}},
+	} {
+		_, err := service.BatchURLs(ctx, batch)
+		assert.True(t, domainErrors.Is(err, domainErrors.ErrInvalidBatch), name)
+	}
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"net/http"
+	"time"
+
+	"tiny-url/internal/domain/model"
+)
+
+// DefaultRedirectCode es el código de las URLs sin uno propio cuando no se
+// configura otro. Se mantiene el permanente con el que siempre han redirigido.
+const DefaultRedirectCode = http.StatusMovedPermanently
+
+// DefaultRedirectMaxAge es el tiempo máximo que se pueden cachear las
+// redirecciones permanentes cuando no se configura otro
+const DefaultRedirectMaxAge = 24 * time.Hour
+
+// WithDefaultRedirectCode establece el código de las URLs sin uno propio.
+// Los códigos que no están en model.RedirectCodes se ignoran.
+func WithDefaultRedirectCode(code int) URLServiceOption {
+	return func(s *urlService) {
+		if model.ValidRedirectCode(code) {
+			s.redirectCode = code
+		}
+	}
+}
+
+// WithRedirectMaxAge establece el tiempo máximo que navegadores y cachés
+// pueden reutilizar una redirección permanente; cero desactiva la caché
+func WithRedirectMaxAge(maxAge time.Duration) URLServiceOption {
+	return func(s *urlService) {
+		if maxAge >= 0 {
+			s.redirectMaxAge = maxAge
+		}
+	}
+}
+
+// redirectTo devuelve la redirección de visit a url en now. Solo las
+// redirecciones permanentes se cachean, y nunca más allá de la caducidad o
+// del fin del periodo de actividad de la URL.
+func (s *urlService) redirectTo(url *model.URL, visit model.Visit, now time.Time) *model.Redirect {
+	redirect := &model.Redirect{Location: url.Destination(visit), Status: url.RedirectCode}
+	if redirect.Status == 0 {
+		redirect.Status = s.redirectCode
+	}
+
+	// Cada visita a una URL con contraseña o límite de visitas debe llegar al
+	// servidor para comprobarse y contarse, y el destino de una plantilla que
+	// usa cabeceras cambia de un visitante a otro
+	if !model.PermanentRedirect(redirect.Status) || url.Protected() || url.MaxVisits != nil || url.VariesByHeader() {
+		return redirect
+	}
+	maxAge := s.redirectMaxAge
+	for _, end := range []*time.Time{url.ExpiresAt, url.ActiveUntil} {
+		if end != nil && end.Sub(now) < maxAge {
+			maxAge = end.Sub(now)
+		}
+	}
+	if maxAge = maxAge.Truncate(time.Second); maxAge > 0 {
+		redirect.MaxAge = maxAge
+	}
+	return redirect
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"tiny-url/internal/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedirectTo_StatusAndCaching(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	soon := now.Add(90 * time.Minute)
+	maxVisits := 10
+
+	tests := map[string]struct {
+		url      model.URL
+		expected model.Redirect
+	}{
+		"server default is cached up to the max age": {
+			url:      model.URL{},
+			expected: model.Redirect{Status: http.StatusPermanentRedirect, MaxAge: 2 * time.Hour},
+		},
+		"temporary redirects are not cached": {
+			url:      model.URL{RedirectCode: http.StatusTemporaryRedirect},
+			expected: model.Redirect{Status: http.StatusTemporaryRedirect},
+		},
+		"expiry bounds the cache": {
+			url:      model.URL{RedirectCode: http.StatusMovedPermanently, ExpiresAt: &soon},
+			expected: model.Redirect{Status: http.StatusMovedPermanently, MaxAge: 90 * time.Minute},
+		},
+		"end of the active period bounds the cache": {
+			url:      model.URL{ActiveUntil: &soon},
+			expected: model.Redirect{Status: http.StatusPermanentRedirect, MaxAge: 90 * time.Minute},
+		},
+		"visit limits are never cached": {
+			url:      model.URL{MaxVisits: &maxVisits},
+			expected: model.Redirect{Status: http.StatusPermanentRedirect},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			service := NewURLService(nil, nil,
+				WithDefaultRedirectCode(http.StatusPermanentRedirect),
+				WithRedirectMaxAge(2*time.Hour),
+			).(*urlService)
+			url := tt.url
+			url.OriginalURL = "https://example.com"
+
+			// Act
+			redirect := service.redirectTo(&url, model.Visit{}, now)
+
+			// Assert
+			tt.expected.Location = "https://example.com"
+			assert.Equal(t, &tt.expected, redirect)
+		})
+	}
+}
+
+func TestWithDefaultRedirectCode_IgnoresInvalidCodes(t *testing.T) {
+	// Arrange & Act
+	service := NewURLService(nil, nil, WithDefaultRedirectCode(http.StatusOK)).(*urlService)
+
+	// Assert
+	assert.Equal(t, DefaultRedirectCode, service.redirectCode)
+}
+
+func TestRedirectTo_Passthrough(t *testing.T) {
+	visit := model.Visit{Path: "/docs/../intro/", RawQuery: "utm_source=x&lang=en"}
+
+	tests := map[string]struct {
+		url      model.URL
+		visit    model.Visit
+		expected string
+	}{
+		"nothing is forwarded by default": {
+			url:      model.URL{},
+			visit:    visit,
+			expected: "https://example.com/app?lang=es",
+		},
+		"path is cleaned and appended": {
+			url:      model.URL{ForwardPath: true},
+			visit:    model.Visit{Path: "/../../etc/passwd"},
+			expected: "https://example.com/app/etc/passwd?lang=es",
+		},
+		"keep leaves the destination parameters": {
+			url:      model.URL{ForwardQuery: model.QueryKeep, ForwardPath: true},
+			visit:    visit,
+			expected: "https://example.com/app/intro/?lang=es&utm_source=x",
+		},
+		"override replaces the destination parameters": {
+			url:      model.URL{ForwardQuery: model.QueryOverride},
+			visit:    visit,
+			expected: "https://example.com/app?lang=en&utm_source=x",
+		},
+		"append keeps both values": {
+			url:      model.URL{ForwardQuery: model.QueryAppend},
+			visit:    visit,
+			expected: "https://example.com/app?lang=es&lang=en&utm_source=x",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			service := NewURLService(nil, nil).(*urlService)
+			url := tt.url
+			url.OriginalURL = "https://example.com/app?lang=es"
+
+			// Act
+			redirect := service.redirectTo(&url, tt.visit, time.Now())
+
+			// Assert
+			assert.Equal(t, tt.expected, redirect.Location)
+		})
+	}
+}
+
+func TestRedirectTo_Template(t *testing.T) {
+	tests := map[string]struct {
+		template string
+		visit    model.Visit
+		expected string
+		cached   bool
+	}{
+		"defaults fill missing values": {
+			template: "https://docs.example.com/{path|index}?lang={query.lang|en}",
+			visit:    model.Visit{},
+			expected: "https://docs.example.com/index?lang=en",
+			cached:   true,
+		},
+		"path keeps its segments and values are encoded": {
+			template: "https://docs.example.com/{path}?lang={query.lang|en}",
+			visit:    model.Visit{Path: "/guía/../intro a", RawQuery: "lang=es%26x%3D1"},
+			expected: "https://docs.example.com/intro%20a?lang=es%26x%3D1",
+			cached:   true,
+		},
+		"query values in the path cannot add segments": {
+			template: "https://docs.example.com/{query.page}",
+			visit:    model.Visit{RawQuery: "page=../admin"},
+			expected: "https://docs.example.com/..%2Fadmin",
+			cached:   true,
+		},
+		"headers disable caching": {
+			template: "https://docs.example.com/?hl={header.Accept-Language|en}",
+			visit:    model.Visit{Header: http.Header{"Accept-Language": {"es-ES"}}},
+			expected: "https://docs.example.com/?hl=es-ES",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			service := NewURLService(nil, nil,
+				WithDefaultRedirectCode(http.StatusMovedPermanently),
+				WithRedirectMaxAge(time.Hour),
+			).(*urlService)
+			url := model.URL{OriginalURL: tt.template, Templated: true}
+
+			// Act
+			redirect := service.redirectTo(&url, tt.visit, time.Now())
+
+			// Assert
+			assert.Equal(t, tt.expected, redirect.Location)
+			assert.Equal(t, tt.cached, redirect.MaxAge > 0)
+		})
+	}
+}
+
+func TestRedirectTo_DeviceRules(t *testing.T) {
+	url := model.URL{
+		OriginalURL: "https://example.com/app",
+		DeviceRules: []model.DeviceRule{
+			{Platform: model.PlatformIOS, Destination: "https://apps.apple.com/app/id1"},
+			{Platform: model.PlatformAndroid, Destination: "miapp://inicio"},
+			{Platform: model.PlatformBot, Destination: "https://example.com/preview"},
+		},
+	}
+
+	tests := map[string]struct {
+		userAgent string
+		expected  string
+	}{
+		"iphone": {
+			userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15",
+			expected:  "https://apps.apple.com/app/id1",
+		},
+		"android": {
+			userAgent: "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 Chrome/120.0 Mobile",
+			expected:  "miapp://inicio",
+		},
+		"crawler posing as android": {
+			userAgent: "Mozilla/5.0 (Linux; Android 6.0.1; Nexus 5X) (compatible; Googlebot/2.1)",
+			expected:  "https://example.com/preview",
+		},
+		"desktop without a rule falls back to the original url": {
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 Chrome/120.0",
+			expected:  "https://example.com/app",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			service := NewURLService(nil, nil, WithRedirectMaxAge(time.Hour)).(*urlService)
+			visit := model.Visit{Header: http.Header{"User-Agent": {tt.userAgent}}}
+
+			// Act
+			redirect := service.redirectTo(&url, visit, time.Now())
+
+			// Assert
+			assert.Equal(t, tt.expected, redirect.Location)
+			assert.Zero(t, redirect.MaxAge)
+		})
+	}
+}
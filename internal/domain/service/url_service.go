@@ -5,6 +5,11 @@ import (
 	"crypto/rand"
 	"fmt"
 	"math/big"
+	"net/http"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
 
 	"tiny-url/internal/domain/errors"
 	"tiny-url/internal/domain/model"
@@ -16,50 +21,318 @@ const (
 	charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 	// Longitud del código corto
 	codeLength = 6
+	// Intentos de generar un código libre antes de rendirse
+	maxCodeAttempts = 5
 )
 
 type urlService struct {
-	repo ports.URLRepository
+	repo   ports.URLRepository
+	uow    ports.UnitOfWork
+	access accessSigner
+	now    func() time.Time
+
+	// redirectCode es el código de las URLs sin uno propio y redirectMaxAge
+	// el tiempo máximo que se pueden cachear las redirecciones permanentes
+	redirectCode   int
+	redirectMaxAge time.Duration
 }
 
+// URLServiceOption configura el servicio de URL en su construcción
+type URLServiceOption func(*urlService)
+
 // NewURLService crea una nueva instancia del servicio de URL
-func NewURLService(repo ports.URLRepository) ports.URLService {
-	return &urlService{
-		repo: repo,
+func NewURLService(repo ports.URLRepository, uow ports.UnitOfWork, opts ...URLServiceOption) ports.URLService {
+	s := &urlService{
+		repo:           repo,
+		uow:            uow,
+		access:         accessSigner{ttl: DefaultAccessTTL},
+		now:            time.Now,
+		redirectCode:   DefaultRedirectCode,
+		redirectMaxAge: DefaultRedirectMaxAge,
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	s.access = newAccessSigner(s.access.secret, s.access.ttl)
+	return s
 }
 
-// ShortenURL implementa la lógica para acortar una URL
-func (s *urlService) ShortenURL(ctx context.Context, originalURL string) (*model.URL, error) {
-	// Validar que la URL no esté vacía
+// ShortenURL implementa la lógica para acortar una URL. Con etiquetas o
+// carpeta la URL se crea, sin reutilizar otra, en una transacción.
+func (s *urlService) ShortenURL(ctx context.Context, userID uint, originalURL string, settings model.URLSettings) (*model.URL, error) {
+	if settings.IsZero() {
+		url, _, err := s.shorten(ctx, s.repo, userID, originalURL)
+		return url, err
+	}
 	if originalURL == "" {
 		return nil, errors.ErrInvalidURL
 	}
-
-	// Verificar si la URL ya existe en la base de datos
-	existingURL, err := s.repo.GetByOriginalURL(ctx, originalURL)
-	if err == nil && existingURL != nil {
-		return existingURL, nil
+	tags, err := validTags(settings.Tags)
+	if err != nil {
+		return nil, err
+	}
+	passwordHash, err := hashLinkPassword(settings.Password)
+	if err != nil {
+		return nil, err
+	}
+	if settings.MaxVisits != nil && *settings.MaxVisits < 1 {
+		return nil, errors.ErrInvalidLimit
+	}
+	if settings.ActiveFrom != nil && settings.ActiveUntil != nil && !settings.ActiveUntil.After(*settings.ActiveFrom) {
+		return nil, errors.ErrInvalidSchedule
+	}
+	if settings.RedirectCode != 0 && !model.ValidRedirectCode(settings.RedirectCode) {
+		return nil, errors.ErrInvalidRedirect
+	}
+	if !settings.ForwardQuery.Valid() {
+		return nil, errors.ErrInvalidForwarding
+	}
+	if settings.Template {
+		if _, err := model.ParseTemplate(originalURL); err != nil {
+			return nil, fmt.Errorf("%w: %v", errors.ErrInvalidTemplate, err)
+		}
+	}
+	if !model.ValidDeviceRules(settings.DeviceRules) {
+		return nil, errors.ErrInvalidDeviceRule
 	}
 
-	// Generar un código corto único
-	shortCode, err := s.generateShortCode()
+	var url *model.URL
+	err = s.uow.Do(ctx, func(tx ports.Repos) error {
+		if settings.FolderID != nil {
+			if _, err := tx.Folders().GetByID(ctx, userID, *settings.FolderID); err != nil {
+				return err
+			}
+		}
+		created, err := s.create(ctx, tx.URLs(), &model.URL{
+			UserID:       userID,
+			OriginalURL:  originalURL,
+			FolderID:     settings.FolderID,
+			PasswordHash: passwordHash,
+			MaxVisits:    settings.MaxVisits,
+			ActiveFrom:   settings.ActiveFrom,
+			ActiveUntil:  settings.ActiveUntil,
+			FallbackURL:  settings.FallbackURL,
+			RedirectCode: settings.RedirectCode,
+			ForwardQuery: settings.ForwardQuery,
+			ForwardPath:  settings.ForwardPath,
+			Templated:    settings.Template,
+		})
+		if err != nil {
+			return err
+		}
+		if err := tx.URLs().AddTags(ctx, created, tags); err != nil {
+			return err
+		}
+		if len(settings.DeviceRules) > 0 {
+			if err := tx.URLs().SetDeviceRules(ctx, created, settings.DeviceRules); err != nil {
+				return err
+			}
+		}
+		url = created
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("%w: %v", errors.ErrGeneratingCode, err)
+		return nil, err
 	}
+	return url, nil
+}
 
-	// Crear el objeto URL
-	url := &model.URL{
-		OriginalURL: originalURL,
-		ShortCode:   shortCode,
-		Visits:      0,
+// errBulkRollback revierte la transacción de una creación masiva atómica
+var errBulkRollback = errors.New("bulk creation rolled back")
+
+// BulkShortenURLs acorta varias URLs con la misma validación y deduplicación
+// que ShortenURL
+func (s *urlService) BulkShortenURLs(ctx context.Context, userID uint, originalURLs []string, atomic bool) ([]model.BulkResult, error) {
+	results := make([]model.BulkResult, len(originalURLs))
+	if !atomic {
+		for i, originalURL := range originalURLs {
+			results[i] = s.shortenResult(ctx, s.repo, userID, originalURL)
+		}
+		return results, nil
 	}
 
-	// Guardar la URL en el repositorio
-	if err := s.repo.Create(ctx, url); err != nil {
+	err := s.uow.Do(ctx, func(tx ports.Repos) error {
+		for i, originalURL := range originalURLs {
+			results[i] = s.shortenResult(ctx, tx.URLs(), userID, originalURL)
+			if results[i].Status == model.BulkFailed {
+				return errBulkRollback
+			}
+		}
+		return nil
+	})
+	if err == nil {
+		return results, nil
+	}
+	if !errors.Is(err, errBulkRollback) {
 		return nil, err
 	}
 
+	// Nada se ha guardado: solo la URL que falló conserva su resultado
+	for i := range results {
+		if results[i].Status != model.BulkFailed {
+			results[i] = model.BulkResult{Status: model.BulkSkipped}
+		}
+	}
+	return results, nil
+}
+
+// shortenResult acorta una URL y describe el resultado para una creación masiva
+func (s *urlService) shortenResult(ctx context.Context, repo ports.URLRepository, userID uint, originalURL string) model.BulkResult {
+	url, created, err := s.shorten(ctx, repo, userID, originalURL)
+	switch {
+	case err != nil:
+		return model.BulkResult{Status: model.BulkFailed, Err: err}
+	case created:
+		return model.BulkResult{URL: url, Status: model.BulkCreated}
+	default:
+		return model.BulkResult{URL: url, Status: model.BulkExisting}
+	}
+}
+
+// shorten crea la URL acortada del usuario en repo o devuelve la que ya tiene
+// para la misma URL original; created indica si se ha creado
+func (s *urlService) shorten(ctx context.Context, repo ports.URLRepository, userID uint, originalURL string) (*model.URL, bool, error) {
+	// Validar que la URL no esté vacía
+	if originalURL == "" {
+		return nil, false, errors.ErrInvalidURL
+	}
+
+	// Verificar si la URL ya existe en la base de datos. Una URL con
+	// contraseña, límite de visitas, programación o caducidad no sirve como URL
+	// pública, ni una cuyo destino dependa de la visita.
+	existingURL, err := repo.GetByOriginalURL(ctx, userID, originalURL)
+	if err == nil && existingURL != nil && !existingURL.HasAccessRules() && !existingURL.Forwards() {
+		return existingURL, false, nil
+	}
+
+	url, err := s.create(ctx, repo, &model.URL{UserID: userID, OriginalURL: originalURL})
+	if err != nil {
+		return nil, false, err
+	}
+	return url, true, nil
+}
+
+// create guarda en repo la URL con un código corto nuevo y el dominio de su
+// URL original
+func (s *urlService) create(ctx context.Context, repo ports.URLRepository, url *model.URL) (*model.URL, error) {
+	url.Domain = model.DomainOf(url.OriginalURL)
+	url.Tags = []model.Tag{}
+	url.DeviceRules = []model.DeviceRule{}
+
+	// Generar un código corto único. Los códigos en uso, incluidos los de la
+	// papelera, se rechazan al guardar y se prueba con otro.
+	for attempt := 1; ; attempt++ {
+		shortCode, err := s.generateShortCode()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errors.ErrGeneratingCode, err)
+		}
+		url.ShortCode = shortCode
+
+		// Guardar la URL en el repositorio
+		err = repo.Create(ctx, url)
+		if errors.Is(err, errors.ErrDuplicateKey) && attempt < maxCodeAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return url, nil
+	}
+}
+
+// UpdateURL modifica las etiquetas, la carpeta, los ajustes de redirección o
+// las reglas por dispositivo de una URL del usuario en una transacción
+func (s *urlService) UpdateURL(ctx context.Context, userID uint, shortCode string, changes model.URLChanges) (*model.URL, error) {
+	if changes.MaxVisits != nil && *changes.MaxVisits < 0 {
+		return nil, errors.ErrInvalidLimit
+	}
+	if changes.RedirectCode != nil && *changes.RedirectCode != 0 && !model.ValidRedirectCode(*changes.RedirectCode) {
+		return nil, errors.ErrInvalidRedirect
+	}
+	if changes.ForwardQuery != nil && !changes.ForwardQuery.Valid() {
+		return nil, errors.ErrInvalidForwarding
+	}
+	if changes.DeviceRules != nil && !model.ValidDeviceRules(*changes.DeviceRules) {
+		return nil, errors.ErrInvalidDeviceRule
+	}
+	var tags []string
+	if changes.Tags != nil {
+		var err error
+		if tags, err = validTags(*changes.Tags); err != nil {
+			return nil, err
+		}
+	}
+
+	var url *model.URL
+	err := s.uow.Do(ctx, func(tx ports.Repos) error {
+		urls := tx.URLs()
+		found, err := urls.GetByShortCode(ctx, shortCode)
+		if err != nil {
+			return err
+		}
+		// Las URLs de otros usuarios se tratan como inexistentes
+		if found.UserID != userID {
+			return errors.ErrURLNotFound
+		}
+
+		if changes.FolderID != nil {
+			found.FolderID = nil
+			if *changes.FolderID != 0 {
+				if _, err := tx.Folders().GetByID(ctx, userID, *changes.FolderID); err != nil {
+					return err
+				}
+				folderID := *changes.FolderID
+				found.FolderID = &folderID
+			}
+		}
+		if changes.MaxVisits != nil {
+			found.MaxVisits = nil
+			if *changes.MaxVisits != 0 {
+				maxVisits := *changes.MaxVisits
+				found.MaxVisits = &maxVisits
+			}
+		}
+		if changes.RedirectCode != nil {
+			found.RedirectCode = *changes.RedirectCode
+		}
+		if changes.ForwardQuery != nil {
+			found.ForwardQuery = *changes.ForwardQuery
+		}
+		if changes.ForwardPath != nil {
+			found.ForwardPath = *changes.ForwardPath
+		}
+		if changes.FolderID != nil || changes.MaxVisits != nil || changes.RedirectCode != nil ||
+			changes.ForwardQuery != nil || changes.ForwardPath != nil {
+			if err := urls.Update(ctx, found); err != nil {
+				return err
+			}
+		}
+
+		if changes.Tags != nil {
+			var removed []string
+			for _, name := range found.TagNames() {
+				if !slices.Contains(tags, name) {
+					removed = append(removed, name)
+				}
+			}
+			if err := urls.RemoveTags(ctx, found, removed); err != nil {
+				return err
+			}
+			if err := urls.AddTags(ctx, found, tags); err != nil {
+				return err
+			}
+		}
+		if changes.DeviceRules != nil {
+			if err := urls.SetDeviceRules(ctx, found, *changes.DeviceRules); err != nil {
+				return err
+			}
+		}
+		url = found
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 	return url, nil
 }
 
@@ -75,35 +348,135 @@ func (s *urlService) GetURL(ctx context.Context, shortCode string) (*model.URL,
 	return url, nil
 }
 
-// RedirectURL recupera la URL original y aumenta el contador de visitas
-func (s *urlService) RedirectURL(ctx context.Context, shortCode string) (string, error) {
+// RedirectURL recupera el destino de una visita y aumenta el contador de
+// visitas. Fuera de su periodo de actividad la URL envía a su URL alternativa
+// sin contar la visita o, si no la tiene, devuelve
+// errors.NotYetActiveError o errors.ErrURLEnded. Las URLs protegidas exigen un
+// pase vigente y sin él no cuentan la visita; las que han agotado sus visitas
+// devuelven errors.ErrURLExhausted.
+func (s *urlService) RedirectURL(ctx context.Context, shortCode string, visit model.Visit) (*model.Redirect, error) {
 	url, err := s.repo.GetByShortCode(ctx, shortCode)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if url == nil {
-		return "", errors.ErrURLNotFound
+		return nil, errors.ErrURLNotFound
+	}
+
+	now := s.now()
+	if state := url.StateAt(now); state != model.StateActive {
+		if url.FallbackURL != "" {
+			// Temporal y sin caché: el destino cambia en cuanto la URL se activa
+			return &model.Redirect{Location: url.FallbackURL, Status: http.StatusFound}, nil
+		}
+		if state == model.StateScheduled {
+			return nil, &errors.NotYetActiveError{ActiveFrom: *url.ActiveFrom}
+		}
+		return nil, errors.ErrURLEnded
+	}
+	if url.Expired(now) {
+		return nil, errors.ErrURLEnded
+	}
+	if url.Exhausted() {
+		return nil, errors.ErrURLExhausted
+	}
+	if url.Protected() && !s.access.verify(url, visit.AccessToken, now) {
+		return nil, errors.ErrPasswordRequired
 	}
 
-	// Incrementar el contador de visitas
-	if err := s.repo.IncrementVisits(ctx, shortCode); err != nil {
+	// Incrementar el contador de visitas. El repositorio decide si queda
+	// alguna visita, por si otras visitas simultáneas han agotado el límite.
+	allowed, err := s.repo.IncrementVisits(ctx, shortCode)
+	switch {
+	case err != nil && url.MaxVisits != nil:
+		// Sin contar la visita no se puede garantizar el límite
+		return nil, err
+	case err != nil:
 		// Simplemente lo registramos pero no fallamos la redirección
 		fmt.Printf("Error incrementando visitas: %v\n", err)
+	case !allowed:
+		return nil, errors.ErrURLExhausted
 	}
 
-	return url.OriginalURL, nil
+	return s.redirectTo(url, visit, now), nil
 }
 
-// ListURLs recupera todas las URLs con paginación
-func (s *urlService) ListURLs(ctx context.Context, limit, offset int) ([]*model.URL, error) {
-	return s.repo.List(ctx, limit, offset)
+// ListURLs recupera una página de URLs filtrada, ordenada, con el total y los
+// cursores de las páginas vecinas
+func (s *urlService) ListURLs(ctx context.Context, query model.URLQuery) (*model.URLPage, error) {
+	query = query.Normalize()
+
+	// Pedir una URL de más para saber si hay otra página en esa dirección
+	fetch := query
+	fetch.Limit++
+	urls, total, err := s.repo.List(ctx, fetch)
+	if err != nil {
+		return nil, err
+	}
+
+	backwards := query.Cursor != nil && query.Cursor.Before
+	more := len(urls) > query.Limit
+	if more {
+		if backwards {
+			urls = urls[len(urls)-query.Limit:]
+		} else {
+			urls = urls[:query.Limit]
+		}
+	}
+
+	page := &model.URLPage{URLs: urls, Total: total}
+	if len(urls) == 0 {
+		return page, nil
+	}
+
+	// Hacia atrás siempre queda al menos la URL del cursor por delante; hacia
+	// delante hay página anterior si se partió de un cursor o de un desplazamiento
+	hasNext := more || backwards
+	hasPrev := (backwards && more) || (!backwards && (query.Cursor != nil || query.Offset > 0))
+	if hasNext {
+		page.Next = model.CursorAt(urls[len(urls)-1], query.Sort, false)
+	}
+	if hasPrev {
+		page.Prev = model.CursorAt(urls[0], query.Sort, true)
+	}
+	return page, nil
 }
 
-// DeleteURL elimina una URL por su código corto
-func (s *urlService) DeleteURL(ctx context.Context, shortCode string) error {
+// DeleteURL mueve a la papelera una URL del usuario
+func (s *urlService) DeleteURL(ctx context.Context, userID uint, shortCode string) error {
+	url, err := s.repo.GetByShortCode(ctx, shortCode)
+	if err != nil {
+		return err
+	}
+	// Las URLs de otros usuarios se tratan como inexistentes
+	if url.UserID != userID {
+		return errors.ErrURLNotFound
+	}
 	return s.repo.Delete(ctx, shortCode)
 }
 
+// RestoreURL saca de la papelera una URL del usuario
+func (s *urlService) RestoreURL(ctx context.Context, userID uint, shortCode string) (*model.URL, error) {
+	url, err := s.repo.GetDeletedByShortCode(ctx, shortCode)
+	if err != nil {
+		return nil, err
+	}
+	if url.UserID != userID {
+		return nil, errors.ErrURLNotFound
+	}
+	if err := s.repo.Restore(ctx, shortCode); err != nil {
+		return nil, err
+	}
+	url.DeletedAt = gorm.DeletedAt{}
+	return url, nil
+}
+
+// PurgeDeletedURLs elimina definitivamente las URLs que entraron en la
+// papelera antes de before
+func (s *urlService) PurgeDeletedURLs(ctx context.Context, before time.Time) (int64, error) {
+	return s.repo.PurgeDeleted(ctx, before)
+}
+
 // generateShortCode genera un código corto único para la URL
 func (s *urlService) generateShortCode() (string, error) {
 	code := make([]byte, codeLength)
@@ -2,49 +2,55 @@ package service
 
 import (
 	"context"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	domainErrors "tiny-url/internal/domain/errors"
 	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports"
 	"tiny-url/internal/domain/ports/mocks"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 func TestShortenURL_Success(t *testing.T) {
 	// Arrange
 	mockRepo := mocks.NewMockURLRepository(t)
-	service := NewURLService(mockRepo)
+	service := NewURLService(mockRepo, nil)
 
 	originalURL := "https://www.example.com/test"
 	ctx := context.Background()
 
 	// Configurar el comportamiento esperado del mock
-	mockRepo.EXPECT().GetByOriginalURL(ctx, originalURL).Return(nil, nil)
+	mockRepo.EXPECT().GetByOriginalURL(ctx, uint(1), originalURL).Return(nil, nil)
 	mockRepo.EXPECT().Create(ctx, mock.AnythingOfType("*model.URL")).Return(nil)
 
 	// Act
-	url, err := service.ShortenURL(ctx, originalURL)
+	url, err := service.ShortenURL(ctx, 1, originalURL, model.URLSettings{})
 
 	// Assert
 	assert.NoError(t, err)
 	assert.NotNil(t, url)
 	assert.Equal(t, originalURL, url.OriginalURL)
 	assert.NotEmpty(t, url.ShortCode)
+	assert.Equal(t, "www.example.com", url.Domain)
 	assert.Equal(t, 0, url.Visits)
 }
 
 func TestShortenURL_EmptyURL(t *testing.T) {
 	// Arrange
 	mockRepo := mocks.NewMockURLRepository(t)
-	service := NewURLService(mockRepo)
+	service := NewURLService(mockRepo, nil)
 
 	originalURL := ""
 	ctx := context.Background()
 
 	// Act
-	url, err := service.ShortenURL(ctx, originalURL)
+	url, err := service.ShortenURL(ctx, 1, originalURL, model.URLSettings{})
 
 	// Assert
 	assert.Error(t, err)
@@ -55,7 +61,7 @@ func TestShortenURL_EmptyURL(t *testing.T) {
 func TestShortenURL_ExistingURL(t *testing.T) {
 	// Arrange
 	mockRepo := mocks.NewMockURLRepository(t)
-	service := NewURLService(mockRepo)
+	service := NewURLService(mockRepo, nil)
 
 	originalURL := "https://www.example.com/test"
 	existingShortCode := "abc123"
@@ -68,10 +74,10 @@ func TestShortenURL_ExistingURL(t *testing.T) {
 	}
 
 	// Configurar el comportamiento esperado del mock
-	mockRepo.EXPECT().GetByOriginalURL(ctx, originalURL).Return(existingURL, nil)
+	mockRepo.EXPECT().GetByOriginalURL(ctx, uint(1), originalURL).Return(existingURL, nil)
 
 	// Act
-	url, err := service.ShortenURL(ctx, originalURL)
+	url, err := service.ShortenURL(ctx, 1, originalURL, model.URLSettings{})
 
 	// Assert
 	assert.NoError(t, err)
@@ -81,7 +87,7 @@ func TestShortenURL_ExistingURL(t *testing.T) {
 func TestGetURL_Success(t *testing.T) {
 	// Arrange
 	mockRepo := mocks.NewMockURLRepository(t)
-	service := NewURLService(mockRepo)
+	service := NewURLService(mockRepo, nil)
 
 	shortCode := "abc123"
 	ctx := context.Background()
@@ -106,7 +112,7 @@ func TestGetURL_Success(t *testing.T) {
 func TestGetURL_NotFound(t *testing.T) {
 	// Arrange
 	mockRepo := mocks.NewMockURLRepository(t)
-	service := NewURLService(mockRepo)
+	service := NewURLService(mockRepo, nil)
 
 	shortCode := "nonexistent"
 	ctx := context.Background()
@@ -126,7 +132,7 @@ func TestGetURL_NotFound(t *testing.T) {
 func TestRedirectURL_Success(t *testing.T) {
 	// Arrange
 	mockRepo := mocks.NewMockURLRepository(t)
-	service := NewURLService(mockRepo)
+	service := NewURLService(mockRepo, nil)
 
 	shortCode := "abc123"
 	originalURL := "https://www.example.com/test"
@@ -141,20 +147,20 @@ func TestRedirectURL_Success(t *testing.T) {
 
 	// Configurar el comportamiento esperado del mock
 	mockRepo.EXPECT().GetByShortCode(ctx, shortCode).Return(urlBeforeRedirect, nil)
-	mockRepo.EXPECT().IncrementVisits(ctx, shortCode).Return(nil)
+	mockRepo.EXPECT().IncrementVisits(ctx, shortCode).Return(true, nil)
 
 	// Act
-	redirectURL, err := service.RedirectURL(ctx, shortCode)
+	redirect, err := service.RedirectURL(ctx, shortCode, model.Visit{})
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Equal(t, originalURL, redirectURL)
+	assert.Equal(t, &model.Redirect{Location: originalURL, Status: http.StatusMovedPermanently, MaxAge: DefaultRedirectMaxAge}, redirect)
 }
 
 func TestRedirectURL_NotFound(t *testing.T) {
 	// Arrange
 	mockRepo := mocks.NewMockURLRepository(t)
-	service := NewURLService(mockRepo)
+	service := NewURLService(mockRepo, nil)
 
 	shortCode := "nonexistent"
 	ctx := context.Background()
@@ -163,21 +169,20 @@ func TestRedirectURL_NotFound(t *testing.T) {
 	mockRepo.EXPECT().GetByShortCode(ctx, shortCode).Return(nil, domainErrors.ErrURLNotFound)
 
 	// Act
-	redirectURL, err := service.RedirectURL(ctx, shortCode)
+	redirect, err := service.RedirectURL(ctx, shortCode, model.Visit{})
 
 	// Assert
 	assert.Error(t, err)
 	assert.True(t, domainErrors.Is(err, domainErrors.ErrURLNotFound))
-	assert.Empty(t, redirectURL)
+	assert.Nil(t, redirect)
 }
 
 func TestListURLs_Success(t *testing.T) {
 	// Arrange
 	mockRepo := mocks.NewMockURLRepository(t)
-	service := NewURLService(mockRepo)
+	service := NewURLService(mockRepo, nil)
 
-	limit := 10
-	offset := 0
+	query := model.URLQuery{Limit: 10, Offset: 0, Filter: model.URLFilter{Domain: "Example.com"}}
 	ctx := context.Background()
 
 	expectedURLs := []*model.URL{
@@ -194,30 +199,95 @@ func TestListURLs_Success(t *testing.T) {
 	}
 
 	// Configurar el comportamiento esperado del mock
-	mockRepo.EXPECT().List(ctx, limit, offset).Return(expectedURLs, nil)
+	fetch := query.Normalize()
+	fetch.Limit++
+	mockRepo.EXPECT().List(ctx, fetch).Return(expectedURLs, 2, nil)
 
 	// Act
-	urls, err := service.ListURLs(ctx, limit, offset)
+	page, err := service.ListURLs(ctx, query)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, expectedURLs, page.URLs)
+	assert.Equal(t, int64(2), page.Total)
+	assert.Nil(t, page.Next)
+	assert.Nil(t, page.Prev)
+}
+
+func TestListURLs_ForwardCursors(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, nil)
+	ctx := context.Background()
+	urls := []*model.URL{{ID: 9}, {ID: 8}, {ID: 7}}
+	cursor := &model.URLCursor{Sort: model.SortCreatedAtDesc, ID: 10}
+
+	mockRepo.EXPECT().List(ctx, mock.MatchedBy(func(query model.URLQuery) bool {
+		return query.Limit == 3 && query.Cursor == cursor
+	})).Return(urls, 20, nil)
+
+	// Act
+	page, err := service.ListURLs(ctx, model.URLQuery{Limit: 2, Cursor: cursor})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, urls[:2], page.URLs)
+	assert.Equal(t, &model.URLCursor{Sort: model.SortCreatedAtDesc, ID: 8}, page.Next)
+	assert.Equal(t, &model.URLCursor{Sort: model.SortCreatedAtDesc, ID: 9, Before: true}, page.Prev)
+}
+
+func TestListURLs_BackwardCursors(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, nil)
+	ctx := context.Background()
+
+	// Primera página alcanzada hacia atrás: no hay URL de más antes de ella
+	urls := []*model.URL{{ID: 12}, {ID: 11}}
+	cursor := &model.URLCursor{Sort: model.SortCreatedAtDesc, ID: 10, Before: true}
+	mockRepo.EXPECT().List(ctx, mock.AnythingOfType("model.URLQuery")).Return(urls, 20, nil)
+
+	// Act
+	page, err := service.ListURLs(ctx, model.URLQuery{Limit: 2, Cursor: cursor})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, urls, page.URLs)
+	assert.Equal(t, &model.URLCursor{Sort: model.SortCreatedAtDesc, ID: 11}, page.Next)
+	assert.Nil(t, page.Prev)
+}
+
+func TestListURLs_ClampsLimit(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, nil)
+	ctx := context.Background()
+
+	mockRepo.EXPECT().List(ctx, mock.MatchedBy(func(query model.URLQuery) bool {
+		return query.Limit == model.MaxListLimit+1 && query.Offset == 0 && query.Sort == model.DefaultURLSort
+	})).Return([]*model.URL{}, 0, nil)
+
+	// Act
+	_, err := service.ListURLs(ctx, model.URLQuery{Limit: 100000, Offset: -5})
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Equal(t, expectedURLs, urls)
-	assert.Len(t, urls, 2)
 }
 
 func TestDeleteURL_Success(t *testing.T) {
 	// Arrange
 	mockRepo := mocks.NewMockURLRepository(t)
-	service := NewURLService(mockRepo)
+	service := NewURLService(mockRepo, nil)
 
 	shortCode := "abc123"
 	ctx := context.Background()
 
 	// Configurar el comportamiento esperado del mock
+	mockRepo.EXPECT().GetByShortCode(ctx, shortCode).Return(&model.URL{ID: 1, UserID: 7, ShortCode: shortCode}, nil)
 	mockRepo.EXPECT().Delete(ctx, shortCode).Return(nil)
 
 	// Act
-	err := service.DeleteURL(ctx, shortCode)
+	err := service.DeleteURL(ctx, 7, shortCode)
 
 	// Assert
 	assert.NoError(t, err)
@@ -226,18 +296,578 @@ func TestDeleteURL_Success(t *testing.T) {
 func TestDeleteURL_NotFound(t *testing.T) {
 	// Arrange
 	mockRepo := mocks.NewMockURLRepository(t)
-	service := NewURLService(mockRepo)
+	service := NewURLService(mockRepo, nil)
 
 	shortCode := "nonexistent"
 	ctx := context.Background()
 
 	// Configurar el comportamiento esperado del mock
-	mockRepo.EXPECT().Delete(ctx, shortCode).Return(domainErrors.ErrURLNotFound)
+	mockRepo.EXPECT().GetByShortCode(ctx, shortCode).Return(nil, domainErrors.ErrURLNotFound)
 
 	// Act
-	err := service.DeleteURL(ctx, shortCode)
+	err := service.DeleteURL(ctx, 7, shortCode)
 
 	// Assert
 	assert.Error(t, err)
 	assert.True(t, domainErrors.Is(err, domainErrors.ErrURLNotFound))
 }
+
+func TestDeleteURL_OtherOwner(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, nil)
+
+	shortCode := "abc123"
+	ctx := context.Background()
+
+	// Delete no debe llamarse: la URL pertenece a otro usuario
+	mockRepo.EXPECT().GetByShortCode(ctx, shortCode).Return(&model.URL{ID: 1, UserID: 8, ShortCode: shortCode}, nil)
+
+	// Act
+	err := service.DeleteURL(ctx, 7, shortCode)
+
+	// Assert
+	assert.True(t, domainErrors.Is(err, domainErrors.ErrURLNotFound))
+}
+
+func TestRestoreURL_Success(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, nil)
+
+	shortCode := "abc123"
+	ctx := context.Background()
+	deleted := &model.URL{ID: 1, UserID: 7, ShortCode: shortCode, DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}}
+
+	mockRepo.EXPECT().GetDeletedByShortCode(ctx, shortCode).Return(deleted, nil)
+	mockRepo.EXPECT().Restore(ctx, shortCode).Return(nil)
+
+	// Act
+	url, err := service.RestoreURL(ctx, 7, shortCode)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, shortCode, url.ShortCode)
+	assert.False(t, url.DeletedAt.Valid)
+}
+
+func TestRestoreURL_OtherOwner(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, nil)
+
+	shortCode := "abc123"
+	ctx := context.Background()
+	deleted := &model.URL{ID: 1, UserID: 8, ShortCode: shortCode, DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}}
+
+	// Restore no debe llamarse: la URL pertenece a otro usuario
+	mockRepo.EXPECT().GetDeletedByShortCode(ctx, shortCode).Return(deleted, nil)
+
+	// Act
+	url, err := service.RestoreURL(ctx, 7, shortCode)
+
+	// Assert
+	assert.Nil(t, url)
+	assert.True(t, domainErrors.Is(err, domainErrors.ErrURLNotFound))
+}
+
+func TestShortenURL_RetriesReservedCode(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, nil)
+
+	originalURL := "https://example.com"
+	ctx := context.Background()
+
+	// El primer código generado está reservado por una URL de la papelera
+	mockRepo.EXPECT().GetByOriginalURL(ctx, uint(7), originalURL).Return(nil, domainErrors.ErrURLNotFound)
+	mockRepo.EXPECT().Create(ctx, mock.AnythingOfType("*model.URL")).Return(domainErrors.ErrDuplicateKey).Once()
+	mockRepo.EXPECT().Create(ctx, mock.AnythingOfType("*model.URL")).Return(nil).Once()
+
+	// Act
+	url, err := service.ShortenURL(ctx, 7, originalURL, model.URLSettings{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, originalURL, url.OriginalURL)
+}
+
+func TestShortenURL_GivesUpAfterMaxAttempts(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, nil)
+
+	originalURL := "https://example.com"
+	ctx := context.Background()
+
+	mockRepo.EXPECT().GetByOriginalURL(ctx, uint(7), originalURL).Return(nil, domainErrors.ErrURLNotFound)
+	mockRepo.EXPECT().Create(ctx, mock.AnythingOfType("*model.URL")).Return(domainErrors.ErrDuplicateKey).Times(maxCodeAttempts)
+
+	// Act
+	url, err := service.ShortenURL(ctx, 7, originalURL, model.URLSettings{})
+
+	// Assert
+	assert.Nil(t, url)
+	assert.True(t, domainErrors.Is(err, domainErrors.ErrDuplicateKey))
+}
+
+// newURLUnitOfWorkMock devuelve una unidad de trabajo simulada que ejecuta la
+// función recibida con el repositorio de URLs indicado
+func newURLUnitOfWorkMock(t *testing.T, urlRepo ports.URLRepository) *mocks.MockUnitOfWork {
+	repos := mocks.NewMockRepos(t)
+	repos.EXPECT().URLs().Return(urlRepo).Maybe()
+
+	uow := mocks.NewMockUnitOfWork(t)
+	uow.EXPECT().Do(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, fn func(tx ports.Repos) error) error {
+		return fn(repos)
+	}).Maybe()
+
+	return uow
+}
+
+func TestBulkShortenURLs_BestEffort(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, nil)
+	ctx := context.Background()
+
+	existing := &model.URL{ID: 1, OriginalURL: "https://www.example.com/old", ShortCode: "old123"}
+	mockRepo.EXPECT().GetByOriginalURL(ctx, uint(1), "https://www.example.com/new").Return(nil, domainErrors.ErrURLNotFound)
+	mockRepo.EXPECT().GetByOriginalURL(ctx, uint(1), "https://www.example.com/old").Return(existing, nil)
+	mockRepo.EXPECT().Create(ctx, mock.AnythingOfType("*model.URL")).Return(nil)
+
+	// Act
+	results, err := service.BulkShortenURLs(ctx, 1, []string{"https://www.example.com/new", "", "https://www.example.com/old"}, false)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, results, 3)
+	assert.Equal(t, model.BulkCreated, results[0].Status)
+	assert.Equal(t, "www.example.com", results[0].URL.Domain)
+	assert.Equal(t, model.BulkFailed, results[1].Status)
+	assert.True(t, domainErrors.Is(results[1].Err, domainErrors.ErrInvalidURL))
+	assert.Equal(t, model.BulkExisting, results[2].Status)
+	assert.Equal(t, existing, results[2].URL)
+}
+
+func TestBulkShortenURLs_AtomicRollsBack(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, newURLUnitOfWorkMock(t, mockRepo))
+	ctx := context.Background()
+
+	mockRepo.EXPECT().GetByOriginalURL(ctx, uint(1), "https://www.example.com/new").Return(nil, domainErrors.ErrURLNotFound)
+	mockRepo.EXPECT().Create(ctx, mock.AnythingOfType("*model.URL")).Return(nil)
+
+	// Act
+	results, err := service.BulkShortenURLs(ctx, 1, []string{"https://www.example.com/new", "", "https://www.example.com/other"}, true)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, results, 3)
+	assert.Equal(t, model.BulkSkipped, results[0].Status)
+	assert.Nil(t, results[0].URL)
+	assert.Equal(t, model.BulkFailed, results[1].Status)
+	assert.Equal(t, model.BulkSkipped, results[2].Status)
+}
+
+func TestBulkShortenURLs_AtomicCommits(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, newURLUnitOfWorkMock(t, mockRepo))
+	ctx := context.Background()
+
+	mockRepo.EXPECT().GetByOriginalURL(ctx, uint(1), mock.Anything).Return(nil, domainErrors.ErrURLNotFound).Times(2)
+	mockRepo.EXPECT().Create(ctx, mock.AnythingOfType("*model.URL")).Return(nil).Times(2)
+
+	// Act
+	results, err := service.BulkShortenURLs(ctx, 1, []string{"https://www.example.com/a", "https://www.example.com/b"}, true)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, model.BulkCreated, results[0].Status)
+	assert.Equal(t, model.BulkCreated, results[1].Status)
+	assert.NotEqual(t, results[0].URL.ShortCode, results[1].URL.ShortCode)
+}
+
+// newFolderUnitOfWorkMock devuelve una unidad de trabajo simulada que ejecuta
+// la función recibida con los repositorios de URLs y carpetas indicados
+func newFolderUnitOfWorkMock(t *testing.T, urlRepo ports.URLRepository, folderRepo ports.FolderRepository) *mocks.MockUnitOfWork {
+	repos := mocks.NewMockRepos(t)
+	repos.EXPECT().URLs().Return(urlRepo).Maybe()
+	repos.EXPECT().Folders().Return(folderRepo).Maybe()
+
+	uow := mocks.NewMockUnitOfWork(t)
+	uow.EXPECT().Do(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, fn func(tx ports.Repos) error) error {
+		return fn(repos)
+	}).Maybe()
+
+	return uow
+}
+
+func TestShortenURL_WithSettingsCreatesNewURL(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	folderRepo := mocks.NewMockFolderRepository(t)
+	service := NewURLService(mockRepo, newFolderUnitOfWorkMock(t, mockRepo, folderRepo))
+	ctx := context.Background()
+	folderID := uint(3)
+
+	// No se busca una URL existente: con ajustes siempre se crea una nueva
+	folderRepo.EXPECT().GetByID(ctx, uint(7), folderID).Return(&model.Folder{ID: folderID, UserID: 7}, nil)
+	mockRepo.EXPECT().Create(ctx, mock.MatchedBy(func(url *model.URL) bool {
+		return url.UserID == 7 && url.FolderID != nil && *url.FolderID == folderID
+	})).Return(nil)
+	mockRepo.EXPECT().AddTags(ctx, mock.AnythingOfType("*model.URL"), []string{"verano", "viajes"}).Return(nil)
+
+	// Act
+	url, err := service.ShortenURL(ctx, 7, "https://example.com", model.URLSettings{
+		Tags:     []string{" verano ", "viajes", "verano"},
+		FolderID: &folderID,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com", url.Domain)
+}
+
+func TestShortenURL_UnknownFolder(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	folderRepo := mocks.NewMockFolderRepository(t)
+	service := NewURLService(mockRepo, newFolderUnitOfWorkMock(t, mockRepo, folderRepo))
+	ctx := context.Background()
+	folderID := uint(3)
+
+	// Las carpetas de otros usuarios no se encuentran
+	folderRepo.EXPECT().GetByID(ctx, uint(7), folderID).Return(nil, domainErrors.ErrFolderNotFound)
+
+	// Act
+	url, err := service.ShortenURL(ctx, 7, "https://example.com", model.URLSettings{FolderID: &folderID})
+
+	// Assert
+	assert.Nil(t, url)
+	assert.Equal(t, domainErrors.ErrFolderNotFound, err)
+}
+
+func TestShortenURL_InvalidTag(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, nil)
+	tooLong := strings.Repeat("a", model.MaxTagLength)
+
+	// Act
+	url, err := service.ShortenURL(context.Background(), 7, "https://example.com", model.URLSettings{Tags: []string{"a" + tooLong}})
+
+	// Assert
+	assert.Nil(t, url)
+	assert.Equal(t, domainErrors.ErrInvalidTag, err)
+}
+
+func TestUpdateURL_ReplacesTagsAndLeavesFolder(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	folderRepo := mocks.NewMockFolderRepository(t)
+	service := NewURLService(mockRepo, newFolderUnitOfWorkMock(t, mockRepo, folderRepo))
+	ctx := context.Background()
+	folderID := uint(3)
+	existing := &model.URL{ID: 1, UserID: 7, ShortCode: "abc123", FolderID: &folderID,
+		Tags: []model.Tag{{Name: "otoño"}, {Name: "verano"}}}
+	tags := []string{"verano", "viajes"}
+	noFolder := uint(0)
+
+	mockRepo.EXPECT().GetByShortCode(ctx, "abc123").Return(existing, nil)
+	mockRepo.EXPECT().Update(ctx, mock.MatchedBy(func(url *model.URL) bool { return url.FolderID == nil })).Return(nil)
+	mockRepo.EXPECT().RemoveTags(ctx, existing, []string{"otoño"}).Return(nil)
+	mockRepo.EXPECT().AddTags(ctx, existing, tags).Return(nil)
+
+	// Act
+	url, err := service.UpdateURL(ctx, 7, "abc123", model.URLChanges{Tags: &tags, FolderID: &noFolder})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, url.FolderID)
+}
+
+func TestUpdateURL_OtherOwner(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, newURLUnitOfWorkMock(t, mockRepo))
+	ctx := context.Background()
+	tags := []string{"verano"}
+
+	// Nada debe modificarse: la URL pertenece a otro usuario
+	mockRepo.EXPECT().GetByShortCode(ctx, "abc123").Return(&model.URL{ID: 1, UserID: 8, ShortCode: "abc123"}, nil)
+
+	// Act
+	url, err := service.UpdateURL(ctx, 7, "abc123", model.URLChanges{Tags: &tags})
+
+	// Assert
+	assert.Nil(t, url)
+	assert.Equal(t, domainErrors.ErrURLNotFound, err)
+}
+
+func TestRedirectURL_ExhaustedURL(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, nil)
+	ctx := context.Background()
+	maxVisits := 1
+
+	// No se intenta contar la visita de una URL ya agotada
+	mockRepo.EXPECT().GetByShortCode(ctx, "abc123").Return(&model.URL{
+		ShortCode: "abc123", OriginalURL: "https://example.com", Visits: 1, MaxVisits: &maxVisits,
+	}, nil)
+
+	// Act
+	redirect, err := service.RedirectURL(ctx, "abc123", model.Visit{})
+
+	// Assert
+	assert.ErrorIs(t, err, domainErrors.ErrURLExhausted)
+	assert.Nil(t, redirect)
+}
+
+func TestRedirectURL_LastVisitTakenConcurrently(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, nil)
+	ctx := context.Background()
+	maxVisits := 1
+
+	// Otra visita se lleva la última entre la lectura y el incremento
+	mockRepo.EXPECT().GetByShortCode(ctx, "abc123").Return(&model.URL{
+		ShortCode: "abc123", OriginalURL: "https://example.com", MaxVisits: &maxVisits,
+	}, nil)
+	mockRepo.EXPECT().IncrementVisits(ctx, "abc123").Return(false, nil)
+
+	// Act
+	redirect, err := service.RedirectURL(ctx, "abc123", model.Visit{})
+
+	// Assert
+	assert.ErrorIs(t, err, domainErrors.ErrURLExhausted)
+	assert.Nil(t, redirect)
+}
+
+func TestShortenURL_InvalidMaxVisits(t *testing.T) {
+	// Arrange
+	service := NewURLService(mocks.NewMockURLRepository(t), nil)
+	maxVisits := 0
+
+	// Act
+	url, err := service.ShortenURL(context.Background(), 7, "https://example.com", model.URLSettings{MaxVisits: &maxVisits})
+
+	// Assert
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidLimit)
+	assert.Nil(t, url)
+}
+
+func TestShortenURL_DoesNotReuseLimitedURL(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, nil)
+	ctx := context.Background()
+	maxVisits := 1
+
+	// La URL existente es de un solo uso: se crea otra pública
+	mockRepo.EXPECT().GetByOriginalURL(ctx, uint(7), "https://example.com").Return(&model.URL{
+		ShortCode: "abc123", OriginalURL: "https://example.com", MaxVisits: &maxVisits,
+	}, nil)
+	mockRepo.EXPECT().Create(ctx, mock.AnythingOfType("*model.URL")).Return(nil)
+
+	// Act
+	url, err := service.ShortenURL(ctx, 7, "https://example.com", model.URLSettings{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEqual(t, "abc123", url.ShortCode)
+	assert.Nil(t, url.MaxVisits)
+}
+
+func TestShortenURL_DoesNotReuseExpiredURL(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, nil)
+	ctx := context.Background()
+	expiredAt := time.Now().Add(-time.Hour)
+
+	// La URL existente ya ha caducado: se crea otra que sí redirige
+	mockRepo.EXPECT().GetByOriginalURL(ctx, uint(7), "https://example.com").Return(&model.URL{
+		ShortCode: "abc123", OriginalURL: "https://example.com", ExpiresAt: &expiredAt,
+	}, nil)
+	mockRepo.EXPECT().Create(ctx, mock.AnythingOfType("*model.URL")).Return(nil)
+
+	// Act
+	url, err := service.ShortenURL(ctx, 7, "https://example.com", model.URLSettings{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEqual(t, "abc123", url.ShortCode)
+	assert.Nil(t, url.ExpiresAt)
+	assert.False(t, url.Expired(time.Now()))
+}
+
+func TestUpdateURL_RemovesMaxVisits(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, newURLUnitOfWorkMock(t, mockRepo))
+	ctx := context.Background()
+	maxVisits, noLimit := 5, 0
+
+	mockRepo.EXPECT().GetByShortCode(ctx, "abc123").Return(&model.URL{ID: 1, UserID: 7, ShortCode: "abc123", MaxVisits: &maxVisits}, nil)
+	mockRepo.EXPECT().Update(ctx, mock.MatchedBy(func(url *model.URL) bool { return url.MaxVisits == nil })).Return(nil)
+
+	// Act
+	url, err := service.UpdateURL(ctx, 7, "abc123", model.URLChanges{MaxVisits: &noLimit})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, url.MaxVisits)
+}
+
+func TestRedirectURL_Schedule(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	launch, end := now.Add(time.Hour), now.Add(-time.Hour)
+
+	tests := map[string]struct {
+		url      model.URL
+		expected *model.Redirect
+		err      error
+	}{
+		"scheduled without fallback": {
+			url: model.URL{ActiveFrom: &launch},
+			err: &domainErrors.NotYetActiveError{ActiveFrom: launch},
+		},
+		"scheduled with fallback": {
+			url:      model.URL{ActiveFrom: &launch, FallbackURL: "https://example.com/soon"},
+			expected: &model.Redirect{Location: "https://example.com/soon", Status: http.StatusFound},
+		},
+		"ended without fallback": {
+			url: model.URL{ActiveUntil: &end},
+			err: domainErrors.ErrURLEnded,
+		},
+		"ended with fallback": {
+			url:      model.URL{ActiveUntil: &end, FallbackURL: "https://example.com/over"},
+			expected: &model.Redirect{Location: "https://example.com/over", Status: http.StatusFound},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			mockRepo := mocks.NewMockURLRepository(t)
+			service := NewURLService(mockRepo, nil).(*urlService)
+			service.now = func() time.Time { return now }
+			ctx := context.Background()
+			url := tt.url
+			url.ShortCode, url.OriginalURL = "abc123", "https://example.com"
+
+			// Fuera del periodo de actividad no se cuenta la visita
+			mockRepo.EXPECT().GetByShortCode(ctx, "abc123").Return(&url, nil)
+
+			// Act
+			redirect, err := service.RedirectURL(ctx, "abc123", model.Visit{})
+
+			// Assert
+			assert.Equal(t, tt.expected, redirect)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestRedirectURL_NotYetActiveMatchesSentinel(t *testing.T) {
+	// Arrange
+	err := error(&domainErrors.NotYetActiveError{ActiveFrom: time.Now()})
+
+	// Act
+	matches := domainErrors.Is(err, domainErrors.ErrURLNotYetActive)
+
+	// Assert
+	assert.True(t, matches)
+}
+
+func TestShortenURL_InvalidSchedule(t *testing.T) {
+	// Arrange
+	service := NewURLService(mocks.NewMockURLRepository(t), nil)
+	from := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	url, err := service.ShortenURL(context.Background(), 7, "https://example.com", model.URLSettings{
+		ActiveFrom:  &from,
+		ActiveUntil: &from,
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidSchedule)
+	assert.Nil(t, url)
+}
+
+func TestShortenURL_InvalidTemplate(t *testing.T) {
+	templates := map[string]string{
+		"placeholder in the host": "https://{query.host}/docs",
+		"unknown placeholder":     "https://docs.example.com/{user}",
+		"unclosed placeholder":    "https://docs.example.com/{path",
+		"credential header":       "https://docs.example.com/?t={header.authorization}",
+		"not an absolute url":     "{path}",
+	}
+
+	for name, template := range templates {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			service := NewURLService(mocks.NewMockURLRepository(t), nil)
+
+			// Act
+			url, err := service.ShortenURL(context.Background(), 7, template, model.URLSettings{Template: true})
+
+			// Assert
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidTemplate)
+			assert.Nil(t, url)
+		})
+	}
+}
+
+func TestShortenURL_InvalidDeviceRules(t *testing.T) {
+	rules := map[string][]model.DeviceRule{
+		"unknown platform": {{Platform: "tv", Destination: "https://example.com/tv"}},
+		"repeated platform": {
+			{Platform: model.PlatformIOS, Destination: "https://apps.apple.com/app/id1"},
+			{Platform: model.PlatformIOS, Destination: "miapp://inicio"},
+		},
+		"relative destination": {{Platform: model.PlatformAndroid, Destination: "/app"}},
+		"script destination":   {{Platform: model.PlatformDesktop, Destination: "javascript:alert(1)"}},
+	}
+
+	for name, deviceRules := range rules {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			service := NewURLService(mocks.NewMockURLRepository(t), nil)
+
+			// Act
+			url, err := service.ShortenURL(context.Background(), 7, "https://example.com", model.URLSettings{DeviceRules: deviceRules})
+
+			// Assert
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidDeviceRule)
+			assert.Nil(t, url)
+		})
+	}
+}
+
+func TestRedirectURL_Expired(t *testing.T) {
+	// Arrange
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(-time.Minute)
+	mockRepo := mocks.NewMockURLRepository(t)
+	service := NewURLService(mockRepo, nil).(*urlService)
+	service.now = func() time.Time { return now }
+	ctx := context.Background()
+	url := &model.URL{ShortCode: "abc123", OriginalURL: "https://example.com", ExpiresAt: &expiresAt}
+
+	// Una URL caducada no cuenta la visita
+	mockRepo.EXPECT().GetByShortCode(ctx, "abc123").Return(url, nil)
+
+	// Act
+	redirect, err := service.RedirectURL(ctx, "abc123", model.Visit{})
+
+	// Assert
+	assert.Nil(t, redirect)
+	assert.Equal(t, domainErrors.ErrURLEnded, err)
+}
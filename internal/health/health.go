@@ -0,0 +1,143 @@
+// Package health define un registro de comprobaciones de salud al que cada
+// componente de la aplicación puede añadir las suyas.
+package health
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Estados posibles de una comprobación o del informe completo
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// HealthChecker comprueba si un componente está listo para atender peticiones
+type HealthChecker interface {
+	// Check devuelve un error si el componente no está disponible
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc adapta una función al interfaz HealthChecker
+type CheckerFunc func(ctx context.Context) error
+
+// Check implementa HealthChecker
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Result es el resultado de una comprobación individual
+type Result struct {
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+// Report agrupa el resultado de todas las comprobaciones registradas
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// Healthy indica si todas las comprobaciones han sido satisfactorias
+func (r Report) Healthy() bool {
+	return r.Status == StatusUp
+}
+
+// Registry guarda las comprobaciones de salud registradas por nombre
+type Registry struct {
+	mu       sync.RWMutex
+	checkers map[string]HealthChecker
+	timeout  time.Duration
+}
+
+// NewRegistry crea un registro vacío. Cada comprobación dispone como máximo
+// de timeout para completarse.
+func NewRegistry(timeout time.Duration) *Registry {
+	return &Registry{
+		checkers: make(map[string]HealthChecker),
+		timeout:  timeout,
+	}
+}
+
+// Register añade una comprobación. Registrar un nombre existente lo reemplaza.
+func (r *Registry) Register(name string, checker HealthChecker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checkers[name] = checker
+}
+
+// Names devuelve los nombres de las comprobaciones registradas ordenados
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.checkers))
+	for name := range r.checkers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Run ejecuta todas las comprobaciones en paralelo y devuelve el informe.
+// Un pánico dentro de una comprobación se informa como fallo.
+func (r *Registry) Run(ctx context.Context) Report {
+	r.mu.RLock()
+	checkers := make(map[string]HealthChecker, len(r.checkers))
+	for name, checker := range r.checkers {
+		checkers[name] = checker
+	}
+	r.mu.RUnlock()
+
+	report := Report{
+		Status: StatusUp,
+		Checks: make(map[string]Result, len(checkers)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, checker := range checkers {
+		wg.Add(1)
+		go func(name string, checker HealthChecker) {
+			defer wg.Done()
+			result := r.runOne(ctx, checker)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = result
+			if result.Status != StatusUp {
+				report.Status = StatusDown
+			}
+		}(name, checker)
+	}
+	wg.Wait()
+
+	return report
+}
+
+// runOne ejecuta una comprobación con el límite de tiempo del registro
+func (r *Registry) runOne(ctx context.Context, checker HealthChecker) (result Result) {
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			result = Result{Status: StatusDown, Error: fmt.Sprintf("panic: %v", recovered)}
+		}
+		result.Duration = time.Since(start).String()
+	}()
+
+	if err := checker.Check(ctx); err != nil {
+		return Result{Status: StatusDown, Error: err.Error()}
+	}
+	return Result{Status: StatusUp}
+}
@@ -0,0 +1,100 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_AllUp(t *testing.T) {
+	// Arrange
+	registry := NewRegistry(time.Second)
+	registry.Register("a", CheckerFunc(func(ctx context.Context) error { return nil }))
+	registry.Register("b", CheckerFunc(func(ctx context.Context) error { return nil }))
+
+	// Act
+	report := registry.Run(context.Background())
+
+	// Assert
+	assert.True(t, report.Healthy())
+	assert.Len(t, report.Checks, 2)
+	assert.Equal(t, StatusUp, report.Checks["a"].Status)
+}
+
+func TestRegistry_OneDown(t *testing.T) {
+	// Arrange
+	registry := NewRegistry(time.Second)
+	registry.Register("ok", CheckerFunc(func(ctx context.Context) error { return nil }))
+	registry.Register("db", CheckerFunc(func(ctx context.Context) error { return errors.New("connection refused") }))
+
+	// Act
+	report := registry.Run(context.Background())
+
+	// Assert
+	assert.False(t, report.Healthy())
+	assert.Equal(t, StatusDown, report.Checks["db"].Status)
+	assert.Equal(t, "connection refused", report.Checks["db"].Error)
+	assert.Equal(t, StatusUp, report.Checks["ok"].Status)
+}
+
+func TestRegistry_Timeout(t *testing.T) {
+	// Arrange
+	registry := NewRegistry(10 * time.Millisecond)
+	registry.Register("slow", CheckerFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+
+	// Act
+	report := registry.Run(context.Background())
+
+	// Assert
+	assert.False(t, report.Healthy())
+	assert.Equal(t, StatusDown, report.Checks["slow"].Status)
+}
+
+func TestRegistry_PanicIsReportedAsDown(t *testing.T) {
+	// Arrange
+	registry := NewRegistry(time.Second)
+	registry.Register("boom", CheckerFunc(func(ctx context.Context) error { panic("boom") }))
+
+	// Act
+	report := registry.Run(context.Background())
+
+	// Assert
+	assert.False(t, report.Healthy())
+	assert.Contains(t, report.Checks["boom"].Error, "boom")
+}
+
+func TestHeartbeat_Lifecycle(t *testing.T) {
+	// Arrange
+	heartbeat := NewHeartbeat(time.Minute)
+	ctx := context.Background()
+
+	// Assert
+	assert.Error(t, heartbeat.Check(ctx), "un worker no arrancado no está listo")
+
+	heartbeat.Start()
+	assert.NoError(t, heartbeat.Check(ctx))
+
+	heartbeat.Beat()
+	assert.NoError(t, heartbeat.Check(ctx))
+
+	heartbeat.Stop()
+	assert.Error(t, heartbeat.Check(ctx))
+}
+
+func TestHeartbeat_Silence(t *testing.T) {
+	// Arrange
+	heartbeat := NewHeartbeat(time.Minute)
+	heartbeat.Start()
+
+	// Act
+	heartbeat.lastBeat = time.Now().Add(-2 * time.Minute)
+
+	// Assert
+	assert.Error(t, heartbeat.Check(context.Background()))
+}
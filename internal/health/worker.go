@@ -0,0 +1,62 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Heartbeat permite a un proceso en segundo plano informar de que sigue vivo.
+// Implementa HealthChecker: falla si el proceso no ha arrancado, se ha
+// detenido o lleva más de maxSilence sin latir.
+type Heartbeat struct {
+	mu         sync.Mutex
+	maxSilence time.Duration
+	running    bool
+	lastBeat   time.Time
+}
+
+// NewHeartbeat crea un latido que se considera caído tras maxSilence sin noticias
+func NewHeartbeat(maxSilence time.Duration) *Heartbeat {
+	return &Heartbeat{maxSilence: maxSilence}
+}
+
+// Start marca el proceso como en ejecución
+func (h *Heartbeat) Start() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.running = true
+	h.lastBeat = time.Now()
+}
+
+// Beat registra que el proceso ha completado una iteración, haya ido bien o
+// mal. Los fallos de cada iteración los registra el propio proceso: la
+// comprobación solo indica si sigue vivo, para que un error pasajero no
+// retire la réplica del balanceador.
+func (h *Heartbeat) Beat() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lastBeat = time.Now()
+}
+
+// Stop marca el proceso como detenido
+func (h *Heartbeat) Stop() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.running = false
+}
+
+// Check implementa HealthChecker
+func (h *Heartbeat) Check(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.running {
+		return fmt.Errorf("worker not running")
+	}
+	if h.maxSilence > 0 && time.Since(h.lastBeat) > h.maxSilence {
+		return fmt.Errorf("no heartbeat since %s", h.lastBeat.Format(time.RFC3339))
+	}
+	return nil
+}
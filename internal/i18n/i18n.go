@@ -0,0 +1,84 @@
+// Package i18n contiene el catálogo de mensajes de la API y la negociación
+// del idioma de cada petición.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"golang.org/x/text/language"
+)
+
+// Idiomas soportados por la API
+const (
+	Spanish = "es"
+	English = "en"
+
+	// DefaultLanguage se usa cuando el cliente no indica un idioma soportado
+	DefaultLanguage = Spanish
+)
+
+// Languages enumera los idiomas soportados, empezando por el predeterminado
+var Languages = []string{Spanish, English}
+
+//go:embed locales/*.json
+var locales embed.FS
+
+// catalog contiene los mensajes de cada idioma indexados por clave
+var catalog = mustLoad()
+
+// matcher elige el idioma soportado más cercano a las preferencias del cliente
+var matcher = language.NewMatcher([]language.Tag{language.Spanish, language.English})
+
+// mustLoad lee los ficheros de mensajes embebidos. Un fichero inválido es un
+// error de programación, así que detiene el arranque.
+func mustLoad() map[string]map[string]string {
+	catalog := make(map[string]map[string]string, len(Languages))
+	for _, lang := range Languages {
+		content, err := locales.ReadFile(path.Join("locales", lang+".json"))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: missing bundle for %q: %v", lang, err))
+		}
+		messages := make(map[string]string)
+		if err := json.Unmarshal(content, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: invalid bundle for %q: %v", lang, err))
+		}
+		catalog[lang] = messages
+	}
+	return catalog
+}
+
+// Supported indica si lang es uno de los idiomas soportados
+func Supported(lang string) bool {
+	_, ok := catalog[lang]
+	return ok
+}
+
+// Negotiate elige el idioma soportado que mejor encaja con una cabecera
+// Accept-Language. Devuelve DefaultLanguage si ninguno encaja.
+func Negotiate(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return DefaultLanguage
+	}
+
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return DefaultLanguage
+	}
+	return Languages[index]
+}
+
+// T devuelve el mensaje de key en lang. Si falta, usa el idioma
+// predeterminado y, en último caso, la propia clave.
+func T(lang, key string) string {
+	if message, ok := catalog[lang][key]; ok {
+		return message
+	}
+	if message, ok := catalog[DefaultLanguage][key]; ok {
+		return message
+	}
+	return key
+}
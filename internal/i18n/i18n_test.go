@@ -0,0 +1,44 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", DefaultLanguage},
+		{"en", English},
+		{"en-US,en;q=0.9", English},
+		{"es-MX", Spanish},
+		{"fr-FR,en;q=0.5", English},
+		{"de", DefaultLanguage},
+		{"en;q=0.2,es;q=0.8", Spanish},
+		{"no es una cabecera válida;;;", DefaultLanguage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.want, Negotiate(tt.header))
+		})
+	}
+}
+
+func TestT_Fallbacks(t *testing.T) {
+	assert.Equal(t, "URL not found", T(English, "url_not_found"))
+	assert.Equal(t, "URL no encontrada", T("fr", "url_not_found"))
+	assert.Equal(t, "clave.desconocida", T(English, "clave.desconocida"))
+}
+
+func TestBundles_HaveSameKeys(t *testing.T) {
+	for _, lang := range Languages {
+		for key := range catalog[DefaultLanguage] {
+			assert.Contains(t, catalog[lang], key, "falta %q en %q", key, lang)
+		}
+		assert.Len(t, catalog[lang], len(catalog[DefaultLanguage]), "el idioma %q tiene claves de más", lang)
+	}
+}
@@ -0,0 +1,35 @@
+package server
+
+import (
+	"github.com/gin-gonic/gin"
+
+	_ "tiny-url/docs/v1"
+)
+
+// Información general de la documentación de v1. Se genera con:
+// swag init -g api_v1.go -d internal/server,internal/adapters/handlers --instanceName v1 -o docs/v1
+
+// @title           Tiny URL API
+// @version         1.0
+// @description     Acortador de URLs. Las rutas versionadas viven bajo /api/v1.
+// @termsOfService  http://swagger.io/terms/
+// @contact.name   API Support
+// @contact.url    http://www.swagger.io/support
+// @contact.email  support@swagger.io
+// @license.name  Apache 2.0
+// @license.url   http://www.apache.org/licenses/LICENSE-2.0.html
+
+// @securitydefinitions.oauth2.password	OPasswordAuth
+// @tokenUrl								/api/v1/auth/login
+// @description							JWT Token created by username and password
+
+// @securityDefinitions.apikey	BearerAuth
+// @in							header
+// @name						Authorization
+// @description					Token JWT con el formato "Bearer <token>"
+
+// registerV1 añade las rutas de la versión 1 de la API
+func (s *Server) registerV1(v1 *gin.RouterGroup) {
+	s.registerAuthRoutes(v1.Group("/auth"))
+	s.registerResourceRoutes(v1)
+}
@@ -0,0 +1,265 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"tiny-url/internal/adapters/handlers"
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports"
+)
+
+// IdempotencyKeyHeader es la cabecera con la que el cliente identifica una
+// operación para poder reintentarla sin repetir sus efectos
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader marca las respuestas repetidas a partir de un registro
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// maxIdempotencyKeyLength limita el tamaño de las claves recibidas del cliente
+const maxIdempotencyKeyLength = 255
+
+// idempotencyPurgeInterval es el tiempo mínimo entre dos purgas de registros caducados
+const idempotencyPurgeInterval = time.Hour
+
+// DefaultIdempotencyLease es el tiempo que una petición en curso retiene su
+// clave si no se configura otro. Si el proceso muere antes de responder, la
+// clave queda libre al vencer, sin esperar a la ventana de repetición.
+const DefaultIdempotencyLease = time.Minute
+
+// idempotency guarda y repite las respuestas de las peticiones con Idempotency-Key
+type idempotency struct {
+	repo    ports.IdempotencyRepository
+	window  time.Duration
+	lease   time.Duration
+	maxBody int64
+	now     func() time.Time
+
+	mu        sync.Mutex
+	lastPurge time.Time
+}
+
+// IdempotencyMiddleware guarda la primera respuesta de cada petición de
+// modificación con Idempotency-Key, por usuario y clave, durante window, y la
+// repite ante los reintentos. Reutilizar la clave con otra petición devuelve
+// 422 y reintentar mientras la original sigue en curso devuelve 409; la
+// petición en curso solo retiene la clave durante lease (cero usa
+// DefaultIdempotencyLease). Los cuerpos de más de maxBody bytes se rechazan
+// con 413 antes de leerlos enteros (cero usa el de la creación masiva por
+// defecto). Debe ir después de AuthMiddleware; sin repositorio o sin cabecera
+// no hace nada.
+func IdempotencyMiddleware(repo ports.IdempotencyRepository, window, lease time.Duration, maxBody int64) gin.HandlerFunc {
+	if repo == nil || window <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+	if lease <= 0 {
+		lease = DefaultIdempotencyLease
+	}
+	if maxBody <= 0 {
+		maxBody = handlers.BulkMaxBytes(handlers.DefaultBulkLimit)
+	}
+	i := &idempotency{repo: repo, window: window, lease: min(lease, window), maxBody: maxBody, now: time.Now}
+	return i.handle
+}
+
+// handle procesa una petición con el middleware de idempotencia
+func (i *idempotency) handle(c *gin.Context) {
+	key := c.GetHeader(IdempotencyKeyHeader)
+	userID, authenticated := c.Get("userID")
+	if key == "" || !authenticated || !isMutating(c.Request.Method) {
+		c.Next()
+		return
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		handlers.WriteError(c, errors.ErrInvalidIdempotencyKey)
+		return
+	}
+
+	// Leer el cuerpo, con límite, para calcular su huella y devolverlo a la petición
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, i.maxBody))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			handlers.WriteError(c, errors.ErrRequestTooLarge)
+			return
+		}
+		handlers.WriteError(c, errors.ErrInvalidIdempotencyKey)
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	ctx := c.Request.Context()
+	now := i.now()
+	i.purgeExpired(now)
+
+	record := &model.IdempotencyRecord{
+		UserID:      userID.(uint),
+		Key:         key,
+		RequestHash: requestHash(c.Request.Method, c.Request.URL.Path, c.Request.URL.RawQuery, body),
+		ExpiresAt:   now.Add(i.lease),
+	}
+
+	reserved, err := i.reserve(ctx, record, now)
+	if err != nil {
+		handlers.WriteError(c, err)
+		return
+	}
+	if !reserved.Completed() {
+		// La clave es nuestra: ejecutar la petición grabando la respuesta
+		i.record(c, reserved)
+		return
+	}
+
+	// Repetir la respuesta guardada
+	c.Header(IdempotentReplayedHeader, "true")
+	c.Data(reserved.StatusCode, reserved.ContentType, reserved.Body)
+	c.Abort()
+}
+
+// reserve devuelve el registro recién reservado para la petición o, si la
+// clave ya tenía una respuesta para la misma petición, ese registro
+func (i *idempotency) reserve(ctx context.Context, record *model.IdempotencyRecord, now time.Time) (*model.IdempotencyRecord, error) {
+	err := i.repo.Create(ctx, record)
+	if err == nil {
+		return record, nil
+	}
+	if !errors.Is(err, errors.ErrDuplicateKey) {
+		return nil, err
+	}
+
+	existing, err := i.repo.Get(ctx, record.UserID, record.Key)
+	if errors.Is(err, errors.ErrRecordNotFound) {
+		// La reserva se liberó entre medias; el cliente puede reintentar
+		return nil, errors.ErrIdempotencyKeyInProgress
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Un registro caducado se sustituye por el de esta petición
+	if existing.Expired(now) {
+		if err := i.repo.Delete(ctx, record.UserID, record.Key); err != nil {
+			return nil, err
+		}
+		if err := i.repo.Create(ctx, record); err != nil {
+			if errors.Is(err, errors.ErrDuplicateKey) {
+				return nil, errors.ErrIdempotencyKeyInProgress
+			}
+			return nil, err
+		}
+		return record, nil
+	}
+
+	if existing.RequestHash != record.RequestHash {
+		return nil, errors.ErrIdempotencyKeyReused
+	}
+	if !existing.Completed() {
+		return nil, errors.ErrIdempotencyKeyInProgress
+	}
+	return existing, nil
+}
+
+// record ejecuta el resto de la cadena y guarda su respuesta durante la
+// ventana de repetición. Las respuestas 5xx no se guardan, de modo que el
+// cliente pueda reintentar la operación, y un pánico libera la clave antes de
+// seguir propagándose hasta Recovery.
+func (i *idempotency) record(c *gin.Context, record *model.IdempotencyRecord) {
+	// La respuesta ya está enviada, así que no dependemos del contexto de la petición
+	ctx := context.WithoutCancel(c.Request.Context())
+	defer func() {
+		if r := recover(); r != nil {
+			i.release(c, ctx, record)
+			panic(r)
+		}
+	}()
+
+	writer := &recordingWriter{ResponseWriter: c.Writer}
+	c.Writer = writer
+	c.Next()
+
+	status := writer.Status()
+	if status >= http.StatusInternalServerError {
+		i.release(c, ctx, record)
+		return
+	}
+
+	record.StatusCode = status
+	record.ContentType = writer.Header().Get("Content-Type")
+	record.Body = writer.body.Bytes()
+	record.ExpiresAt = i.now().Add(i.window)
+	if err := i.repo.Update(ctx, record); err != nil {
+		log.Printf("request %s: failed to store idempotent response: %v", c.GetString(handlers.RequestIDKey), err)
+	}
+}
+
+// release libera la clave reservada para que el cliente pueda reintentar
+func (i *idempotency) release(c *gin.Context, ctx context.Context, record *model.IdempotencyRecord) {
+	if err := i.repo.Delete(ctx, record.UserID, record.Key); err != nil {
+		log.Printf("request %s: failed to release idempotency key: %v", c.GetString(handlers.RequestIDKey), err)
+	}
+}
+
+// purgeExpired elimina en segundo plano los registros caducados, como mucho
+// una vez cada idempotencyPurgeInterval
+func (i *idempotency) purgeExpired(now time.Time) {
+	i.mu.Lock()
+	if now.Sub(i.lastPurge) < idempotencyPurgeInterval {
+		i.mu.Unlock()
+		return
+	}
+	i.lastPurge = now
+	i.mu.Unlock()
+
+	go func() {
+		if _, err := i.repo.DeleteExpired(context.Background(), now); err != nil {
+			log.Printf("Failed to purge idempotency keys: %v", err)
+		}
+	}()
+}
+
+// isMutating indica si el método HTTP modifica recursos
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// requestHash calcula la huella de una petición a partir del método, la ruta,
+// la query string y el cuerpo
+func requestHash(method, path, rawQuery string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + path + "?" + rawQuery + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// recordingWriter copia el cuerpo de la respuesta mientras se escribe
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write implementa http.ResponseWriter
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString implementa io.StringWriter
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
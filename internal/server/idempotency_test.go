@@ -0,0 +1,273 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"tiny-url/internal/adapters/handlers"
+	"tiny-url/internal/adapters/repository/memory"
+	"tiny-url/internal/config"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports"
+)
+
+// newIdempotentRouter monta un manejador que cuenta sus llamadas detrás del
+// middleware de idempotencia, autenticando siempre al usuario 1
+func newIdempotentRouter(repo ports.IdempotencyRepository, status *int, calls *int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("userID", uint(1)) })
+	r.Use(IdempotencyMiddleware(repo, time.Hour, time.Minute, 1<<10))
+	r.POST("/items", func(c *gin.Context) {
+		*calls++
+		c.JSON(*status, gin.H{"call": *calls})
+	})
+	return r
+}
+
+func TestIdempotencyMiddleware_ReplaysFirstResponse(t *testing.T) {
+	// Arrange
+	repo := memory.NewIdempotencyRepository(memory.NewStore())
+	status, calls := http.StatusCreated, 0
+	r := newIdempotentRouter(repo, &status, &calls)
+	key := map[string]string{IdempotencyKeyHeader: "clave-1"}
+
+	// Act
+	first := doJSONWithHeaders(t, r, "POST", "/items", "", `{"a":1}`, key)
+	retry := doJSONWithHeaders(t, r, "POST", "/items", "", `{"a":1}`, key)
+
+	// Assert
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, "application/json; charset=utf-8", retry.Header().Get("Content-Type"))
+	assert.Equal(t, "true", retry.Header().Get(IdempotentReplayedHeader))
+	assert.Empty(t, first.Header().Get(IdempotentReplayedHeader))
+}
+
+func TestIdempotencyMiddleware_RejectsKeyReusedWithDifferentBody(t *testing.T) {
+	// Arrange
+	repo := memory.NewIdempotencyRepository(memory.NewStore())
+	status, calls := http.StatusCreated, 0
+	r := newIdempotentRouter(repo, &status, &calls)
+	key := map[string]string{IdempotencyKeyHeader: "clave-1"}
+	doJSONWithHeaders(t, r, "POST", "/items", "", `{"a":1}`, key)
+
+	// Act
+	reused := doJSONWithHeaders(t, r, "POST", "/items", "", `{"a":2}`, key)
+
+	// Assert
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusUnprocessableEntity, reused.Code)
+	assert.Equal(t, handlers.ProblemContentType, reused.Header().Get("Content-Type"))
+	assert.Contains(t, reused.Body.String(), handlers.CodeIdempotencyKeyReused)
+}
+
+func TestIdempotencyMiddleware_RejectsKeyReusedWithDifferentQuery(t *testing.T) {
+	// Arrange
+	repo := memory.NewIdempotencyRepository(memory.NewStore())
+	status, calls := http.StatusCreated, 0
+	r := newIdempotentRouter(repo, &status, &calls)
+	key := map[string]string{IdempotencyKeyHeader: "clave-1"}
+	doJSONWithHeaders(t, r, "POST", "/items?atomic=true", "", `{"a":1}`, key)
+
+	// Act
+	reused := doJSONWithHeaders(t, r, "POST", "/items?atomic=false", "", `{"a":1}`, key)
+
+	// Assert
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusUnprocessableEntity, reused.Code)
+	assert.Contains(t, reused.Body.String(), handlers.CodeIdempotencyKeyReused)
+}
+
+func TestIdempotencyMiddleware_RejectsRetryWhileInProgress(t *testing.T) {
+	// Arrange
+	repo := memory.NewIdempotencyRepository(memory.NewStore())
+	status, calls := http.StatusCreated, 0
+	r := newIdempotentRouter(repo, &status, &calls)
+	require.NoError(t, repo.Create(context.Background(), &model.IdempotencyRecord{
+		UserID:      1,
+		Key:         "clave-1",
+		RequestHash: requestHash("POST", "/items", "", []byte(`{"a":1}`)),
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}))
+
+	// Act
+	retry := doJSONWithHeaders(t, r, "POST", "/items", "", `{"a":1}`, map[string]string{IdempotencyKeyHeader: "clave-1"})
+
+	// Assert
+	assert.Equal(t, 0, calls)
+	assert.Equal(t, http.StatusConflict, retry.Code)
+	assert.Contains(t, retry.Body.String(), handlers.CodeIdempotencyKeyInProgress)
+}
+
+func TestIdempotencyMiddleware_DoesNotStoreServerErrors(t *testing.T) {
+	// Arrange
+	repo := memory.NewIdempotencyRepository(memory.NewStore())
+	status, calls := http.StatusInternalServerError, 0
+	r := newIdempotentRouter(repo, &status, &calls)
+	key := map[string]string{IdempotencyKeyHeader: "clave-1"}
+
+	// Act
+	failed := doJSONWithHeaders(t, r, "POST", "/items", "", `{"a":1}`, key)
+	status = http.StatusCreated
+	retry := doJSONWithHeaders(t, r, "POST", "/items", "", `{"a":1}`, key)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, failed.Code)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotencyMiddleware_ReplacesExpiredRecords(t *testing.T) {
+	// Arrange
+	repo := memory.NewIdempotencyRepository(memory.NewStore())
+	status, calls := http.StatusCreated, 0
+	r := newIdempotentRouter(repo, &status, &calls)
+	require.NoError(t, repo.Create(context.Background(), &model.IdempotencyRecord{
+		UserID:      1,
+		Key:         "clave-1",
+		RequestHash: "otra-peticion",
+		StatusCode:  http.StatusCreated,
+		ExpiresAt:   time.Now().Add(-time.Minute),
+	}))
+
+	// Act
+	rr := doJSONWithHeaders(t, r, "POST", "/items", "", `{"a":1}`, map[string]string{IdempotencyKeyHeader: "clave-1"})
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotencyMiddleware_PassesThroughWithoutKey(t *testing.T) {
+	// Arrange
+	repo := memory.NewIdempotencyRepository(memory.NewStore())
+	status, calls := http.StatusCreated, 0
+	r := newIdempotentRouter(repo, &status, &calls)
+
+	// Act
+	doJSON(t, r, "POST", "/items", "", `{"a":1}`)
+	doJSON(t, r, "POST", "/items", "", `{"a":1}`)
+
+	// Assert
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotencyMiddleware_RejectsLongKeys(t *testing.T) {
+	// Arrange
+	repo := memory.NewIdempotencyRepository(memory.NewStore())
+	status, calls := http.StatusCreated, 0
+	r := newIdempotentRouter(repo, &status, &calls)
+	key := map[string]string{IdempotencyKeyHeader: strings.Repeat("k", maxIdempotencyKeyLength+1)}
+
+	// Act
+	rr := doJSONWithHeaders(t, r, "POST", "/items", "", `{"a":1}`, key)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), handlers.CodeInvalidIdempotencyKey)
+	assert.Equal(t, 0, calls)
+}
+
+func TestIdempotencyMiddleware_RejectsLargeBodies(t *testing.T) {
+	// Arrange
+	repo := memory.NewIdempotencyRepository(memory.NewStore())
+	status, calls := http.StatusCreated, 0
+	r := newIdempotentRouter(repo, &status, &calls)
+	key := map[string]string{IdempotencyKeyHeader: "clave-1"}
+
+	// Act
+	rr := doJSONWithHeaders(t, r, "POST", "/items", "", `{"a":"`+strings.Repeat("x", 1<<10)+`"}`, key)
+
+	// Assert
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+	assert.Contains(t, rr.Body.String(), handlers.CodeRequestTooLarge)
+	assert.Equal(t, 0, calls)
+}
+
+func TestNewServer_IdempotentShorten(t *testing.T) {
+	// Arrange
+	handler := NewServer(config.Config{Port: 8080, Storage: config.StorageMemory, IdempotencyWindow: time.Hour}, nil).Handler
+	token := registerUser(t, handler, "reintentos")
+	key := map[string]string{IdempotencyKeyHeader: "b4c1f2"}
+	otherUser := registerUser(t, handler, "otro")
+
+	// Act
+	first := doJSONWithHeaders(t, handler, "POST", "/api/v1/urls", token, `{"url":"https://www.ejemplo.com"}`, key)
+	retry := doJSONWithHeaders(t, handler, "POST", "/api/v1/urls", token, `{"url":"https://www.ejemplo.com"}`, key)
+	other := doJSONWithHeaders(t, handler, "POST", "/api/v1/urls", otherUser, `{"url":"https://www.otro.com"}`, key)
+
+	// Assert
+	require.Equal(t, http.StatusCreated, first.Code, first.Body.String())
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, "true", retry.Header().Get(IdempotentReplayedHeader))
+
+	var created struct {
+		OriginalURL string `json:"original_url"`
+	}
+	require.Equal(t, http.StatusCreated, other.Code, other.Body.String())
+	require.NoError(t, json.Unmarshal(other.Body.Bytes(), &created))
+	assert.Equal(t, "https://www.otro.com", created.OriginalURL)
+}
+
+func TestIdempotencyMiddleware_ReleasesKeyOnPanic(t *testing.T) {
+	// Arrange
+	repo := memory.NewIdempotencyRepository(memory.NewStore())
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(gin.Recovery())
+	r.Use(func(c *gin.Context) { c.Set("userID", uint(1)) })
+	r.Use(IdempotencyMiddleware(repo, time.Hour, time.Minute, 1<<10))
+	calls := 0
+	r.POST("/items", func(c *gin.Context) {
+		calls++
+		if calls == 1 {
+			panic("boom")
+		}
+		c.JSON(http.StatusCreated, gin.H{"call": calls})
+	})
+	key := map[string]string{IdempotencyKeyHeader: "clave-1"}
+
+	// Act
+	crashed := doJSONWithHeaders(t, r, "POST", "/items", "", `{"a":1}`, key)
+	retry := doJSONWithHeaders(t, r, "POST", "/items", "", `{"a":1}`, key)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, crashed.Code)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotencyMiddleware_LeasesKeyWhileInProgress(t *testing.T) {
+	// Arrange
+	repo := memory.NewIdempotencyRepository(memory.NewStore())
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("userID", uint(1)) })
+	r.Use(IdempotencyMiddleware(repo, 24*time.Hour, time.Minute, 1<<10))
+	var inProgress *model.IdempotencyRecord
+	r.POST("/items", func(c *gin.Context) {
+		inProgress, _ = repo.Get(c.Request.Context(), 1, "clave-1")
+		c.JSON(http.StatusCreated, gin.H{})
+	})
+
+	// Act
+	rr := doJSONWithHeaders(t, r, "POST", "/items", "", `{"a":1}`, map[string]string{IdempotencyKeyHeader: "clave-1"})
+
+	// Assert
+	require.Equal(t, http.StatusCreated, rr.Code)
+	require.NotNil(t, inProgress)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), inProgress.ExpiresAt, 5*time.Second)
+	completed, err := repo.Get(context.Background(), 1, "clave-1")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), completed.ExpiresAt, 5*time.Second)
+}
@@ -1,31 +1,61 @@
 package server
 
 import (
-	"net/http"
+	"crypto/rand"
+	"encoding/hex"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 
+	"tiny-url/internal/adapters/handlers"
 	"tiny-url/internal/domain/errors"
 	"tiny-url/internal/domain/ports"
 )
 
+// RequestIDHeader es la cabecera que transporta el ID de cada petición
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength limita el tamaño de los IDs recibidos del cliente
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware asigna un ID a cada petición, reutilizando el recibido en
+// X-Request-ID si lo hay, y lo devuelve en la respuesta para facilitar el soporte
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = newRequestID()
+		}
+
+		c.Set(handlers.RequestIDKey, requestID)
+		c.Header(RequestIDHeader, requestID)
+		c.Next()
+	}
+}
+
+// newRequestID genera un ID aleatorio de 16 caracteres hexadecimales
+func newRequestID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(buf)
+}
+
 // AuthMiddleware crea un middleware para proteger rutas que requieren autenticación
 func AuthMiddleware(authService ports.AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Extraer token del encabezado "Authorization"
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "No autorizado: token no proporcionado"})
-			c.Abort()
+			handlers.WriteError(c, errors.ErrUnauthorized)
 			return
 		}
 
 		// El formato del encabezado debe ser "Bearer <token>"
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Formato de token inválido"})
-			c.Abort()
+			handlers.WriteError(c, errors.ErrInvalidToken)
 			return
 		}
 
@@ -35,12 +65,7 @@ func AuthMiddleware(authService ports.AuthService) gin.HandlerFunc {
 		// Validar el token y obtener el ID del usuario
 		userID, err := authService.ValidateToken(tokenString)
 		if err != nil {
-			if errors.Is(err, errors.ErrInvalidToken) || errors.Is(err, errors.ErrExpiredToken) {
-				c.JSON(http.StatusUnauthorized, gin.H{"error": "Token inválido o expirado"})
-			} else {
-				c.JSON(http.StatusUnauthorized, gin.H{"error": "Error al validar token"})
-			}
-			c.Abort()
+			handlers.WriteError(c, err)
 			return
 		}
 
@@ -51,3 +76,22 @@ func AuthMiddleware(authService ports.AuthService) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// UserLanguageMiddleware aplica el idioma preferido del usuario autenticado.
+// Debe ir después de AuthMiddleware; si el usuario no tiene preferencia se
+// mantiene el idioma negociado con Accept-Language. El usuario solo se consulta
+// cuando la respuesta lleva un texto traducido, no en cada petición.
+func UserLanguageMiddleware(authService ports.AuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if userID, ok := c.Get("userID"); ok {
+			handlers.SetLanguageResolver(c, func() string {
+				user, err := authService.GetUser(c.Request.Context(), userID.(uint))
+				if err != nil {
+					return ""
+				}
+				return user.Language
+			})
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"tiny-url/internal/adapters/handlers"
+	"tiny-url/internal/domain/errors"
+	"tiny-url/internal/domain/model"
+	"tiny-url/internal/domain/ports/mocks"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	// Arrange
+	r := gin.New()
+	r.Use(RequestIDMiddleware())
+	var seen string
+	r.GET("/", func(c *gin.Context) { seen = c.GetString(handlers.RequestIDKey) })
+
+	// Act
+	generated := httptest.NewRecorder()
+	r.ServeHTTP(generated, httptest.NewRequest("GET", "/", nil))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(RequestIDHeader, "cliente-42")
+	propagated := httptest.NewRecorder()
+	r.ServeHTTP(propagated, req)
+
+	// Assert
+	assert.Len(t, generated.Header().Get(RequestIDHeader), 16)
+	assert.Equal(t, "cliente-42", propagated.Header().Get(RequestIDHeader))
+	assert.Equal(t, "cliente-42", seen)
+}
+
+func TestUserLanguageMiddleware_LooksUpOnlyForMessages(t *testing.T) {
+	// Arrange
+	authService := mocks.NewMockAuthService(t)
+	authService.EXPECT().GetUser(mock.Anything, uint(7)).Return(&model.User{Language: "en"}, nil).Once()
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("userID", uint(7)) }, UserLanguageMiddleware(authService))
+	r.GET("/ok", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	r.GET("/error", func(c *gin.Context) {
+		// Dos textos traducidos en la misma petición, una sola consulta
+		handlers.Language(c)
+		handlers.WriteError(c, errors.ErrURLNotFound)
+	})
+
+	// Act
+	ok := httptest.NewRecorder()
+	r.ServeHTTP(ok, httptest.NewRequest("GET", "/ok", nil))
+	failed := httptest.NewRecorder()
+	r.ServeHTTP(failed, httptest.NewRequest("GET", "/error", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, ok.Code)
+	assert.Equal(t, http.StatusNotFound, failed.Code)
+	assert.Equal(t, "en", failed.Header().Get("Content-Language"))
+	assert.Contains(t, failed.Body.String(), "URL not found")
+}
@@ -3,45 +3,30 @@ package server
 import (
 	"net/http"
 
-	_ "tiny-url/docs"
-
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
-	swaggerFiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
 
 	"tiny-url/internal/adapters/handlers"
 )
 
-// @title           Swagger Example API
-// @version         1.0
-// @description     This is a sample server cellear server.
-// @termsOfService  http://swagger.io/terms/
-// @contact.name   API Support
-// @contact.url    http://www.swagger.io/support
-// @contact.email  support@swagger.io
-// @license.name  Apache 2.0
-// @license.url   http://www.apache.org/licenses/LICENSE-2.0.html
-
-// @securitydefinitions.oauth2.password	OPasswordAuth
-// @tokenUrl								/auth/login
-// @description							JWT Token created by username and password
+// RegisterRoutes monta las rutas de infraestructura, cada versión de la API
+// bajo /api/<versión> y las rutas heredadas sin versión, ya obsoletas
 func (s *Server) RegisterRoutes() http.Handler {
 	r := gin.Default()
 
+	// Identificar cada petición para poder correlacionar errores y registros
+	r.Use(RequestIDMiddleware())
+
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:5173"}, // Add your frontend URL
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
-		AllowHeaders:     []string{"Accept", "Authorization", "Content-Type"},
+		AllowHeaders:     []string{"Accept", "Accept-Language", "Authorization", "Content-Type", RequestIDHeader},
+		ExposeHeaders:    []string{RequestIDHeader},
 		AllowCredentials: true, // Enable cookies/auth
 	}))
 
-	// Endpoint para la documentación Swagger
-	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
-
 	// Crear manejadores
 	urlHandler := handlers.NewURLHandler(s.urlService)
-	authHandler := handlers.NewAuthHandler(s.authService)
 
 	// Ruta raíz para información general
 	// @Summary Información general de la API
@@ -61,42 +46,39 @@ func (s *Server) RegisterRoutes() http.Handler {
 	// @Router /health [get]
 	r.GET("/health", s.healthHandler)
 
-	// Rutas de autenticación (públicas)
-	auth := r.Group("/auth")
-	{
-		auth.POST("/register", authHandler.Register)
-		auth.POST("/login", authHandler.Login)
-	}
-
-	// Middleware de autenticación para rutas protegidas
-	authRequired := AuthMiddleware(s.authService)
-
-	// Rutas para el acortador de URLs
-	api := r.Group("/api")
-	{
-		// Ruta de perfil de usuario (requiere autenticación)
-		api.GET("/profile", authRequired, authHandler.GetUserProfile)
-
-		// Rutas para URLs (requieren autenticación)
-		urls := api.Group("/urls")
-		urls.Use(authRequired) // Aplicar middleware de autenticación a todas las rutas de URLs
-		{
-			// Acortar URL
-			urls.POST("", urlHandler.ShortenURL)
+	// Sondas de vida y disponibilidad
+	// @Summary Sonda de vida
+	// @Description Indica que el proceso está en ejecución, sin comprobar dependencias
+	// @Tags health
+	// @Produce json
+	// @Success 200 {object} map[string]string
+	// @Router /livez [get]
+	r.GET("/livez", s.livenessHandler)
 
-			// Listar todas las URLs acortadas
-			urls.GET("", urlHandler.ListURLs)
+	// @Summary Sonda de disponibilidad
+	// @Description Comprueba la base de datos, la versión del esquema y los procesos en segundo plano
+	// @Tags health
+	// @Produce json
+	// @Success 200 {object} map[string]interface{}
+	// @Failure 503 {object} map[string]interface{}
+	// @Router /readyz [get]
+	r.GET("/readyz", s.readinessHandler)
 
-			// Obtener información de una URL acortada
-			urls.GET("/:shortCode", urlHandler.GetURLInfo)
+	// Versiones de la API, cada una con su documentación Swagger
+	versions := s.apiVersions()
+	s.mountAPIVersions(r, versions)
+	mountSwagger(r, versions)
 
-			// Eliminar una URL acortada
-			urls.DELETE("/:shortCode", urlHandler.DeleteURL)
-		}
-	}
+	// Rutas anteriores al versionado, mantenidas por compatibilidad
+	s.mountLegacyRoutes(r)
 
-	// Ruta para redireccionar usando el código corto (pública)
+	// Ruta para redireccionar usando el código corto (pública), y envío de
+	// la contraseña de las URLs protegidas. La ruta que sigue al código se
+	// pasa al destino en las URLs que lo permiten.
 	r.GET("/:shortCode", urlHandler.RedirectURL)
+	r.GET("/:shortCode/*rest", urlHandler.RedirectURL)
+	r.POST("/:shortCode", urlHandler.UnlockURL)
+	r.POST("/:shortCode/*rest", urlHandler.UnlockURL)
 
 	return r
 }
@@ -110,5 +92,31 @@ func (s *Server) HelloWorldHandler(c *gin.Context) {
 }
 
 func (s *Server) healthHandler(c *gin.Context) {
-	c.JSON(http.StatusOK, s.db.Health())
+	// Sin base de datos (almacenamiento en memoria) no hay nada que comprobar
+	if s.db == nil {
+		c.JSON(http.StatusOK, gin.H{"status": "up", "message": "In-memory storage"})
+		return
+	}
+
+	stats := s.db.Health()
+	if stats["status"] != "up" {
+		c.JSON(http.StatusServiceUnavailable, stats)
+		return
+	}
+	c.JSON(http.StatusOK, stats)
+}
+
+// livenessHandler responde siempre que el proceso sea capaz de atender peticiones
+func (s *Server) livenessHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// readinessHandler ejecuta las comprobaciones registradas y responde 503 si alguna falla
+func (s *Server) readinessHandler(c *gin.Context) {
+	report := s.health.Run(c.Request.Context())
+	if !report.Healthy() {
+		c.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+	c.JSON(http.StatusOK, report)
 }
@@ -1,10 +1,15 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
+
+	"tiny-url/internal/health"
 )
 
 func TestHelloWorldHandler(t *testing.T) {
@@ -30,3 +35,40 @@ func TestHelloWorldHandler(t *testing.T) {
 		t.Errorf("Handler returned unexpected body: got %v want %v", rr.Body.String(), expected)
 	}
 }
+
+func TestLivenessHandler(t *testing.T) {
+	s := &Server{}
+	r := gin.New()
+	r.GET("/livez", s.livenessHandler)
+
+	req := httptest.NewRequest("GET", "/livez", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Handler returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
+	}
+}
+
+func TestReadinessHandler(t *testing.T) {
+	registry := health.NewRegistry(time.Second)
+	s := &Server{health: registry}
+	r := gin.New()
+	r.GET("/readyz", s.readinessHandler)
+
+	// Sin comprobaciones fallidas el servicio está listo
+	registry.Register("database", health.CheckerFunc(func(ctx context.Context) error { return nil }))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest("GET", "/readyz", nil))
+	if rr.Code != http.StatusOK {
+		t.Errorf("Handler returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
+	}
+
+	// Una comprobación fallida devuelve 503 sin detener el proceso
+	registry.Register("database", health.CheckerFunc(func(ctx context.Context) error { return errors.New("db down") }))
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest("GET", "/readyz", nil))
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("Handler returned wrong status code: got %v want %v", rr.Code, http.StatusServiceUnavailable)
+	}
+}
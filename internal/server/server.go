@@ -1,58 +1,109 @@
 package server
 
 import (
+	"context"
+	"crypto/rand"
 	"fmt"
+	"log"
 	"net/http"
 	"os"
 	"strconv"
 	"time"
 
-	_ "github.com/joho/godotenv/autoload"
-	"gorm.io/gorm"
-
 	"tiny-url/internal/adapters/repository"
+	"tiny-url/internal/adapters/repository/memory"
+	"tiny-url/internal/config"
 	"tiny-url/internal/database"
+	"tiny-url/internal/domain/model"
 	"tiny-url/internal/domain/ports"
 	"tiny-url/internal/domain/service"
+	"tiny-url/internal/health"
 )
 
 type Server struct {
 	port int
 
-	db          database.Service
-	gormDB      *database.GormService
-	urlService  ports.URLService
-	authService ports.AuthService
-	userRepo    ports.UserRepository
-}
+	db            database.Service
+	urlService    ports.URLService
+	authService   ports.AuthService
+	tagService    ports.TagService
+	folderService ports.FolderService
+	userRepo      ports.UserRepository
+	health        *health.Registry
 
-func NewServer() *http.Server {
-	port, _ := strconv.Atoi(os.Getenv("PORT"))
+	// idempotencyRepo guarda las respuestas de las peticiones con Idempotency-Key
+	// durante idempotencyWindow; nil desactiva la idempotencia
+	idempotencyRepo   ports.IdempotencyRepository
+	idempotencyWindow time.Duration
+	idempotencyLease  time.Duration
 
-	// Inicializar la base de datos tradicional
-	dbService := database.New()
+	// cursorSecret firma los cursores de paginación de todas las versiones
+	cursorSecret []byte
 
-	// Inicializar GORM para PostgreSQL
-	gormService := database.NewGormService()
+	// bulkMaxItems limita las URLs de cada creación masiva
+	bulkMaxItems int
 
-	// Inicializar el repositorio de URLs
-	urlRepository := repository.NewURLRepository(gormService.GetDB())
+	// legacySunset es la fecha de retirada anunciada en las rutas sin versión
+	legacySunset time.Time
+}
+
+// repositories agrupa los adaptadores de persistencia usados por los servicios
+type repositories struct {
+	urls        ports.URLRepository
+	users       ports.UserRepository
+	tags        ports.TagRepository
+	folders     ports.FolderRepository
+	unitOfWork  ports.UnitOfWork
+	idempotency ports.IdempotencyRepository
+}
 
-	// Inicializar el repositorio de usuarios
-	userRepository := repository.NewUserRepository(gormService.GetDB())
+// NewServer construye el servidor HTTP sobre una conexión ya abierta.
+// El llamador es responsable de cerrar db tras detener el servidor.
+// Con el almacenamiento en memoria db es nil y los datos viven en el proceso.
+func NewServer(cfg config.Config, db database.Service) *http.Server {
+	// Inicializar los repositorios del backend configurado
+	repos := newRepositories(cfg, db)
 
 	// Inicializar los servicios
-	urlService := service.NewURLService(urlRepository)
-	authService := service.NewAuthService(userRepository)
+	urlService := service.NewURLService(repos.urls, repos.unitOfWork,
+		service.WithAccessSecret(signingSecret("LINK_ACCESS_SECRET", cfg.LinkAccessSecret, "link passwords will be asked again after a restart")),
+		service.WithAccessTTL(cfg.LinkAccessTTL),
+		service.WithDefaultRedirectCode(cfg.RedirectCode),
+		service.WithRedirectMaxAge(cfg.RedirectMaxAge),
+	)
+	if cfg.RedirectCode != 0 && !model.ValidRedirectCode(cfg.RedirectCode) {
+		log.Printf("Invalid REDIRECT_CODE %d, using %d", cfg.RedirectCode, service.DefaultRedirectCode)
+	}
+	authService := service.NewAuthService(repos.users, repos.unitOfWork)
+	tagService := service.NewTagService(repos.tags)
+	folderService := service.NewFolderService(repos.folders)
+
+	// Registrar las comprobaciones de disponibilidad
+	healthRegistry := health.NewRegistry(2 * time.Second)
+	if db != nil {
+		registerDatabaseChecks(healthRegistry, db)
+	}
+
+	// Purgar la papelera en segundo plano mientras el servidor esté activo
+	stopPurge := startTrashPurge(urlService, healthRegistry, cfg.TrashRetention, cfg.TrashPurgeInterval)
 
 	// Crear la instancia del servidor
 	newServer := &Server{
-		port:        port,
-		db:          dbService,
-		gormDB:      gormService,
-		urlService:  urlService,
-		authService: authService,
-		userRepo:    userRepository,
+		port:          cfg.Port,
+		db:            db,
+		urlService:    urlService,
+		authService:   authService,
+		tagService:    tagService,
+		folderService: folderService,
+		userRepo:      repos.users,
+		health:        healthRegistry,
+		legacySunset:  cfg.LegacyAPISunset,
+
+		idempotencyRepo:   repos.idempotency,
+		idempotencyWindow: cfg.IdempotencyWindow,
+		idempotencyLease:  cfg.IdempotencyLease,
+		cursorSecret:      signingSecret("CURSOR_SECRET", cfg.CursorSecret, "pagination cursors will not survive a restart"),
+		bulkMaxItems:      cfg.BulkMaxItems,
 	}
 
 	// Configurar el servidor HTTP
@@ -63,26 +114,95 @@ func NewServer() *http.Server {
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 30 * time.Second,
 	}
+	server.RegisterOnShutdown(stopPurge)
 
 	return server
 }
 
+// signingSecret devuelve la clave configurada en la variable name o, si no la
+// hay, una aleatoria compartida por todo el proceso; consequence explica en
+// el aviso qué se pierde al reiniciar
+func signingSecret(name, configured, consequence string) []byte {
+	if configured != "" {
+		return []byte(configured)
+	}
+	log.Printf("%s is not set, %s", name, consequence)
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		log.Fatalf("Failed to generate %s: %v", name, err)
+	}
+	return secret
+}
+
+// newRepositories crea los repositorios del backend de almacenamiento configurado
+func newRepositories(cfg config.Config, db database.Service) repositories {
+	if cfg.Storage == config.StorageMemory {
+		store := memory.NewStore()
+		return repositories{
+			urls:        memory.NewURLRepository(store),
+			users:       memory.NewUserRepository(store),
+			tags:        memory.NewTagRepository(store),
+			folders:     memory.NewFolderRepository(store),
+			unitOfWork:  memory.NewUnitOfWork(store),
+			idempotency: memory.NewIdempotencyRepository(store),
+		}
+	}
+
+	// Límites de tiempo por operación para todas las consultas
+	timeouts := repository.WithTimeouts(repository.Timeouts{
+		Read:  cfg.Database.ReadTimeout,
+		Write: cfg.Database.WriteTimeout,
+	})
+
+	return repositories{
+		urls:        repository.NewURLRepository(db.Gorm(), timeouts),
+		users:       repository.NewUserRepository(db.Gorm(), timeouts),
+		tags:        repository.NewTagRepository(db.Gorm(), timeouts),
+		folders:     repository.NewFolderRepository(db.Gorm(), timeouts),
+		unitOfWork:  repository.NewUnitOfWork(db.Gorm(), timeouts),
+		idempotency: repository.NewIdempotencyRepository(db.Gorm(), timeouts),
+	}
+}
+
+// registerDatabaseChecks registra la conexión y la versión del esquema como
+// comprobaciones de disponibilidad
+func registerDatabaseChecks(registry *health.Registry, db database.Service) {
+	registry.Register("database", health.CheckerFunc(db.Ping))
+	migrator, err := db.Migrator()
+	if err != nil {
+		log.Printf("Failed to load migrations for readiness check: %v", err)
+		return
+	}
+	registry.Register("migrations", health.CheckerFunc(func(ctx context.Context) error {
+		version, err := migrator.Version(ctx)
+		if err != nil {
+			return err
+		}
+		if version < migrator.LatestVersion() {
+			return fmt.Errorf("schema at version %d, expected %d", version, migrator.LatestVersion())
+		}
+		return nil
+	}))
+}
+
 // NewServerWithDependencies crea una instancia del servidor con dependencias inyectadas
 // Útil para pruebas de integración y entornos controlados
-func NewServerWithDependencies(db *gorm.DB, urlService ports.URLService, authService ports.AuthService) *Server {
+func NewServerWithDependencies(db database.Service, urlService ports.URLService, authService ports.AuthService) *Server {
 	port, _ := strconv.Atoi(os.Getenv("PORT"))
 	if port == 0 {
 		port = 8080 // Puerto por defecto para pruebas
 	}
 
-	// Usar una implementación ficticia para la base de datos tradicional en pruebas
-	dbService := database.New()
+	// Registrar la comprobación de la base de datos
+	healthRegistry := health.NewRegistry(2 * time.Second)
+	healthRegistry.Register("database", health.CheckerFunc(db.Ping))
 
 	// Crear la instancia del servidor con las dependencias inyectadas
 	return &Server{
 		port:        port,
-		db:          dbService,
+		db:          db,
 		urlService:  urlService,
 		authService: authService,
+		health:      healthRegistry,
 	}
 }
@@ -0,0 +1,821 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"tiny-url/internal/config"
+)
+
+// doJSON envía una petición JSON al manejador y devuelve la respuesta grabada
+func doJSON(t *testing.T, handler http.Handler, method, path, token, body string) *httptest.ResponseRecorder {
+	return doJSONWithHeaders(t, handler, method, path, token, body, nil)
+}
+
+// doJSONWithHeaders es como doJSON pero añade las cabeceras indicadas
+func doJSONWithHeaders(t *testing.T, handler http.Handler, method, path, token, body string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestNewServer_MemoryStorage(t *testing.T) {
+	// Arrange
+	srv := NewServer(config.Config{Port: 8080, Storage: config.StorageMemory}, nil)
+	handler := srv.Handler
+
+	// Act
+	token := registerUser(t, handler, "demo")
+	shorten := doJSON(t, handler, "POST", "/api/v1/urls", token, `{"url":"https://www.ejemplo.com"}`)
+	require.Equal(t, http.StatusCreated, shorten.Code, shorten.Body.String())
+
+	var created struct {
+		ShortCode string `json:"short_code"`
+	}
+	require.NoError(t, json.Unmarshal(shorten.Body.Bytes(), &created))
+
+	redirect := doJSON(t, handler, "GET", "/"+created.ShortCode, "", "")
+	health := doJSON(t, handler, "GET", "/health", "", "")
+	ready := doJSON(t, handler, "GET", "/readyz", "", "")
+
+	// Assert
+	assert.Equal(t, http.StatusMovedPermanently, redirect.Code)
+	assert.Equal(t, "https://www.ejemplo.com", redirect.Header().Get("Location"))
+	assert.Equal(t, http.StatusOK, health.Code)
+	assert.Equal(t, http.StatusOK, ready.Code)
+}
+
+// registerUser registra un usuario en el servidor y devuelve su token
+func registerUser(t *testing.T, handler http.Handler, username string) string {
+	register := doJSON(t, handler, "POST", "/api/v1/auth/register", "",
+		`{"username":"`+username+`","email":"`+username+`@ejemplo.com","password":"secreto123"}`)
+	require.Equal(t, http.StatusCreated, register.Code, register.Body.String())
+
+	var auth struct {
+		Token string `json:"token"`
+	}
+	require.NoError(t, json.Unmarshal(register.Body.Bytes(), &auth))
+	return auth.Token
+}
+
+func TestNewServer_LanguagePreference(t *testing.T) {
+	// Arrange
+	handler := NewServer(config.Config{Port: 8080, Storage: config.StorageMemory}, nil).Handler
+	token := registerUser(t, handler, "idiomas")
+	spanish := map[string]string{"Accept-Language": "es-ES"}
+
+	// Act
+	before := doJSONWithHeaders(t, handler, "GET", "/api/v1/urls/missing", token, "", spanish)
+	update := doJSON(t, handler, "PATCH", "/api/v1/profile", token, `{"language":"en"}`)
+	after := doJSONWithHeaders(t, handler, "GET", "/api/v1/urls/missing", token, "", spanish)
+	invalid := doJSON(t, handler, "PATCH", "/api/v1/profile", token, `{"language":"fr"}`)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, before.Code)
+	assert.Contains(t, before.Body.String(), "URL no encontrada")
+	assert.Equal(t, http.StatusOK, update.Code, update.Body.String())
+	assert.Contains(t, after.Body.String(), "URL not found")
+	assert.Equal(t, http.StatusBadRequest, invalid.Code)
+	assert.Contains(t, invalid.Body.String(), "The value is not one of the allowed values")
+}
+
+func TestNewServer_ListURLs(t *testing.T) {
+	// Arrange
+	handler := NewServer(config.Config{Port: 8080, Storage: config.StorageMemory}, nil).Handler
+	token := registerUser(t, handler, "listados")
+	for _, url := range []string{"https://www.ejemplo.com/uno", "https://blog.ejemplo.com/dos", "https://otro.org/tres"} {
+		rr := doJSON(t, handler, "POST", "/api/v1/urls", token, `{"url":"`+url+`"}`)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	}
+
+	// Act
+	filtered := doJSON(t, handler, "GET", "/api/v1/urls?domain=ejemplo.com&sort=created_at&limit=1", token, "")
+	search := doJSON(t, handler, "GET", "/api/v1/urls?q=TRES", token, "")
+	clamped := doJSON(t, handler, "GET", "/api/v1/urls?limit=100000", token, "")
+	invalid := doJSON(t, handler, "GET", "/api/v1/urls?sort=nombre&created_from=ayer", token, "")
+
+	// Assert
+	var page struct {
+		URLs []struct {
+			OriginalURL string `json:"original_url"`
+		} `json:"urls"`
+		Total int64 `json:"total"`
+		Limit int   `json:"limit"`
+	}
+	require.Equal(t, http.StatusOK, filtered.Code, filtered.Body.String())
+	require.NoError(t, json.Unmarshal(filtered.Body.Bytes(), &page))
+	assert.Equal(t, int64(2), page.Total)
+	require.Len(t, page.URLs, 1)
+	assert.Equal(t, "https://www.ejemplo.com/uno", page.URLs[0].OriginalURL)
+
+	require.NoError(t, json.Unmarshal(search.Body.Bytes(), &page))
+	assert.Equal(t, int64(1), page.Total)
+	assert.Equal(t, "https://otro.org/tres", page.URLs[0].OriginalURL)
+
+	require.NoError(t, json.Unmarshal(clamped.Body.Bytes(), &page))
+	assert.Equal(t, 100, page.Limit)
+	assert.Equal(t, int64(3), page.Total)
+
+	assert.Equal(t, http.StatusBadRequest, invalid.Code)
+	assert.Contains(t, invalid.Body.String(), `"field":"sort"`)
+}
+
+func TestNewServer_ListURLsCursors(t *testing.T) {
+	// Arrange
+	handler := NewServer(config.Config{Port: 8080, Storage: config.StorageMemory, CursorSecret: "secreto"}, nil).Handler
+	token := registerUser(t, handler, "cursores")
+	for i := 0; i < 5; i++ {
+		rr := doJSON(t, handler, "POST", "/api/v1/urls", token, fmt.Sprintf(`{"url":"https://www.ejemplo.com/%d"}`, i))
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	}
+
+	type page struct {
+		URLs []struct {
+			OriginalURL string `json:"original_url"`
+		} `json:"urls"`
+		NextCursor string `json:"next_cursor"`
+		PrevCursor string `json:"prev_cursor"`
+	}
+	nextLink := regexp.MustCompile(`<([^>]+)>; rel="next"`)
+
+	// Act
+	var listed []string
+	var last page
+	path := "/api/v1/urls?limit=2&domain=ejemplo.com"
+	for path != "" {
+		rr := doJSON(t, handler, "GET", path, token, "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		last = page{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &last))
+		for _, url := range last.URLs {
+			listed = append(listed, url.OriginalURL)
+		}
+
+		path = ""
+		if match := nextLink.FindStringSubmatch(rr.Header().Get("Link")); match != nil {
+			path = match[1]
+		}
+	}
+	back := doJSON(t, handler, "GET", "/api/v1/urls?limit=2&domain=ejemplo.com&cursor="+last.PrevCursor, token, "")
+	mismatch := doJSON(t, handler, "GET", "/api/v1/urls?limit=2&cursor="+last.PrevCursor, token, "")
+
+	// Assert
+	assert.Equal(t, []string{
+		"https://www.ejemplo.com/4", "https://www.ejemplo.com/3", "https://www.ejemplo.com/2",
+		"https://www.ejemplo.com/1", "https://www.ejemplo.com/0",
+	}, listed)
+	assert.Empty(t, last.NextCursor)
+
+	var previous page
+	require.NoError(t, json.Unmarshal(back.Body.Bytes(), &previous))
+	require.Len(t, previous.URLs, 2)
+	assert.Equal(t, "https://www.ejemplo.com/2", previous.URLs[0].OriginalURL)
+	assert.Equal(t, "https://www.ejemplo.com/1", previous.URLs[1].OriginalURL)
+
+	assert.Equal(t, http.StatusBadRequest, mismatch.Code)
+	assert.Contains(t, mismatch.Body.String(), "invalid_cursor")
+}
+
+func TestNewServer_BulkShorten(t *testing.T) {
+	// Arrange
+	handler := NewServer(config.Config{Port: 8080, Storage: config.StorageMemory, BulkMaxItems: 3}, nil).Handler
+	token := registerUser(t, handler, "masivo")
+	existing := doJSON(t, handler, "POST", "/api/v1/urls", token, `{"url":"https://www.ejemplo.com/ya"}`)
+	require.Equal(t, http.StatusCreated, existing.Code, existing.Body.String())
+
+	type bulkResponse struct {
+		Created  int `json:"created"`
+		Existing int `json:"existing"`
+		Failed   int `json:"failed"`
+		Skipped  int `json:"skipped"`
+		Results  []struct {
+			Status    string `json:"status"`
+			ShortCode string `json:"short_code"`
+			Error     *struct {
+				Code string `json:"code"`
+			} `json:"error"`
+		} `json:"results"`
+	}
+
+	// Act
+	jsonBody := doJSON(t, handler, "POST", "/api/v1/urls/bulk", token,
+		`[{"url":"https://www.ejemplo.com/nueva"},{"url":"no-es-url"},{"url":"https://www.ejemplo.com/ya"}]`)
+	atomic := doJSON(t, handler, "POST", "/api/v1/urls/bulk?atomic=true", token,
+		`[{"url":"https://www.ejemplo.com/atomica"},{"url":"no-es-url"}]`)
+	csvBody := doJSONWithHeaders(t, handler, "POST", "/api/v1/urls/bulk", token,
+		"id,url\n1,https://www.ejemplo.com/csv\n2,https://www.ejemplo.com/nueva\n", map[string]string{"Content-Type": "text/csv"})
+	tooMany := doJSON(t, handler, "POST", "/api/v1/urls/bulk", token,
+		`[{"url":"https://a.com"},{"url":"https://b.com"},{"url":"https://c.com"},{"url":"https://d.com"}]`)
+	empty := doJSON(t, handler, "POST", "/api/v1/urls/bulk", token, `[]`)
+	listed := doJSON(t, handler, "GET", "/api/v1/urls?q=atomica", token, "")
+
+	// Assert
+	var result bulkResponse
+	require.Equal(t, http.StatusOK, jsonBody.Code, jsonBody.Body.String())
+	require.NoError(t, json.Unmarshal(jsonBody.Body.Bytes(), &result))
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, 1, result.Existing)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, "invalid_url", result.Results[1].Error.Code)
+	assert.NotEmpty(t, result.Results[2].ShortCode)
+
+	result = bulkResponse{}
+	require.Equal(t, http.StatusOK, atomic.Code, atomic.Body.String())
+	require.NoError(t, json.Unmarshal(atomic.Body.Bytes(), &result))
+	assert.Equal(t, 0, result.Created)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, 1, result.Skipped)
+	assert.Contains(t, listed.Body.String(), `"total":0`)
+
+	result = bulkResponse{}
+	require.Equal(t, http.StatusOK, csvBody.Code, csvBody.Body.String())
+	require.NoError(t, json.Unmarshal(csvBody.Body.Bytes(), &result))
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, 1, result.Existing)
+
+	assert.Equal(t, http.StatusBadRequest, tooMany.Code)
+	assert.Contains(t, tooMany.Body.String(), `"rule":"max"`)
+	assert.Equal(t, http.StatusBadRequest, empty.Code)
+	assert.Contains(t, empty.Body.String(), `"rule":"required"`)
+}
+
+func TestNewServer_BatchURLs(t *testing.T) {
+	// Arrange
+	handler := NewServer(config.Config{Port: 8080, Storage: config.StorageMemory}, nil).Handler
+	token := registerUser(t, handler, "lotes")
+	otherToken := registerUser(t, handler, "ajeno")
+	shorten := func(token, url string) string {
+		rr := doJSON(t, handler, "POST", "/api/v1/urls", token, `{"url":"`+url+`"}`)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var created struct {
+			ShortCode string `json:"short_code"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &created))
+		return created.ShortCode
+	}
+	first := shorten(token, "https://www.ejemplo.com/1")
+	second := shorten(token, "https://www.ejemplo.com/2")
+	kept := shorten(token, "https://www.otro.com/3")
+	theirs := shorten(otherToken, "https://www.ejemplo.com/1")
+
+	type batchResponse struct {
+		Committed bool `json:"committed"`
+		Applied   int  `json:"applied"`
+		Failed    int  `json:"failed"`
+		Skipped   int  `json:"skipped"`
+		Results   []struct {
+			ShortCode string `json:"short_code"`
+			Status    string `json:"status"`
+		} `json:"results"`
+	}
+	listed := func(token, query string) string {
+		rr := doJSON(t, handler, "GET", "/api/v1/urls"+query, token, "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		return rr.Body.String()
+	}
+
+	// Act
+	foreign := doJSON(t, handler, "POST", "/api/v1/urls/batch", token,
+		`{"action":"delete","short_codes":["`+first+`","`+theirs+`"]}`)
+	tagged := doJSON(t, handler, "POST", "/api/v1/urls/batch", token,
+		`{"action":"add-tags","filter":{"domain":"ejemplo.com"},"tags":["verano"]}`)
+	transferred := doJSON(t, handler, "POST", "/api/v1/urls/batch", token,
+		`{"action":"change-owner","short_codes":["`+second+`"],"owner":"ajeno"}`)
+	deleted := doJSON(t, handler, "POST", "/api/v1/urls/batch", token,
+		`{"action":"delete","filter":{"domain":"ejemplo.com"}}`)
+	invalid := doJSON(t, handler, "POST", "/api/v1/urls/batch", token,
+		`{"action":"add-tags","short_codes":["`+kept+`"],"filter":{}}`)
+	unknownOwner := doJSON(t, handler, "POST", "/api/v1/urls/batch", token,
+		`{"action":"change-owner","short_codes":["`+kept+`"],"owner":"nadie"}`)
+
+	// Assert
+	var result batchResponse
+	require.Equal(t, http.StatusOK, foreign.Code, foreign.Body.String())
+	require.NoError(t, json.Unmarshal(foreign.Body.Bytes(), &result))
+	assert.False(t, result.Committed)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, 1, result.Skipped)
+	assert.Contains(t, foreign.Body.String(), "url_not_found")
+	assert.Contains(t, listed(otherToken, ""), theirs)
+
+	result = batchResponse{}
+	require.Equal(t, http.StatusOK, tagged.Code, tagged.Body.String())
+	require.NoError(t, json.Unmarshal(tagged.Body.Bytes(), &result))
+	assert.True(t, result.Committed)
+	assert.Equal(t, 2, result.Applied)
+
+	require.Equal(t, http.StatusOK, transferred.Code, transferred.Body.String())
+	assert.Contains(t, listed(otherToken, "?q="+second), `"name":"verano"`)
+
+	result = batchResponse{}
+	require.Equal(t, http.StatusOK, deleted.Code, deleted.Body.String())
+	require.NoError(t, json.Unmarshal(deleted.Body.Bytes(), &result))
+	require.Len(t, result.Results, 1)
+	assert.Equal(t, first, result.Results[0].ShortCode)
+
+	mine := listed(token, "")
+	assert.Contains(t, mine, kept)
+	assert.NotContains(t, mine, first)
+	assert.NotContains(t, mine, second)
+
+	assert.Equal(t, http.StatusBadRequest, invalid.Code)
+	assert.Contains(t, invalid.Body.String(), `"rule":"excluded_with"`)
+	assert.Contains(t, invalid.Body.String(), `"rule":"required_if"`)
+	assert.Equal(t, http.StatusNotFound, unknownOwner.Code)
+	assert.Contains(t, unknownOwner.Body.String(), "user_not_found")
+}
+
+func TestNewServer_BatchFilterOverLimit(t *testing.T) {
+	// Arrange
+	handler := NewServer(config.Config{Port: 8080, Storage: config.StorageMemory, BulkMaxItems: 2}, nil).Handler
+	token := registerUser(t, handler, "lote_grande")
+	for i := 0; i < 3; i++ {
+		rr := doJSON(t, handler, "POST", "/api/v1/urls", token, fmt.Sprintf(`{"url":"https://www.ejemplo.com/%d"}`, i))
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	}
+
+	// Act
+	tooMany := doJSON(t, handler, "POST", "/api/v1/urls/batch", token, `{"action":"delete","filter":{"domain":"ejemplo.com"}}`)
+	withinLimit := doJSON(t, handler, "POST", "/api/v1/urls/batch", token, `{"action":"delete","filter":{"q":"/1"}}`)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, tooMany.Code)
+	assert.Contains(t, tooMany.Body.String(), "batch_too_large")
+	assert.Equal(t, http.StatusOK, withinLimit.Code, withinLimit.Body.String())
+	assert.Contains(t, doJSON(t, handler, "GET", "/api/v1/urls", token, "").Body.String(), `"total":2`)
+}
+
+func TestNewServer_TrashAndRestore(t *testing.T) {
+	// Arrange
+	handler := NewServer(config.Config{Port: 8080, Storage: config.StorageMemory}, nil).Handler
+	token := registerUser(t, handler, "papelera")
+	otherToken := registerUser(t, handler, "curioso")
+	rr := doJSON(t, handler, "POST", "/api/v1/urls", token, `{"url":"https://www.ejemplo.com/borrar"}`)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var created struct {
+		ShortCode string `json:"short_code"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &created))
+	code := created.ShortCode
+
+	// Act
+	foreignDelete := doJSON(t, handler, "DELETE", "/api/v1/urls/"+code, otherToken, "")
+	deleted := doJSON(t, handler, "DELETE", "/api/v1/urls/"+code, token, "")
+	redirect := doJSON(t, handler, "GET", "/"+code, "", "")
+	trash := doJSON(t, handler, "GET", "/api/v1/urls/trash", token, "")
+	foreignTrash := doJSON(t, handler, "GET", "/api/v1/urls/trash", otherToken, "")
+	foreignRestore := doJSON(t, handler, "POST", "/api/v1/urls/"+code+"/restore", otherToken, "")
+	restored := doJSON(t, handler, "POST", "/api/v1/urls/"+code+"/restore", token, "")
+	restoredTwice := doJSON(t, handler, "POST", "/api/v1/urls/"+code+"/restore", token, "")
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, foreignDelete.Code)
+	assert.Equal(t, http.StatusOK, deleted.Code, deleted.Body.String())
+	assert.Equal(t, http.StatusNotFound, redirect.Code)
+
+	require.Equal(t, http.StatusOK, trash.Code, trash.Body.String())
+	assert.Contains(t, trash.Body.String(), code)
+	assert.Contains(t, trash.Body.String(), `"deleted_at":"`)
+	require.Equal(t, http.StatusOK, foreignTrash.Code)
+	assert.NotContains(t, foreignTrash.Body.String(), code)
+
+	assert.Equal(t, http.StatusNotFound, foreignRestore.Code)
+	require.Equal(t, http.StatusOK, restored.Code, restored.Body.String())
+	assert.Contains(t, restored.Body.String(), `"deleted_at":null`)
+	assert.Equal(t, http.StatusNotFound, restoredTwice.Code)
+
+	listed := doJSON(t, handler, "GET", "/api/v1/urls", token, "")
+	assert.Contains(t, listed.Body.String(), code)
+}
+
+func TestNewServer_TagsAndFolders(t *testing.T) {
+	// Arrange
+	handler := NewServer(config.Config{Port: 8080, Storage: config.StorageMemory}, nil).Handler
+	token := registerUser(t, handler, "organizada")
+	otherToken := registerUser(t, handler, "vecina")
+
+	rr := doJSON(t, handler, "POST", "/api/v1/folders", token, `{"name":"Campañas"}`)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var folder struct {
+		ID uint `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &folder))
+	folderID := strconv.FormatUint(uint64(folder.ID), 10)
+
+	rr = doJSON(t, handler, "POST", "/api/v1/urls", token,
+		`{"url":"https://www.ejemplo.com/verano","tags":["verano","viajes"],"folder_id":`+folderID+`}`)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var created struct {
+		ShortCode string `json:"short_code"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &created))
+	rr = doJSON(t, handler, "POST", "/api/v1/urls", token, `{"url":"https://www.ejemplo.com/suelta"}`)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+	listed := func(query string) string {
+		rr := doJSON(t, handler, "GET", "/api/v1/urls"+query, token, "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		return rr.Body.String()
+	}
+
+	// Act
+	byTag := listed("?tag=verano")
+	byFolder := listed("?folder_id=" + folderID)
+	unfiled := listed("?folder_id=0")
+	foreignFolder := doJSON(t, handler, "POST", "/api/v1/urls", otherToken,
+		`{"url":"https://www.ejemplo.com/ajena","folder_id":`+folderID+`}`)
+	duplicate := doJSON(t, handler, "POST", "/api/v1/folders", token, `{"name":"Campañas"}`)
+	tags := doJSON(t, handler, "GET", "/api/v1/tags", token, "")
+	updated := doJSON(t, handler, "PATCH", "/api/v1/urls/"+created.ShortCode, token, `{"tags":["otoño"],"folder_id":0}`)
+	deletedFolder := doJSON(t, handler, "DELETE", "/api/v1/folders/"+folderID, token, "")
+	missingTag := doJSON(t, handler, "DELETE", "/api/v1/tags/abc", token, "")
+
+	// Assert
+	assert.Contains(t, byTag, created.ShortCode)
+	assert.NotContains(t, byTag, "suelta")
+	assert.Contains(t, byFolder, created.ShortCode)
+	assert.NotContains(t, byFolder, "suelta")
+	assert.Contains(t, unfiled, "suelta")
+	assert.NotContains(t, unfiled, created.ShortCode)
+
+	assert.Equal(t, http.StatusNotFound, foreignFolder.Code)
+	assert.Contains(t, foreignFolder.Body.String(), "folder_not_found")
+	assert.Equal(t, http.StatusConflict, duplicate.Code)
+
+	require.Equal(t, http.StatusOK, tags.Code)
+	assert.Contains(t, tags.Body.String(), `"name":"verano"`)
+	assert.Contains(t, tags.Body.String(), `"name":"viajes"`)
+
+	require.Equal(t, http.StatusOK, updated.Code, updated.Body.String())
+	assert.Contains(t, updated.Body.String(), `"folder_id":null`)
+	assert.Contains(t, updated.Body.String(), `"name":"otoño"`)
+	assert.NotContains(t, updated.Body.String(), `"name":"verano"`)
+	assert.Contains(t, listed("?tag=otoño"), created.ShortCode)
+
+	assert.Equal(t, http.StatusOK, deletedFolder.Code)
+	assert.Equal(t, http.StatusNotFound, missingTag.Code)
+	assert.Contains(t, missingTag.Body.String(), "tag_not_found")
+}
+
+// doForm envía un formulario al manejador con las cookies indicadas
+func doForm(t *testing.T, handler http.Handler, path string, form url.Values, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestNewServer_PasswordProtectedLink(t *testing.T) {
+	// Arrange
+	handler := NewServer(config.Config{Port: 8080, Storage: config.StorageMemory}, nil).Handler
+	token := registerUser(t, handler, "discreta")
+	rr := doJSON(t, handler, "POST", "/api/v1/urls", token, `{"url":"https://www.ejemplo.com/privada","password":"s3creto"}`)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	assert.Contains(t, rr.Body.String(), `"protected":true`)
+	assert.NotContains(t, rr.Body.String(), "s3creto")
+	var created struct {
+		ShortCode string `json:"short_code"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &created))
+	path := "/" + created.ShortCode
+
+	// Act
+	prompt := doJSON(t, handler, "GET", path, "", "")
+	wrong := doForm(t, handler, path, url.Values{"password": {"otra"}})
+	unlocked := doForm(t, handler, path, url.Values{"password": {"s3creto"}})
+	cookies := unlocked.Result().Cookies()
+	require.Len(t, cookies, 1)
+	req := httptest.NewRequest("GET", path, nil)
+	req.AddCookie(cookies[0])
+	redirect := httptest.NewRecorder()
+	handler.ServeHTTP(redirect, req)
+	info := doJSON(t, handler, "GET", "/api/v1/urls"+path, token, "")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, prompt.Code)
+	assert.Contains(t, prompt.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, prompt.Body.String(), `name="password"`)
+	assert.Equal(t, "no-store", prompt.Header().Get("Cache-Control"))
+
+	assert.Equal(t, http.StatusForbidden, wrong.Code)
+	assert.Contains(t, wrong.Body.String(), `role="alert"`)
+	assert.Empty(t, wrong.Result().Cookies())
+
+	assert.Equal(t, http.StatusSeeOther, unlocked.Code)
+	assert.Equal(t, path, unlocked.Header().Get("Location"))
+	assert.Equal(t, "link_access", cookies[0].Name)
+	assert.Equal(t, path, cookies[0].Path)
+	assert.True(t, cookies[0].HttpOnly)
+
+	assert.Equal(t, http.StatusMovedPermanently, redirect.Code)
+	assert.Equal(t, "https://www.ejemplo.com/privada", redirect.Header().Get("Location"))
+	assert.Contains(t, info.Body.String(), `"visits":1`)
+}
+
+func TestNewServer_OneTimeLink(t *testing.T) {
+	// Arrange
+	handler := NewServer(config.Config{Port: 8080, Storage: config.StorageMemory}, nil).Handler
+	token := registerUser(t, handler, "efimera")
+	rr := doJSON(t, handler, "POST", "/api/v1/urls", token, `{"url":"https://www.ejemplo.com/descarga","max_visits":1}`)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	assert.Contains(t, rr.Body.String(), `"max_visits":1`)
+	var created struct {
+		ShortCode string `json:"short_code"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &created))
+	public := doJSON(t, handler, "POST", "/api/v1/urls", token, `{"url":"https://www.ejemplo.com/descarga"}`)
+
+	// Act
+	first := doJSON(t, handler, "GET", "/"+created.ShortCode, "", "")
+	second := doJSON(t, handler, "GET", "/"+created.ShortCode, "", "")
+	raised := doJSON(t, handler, "PATCH", "/api/v1/urls/"+created.ShortCode, token, `{"max_visits":2}`)
+	third := doJSON(t, handler, "GET", "/"+created.ShortCode, "", "")
+
+	// Assert
+	assert.Equal(t, http.StatusMovedPermanently, first.Code)
+	assert.Equal(t, http.StatusGone, second.Code)
+	assert.Contains(t, second.Body.String(), "url_exhausted")
+	require.Equal(t, http.StatusOK, raised.Code, raised.Body.String())
+	assert.Equal(t, http.StatusMovedPermanently, third.Code)
+
+	require.Equal(t, http.StatusCreated, public.Code)
+	assert.NotContains(t, public.Body.String(), created.ShortCode)
+}
+
+func TestNewServer_ScheduledLinks(t *testing.T) {
+	// Arrange
+	handler := NewServer(config.Config{Port: 8080, Storage: config.StorageMemory}, nil).Handler
+	token := registerUser(t, handler, "campañas")
+	launch := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second).Format(time.RFC3339)
+	shorten := func(body string) string {
+		rr := doJSON(t, handler, "POST", "/api/v1/urls", token, body)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var created struct {
+			ShortCode string `json:"short_code"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &created))
+		return created.ShortCode
+	}
+	pending := shorten(`{"url":"https://www.ejemplo.com/lanzamiento","active_from":"` + launch + `"}`)
+	teaser := shorten(`{"url":"https://www.ejemplo.com/lanzamiento","active_from":"` + launch + `","fallback_url":"https://www.ejemplo.com/pronto"}`)
+	live := shorten(`{"url":"https://www.ejemplo.com/ya"}`)
+
+	// Act
+	page := doJSON(t, handler, "GET", "/"+pending, "", "")
+	fallback := doJSON(t, handler, "GET", "/"+teaser, "", "")
+	scheduled := doJSON(t, handler, "GET", "/api/v1/urls?state=scheduled", token, "")
+	invalid := doJSON(t, handler, "POST", "/api/v1/urls", token,
+		`{"url":"https://www.ejemplo.com/x","active_from":"`+launch+`","active_until":"`+launch+`"}`)
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, page.Code)
+	assert.Contains(t, page.Header().Get("Content-Type"), "text/html")
+	assert.NotEmpty(t, page.Header().Get("Retry-After"))
+	assert.Contains(t, page.Body.String(), launch)
+
+	assert.Equal(t, http.StatusFound, fallback.Code)
+	assert.Equal(t, "https://www.ejemplo.com/pronto", fallback.Header().Get("Location"))
+	assert.Equal(t, "no-store", fallback.Header().Get("Cache-Control"))
+
+	require.Equal(t, http.StatusOK, scheduled.Code, scheduled.Body.String())
+	assert.Contains(t, scheduled.Body.String(), `"total":2`)
+	assert.NotContains(t, scheduled.Body.String(), live)
+
+	assert.Equal(t, http.StatusBadRequest, invalid.Code)
+	assert.Contains(t, invalid.Body.String(), "invalid_schedule")
+}
+
+func TestNewServer_RedirectCodes(t *testing.T) {
+	// Arrange
+	handler := NewServer(config.Config{
+		Port:           8080,
+		Storage:        config.StorageMemory,
+		RedirectCode:   http.StatusFound,
+		RedirectMaxAge: time.Hour,
+	}, nil).Handler
+	token := registerUser(t, handler, "analitica")
+	shorten := func(body string) string {
+		rr := doJSON(t, handler, "POST", "/api/v1/urls", token, body)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var created struct {
+			ShortCode string `json:"short_code"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &created))
+		return created.ShortCode
+	}
+	byDefault := shorten(`{"url":"https://www.ejemplo.com/por-defecto"}`)
+	permanent := shorten(`{"url":"https://www.ejemplo.com/permanente","redirect_code":308}`)
+
+	// Act
+	defaultRedirect := doJSON(t, handler, "GET", "/"+byDefault, "", "")
+	permanentRedirect := doJSON(t, handler, "GET", "/"+permanent, "", "")
+	changed := doJSON(t, handler, "PATCH", "/api/v1/urls/"+permanent, token, `{"redirect_code":307}`)
+	changedRedirect := doJSON(t, handler, "GET", "/"+permanent, "", "")
+	invalid := doJSON(t, handler, "POST", "/api/v1/urls", token, `{"url":"https://www.ejemplo.com/x","redirect_code":303}`)
+
+	// Assert
+	assert.Equal(t, http.StatusFound, defaultRedirect.Code)
+	assert.Equal(t, "no-store", defaultRedirect.Header().Get("Cache-Control"))
+
+	assert.Equal(t, http.StatusPermanentRedirect, permanentRedirect.Code)
+	assert.Equal(t, "public, max-age=3600", permanentRedirect.Header().Get("Cache-Control"))
+	assert.NotEmpty(t, permanentRedirect.Header().Get("Expires"))
+
+	require.Equal(t, http.StatusOK, changed.Code, changed.Body.String())
+	assert.Equal(t, http.StatusTemporaryRedirect, changedRedirect.Code)
+	assert.Equal(t, "https://www.ejemplo.com/permanente", changedRedirect.Header().Get("Location"))
+
+	assert.Equal(t, http.StatusBadRequest, invalid.Code)
+}
+
+func TestNewServer_Passthrough(t *testing.T) {
+	// Arrange
+	handler := NewServer(config.Config{Port: 8080, Storage: config.StorageMemory}, nil).Handler
+	token := registerUser(t, handler, "campanas")
+	shorten := func(body string) string {
+		rr := doJSON(t, handler, "POST", "/api/v1/urls", token, body)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var created struct {
+			ShortCode string `json:"short_code"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &created))
+		return created.ShortCode
+	}
+	forwarding := shorten(`{"url":"https://www.ejemplo.com/docs?lang=es","forward_query":"keep","forward_path":true}`)
+	plain := shorten(`{"url":"https://www.ejemplo.com/docs?lang=es"}`)
+
+	// Act
+	forwarded := doJSON(t, handler, "GET", "/"+forwarding+"/guia/inicio?utm_source=x&lang=en", "", "")
+	ignored := doJSON(t, handler, "GET", "/"+plain+"/guia/inicio?utm_source=x", "", "")
+	changed := doJSON(t, handler, "PATCH", "/api/v1/urls/"+forwarding, token, `{"forward_query":"none","forward_path":false}`)
+	switchedOff := doJSON(t, handler, "GET", "/"+forwarding+"/guia?utm_source=x", "", "")
+	invalid := doJSON(t, handler, "POST", "/api/v1/urls", token, `{"url":"https://www.ejemplo.com/x","forward_query":"merge"}`)
+
+	// Assert
+	assert.Equal(t, "https://www.ejemplo.com/docs/guia/inicio?lang=es&utm_source=x", forwarded.Header().Get("Location"))
+	assert.Equal(t, "https://www.ejemplo.com/docs?lang=es", ignored.Header().Get("Location"))
+	require.Equal(t, http.StatusOK, changed.Code, changed.Body.String())
+	assert.Equal(t, "https://www.ejemplo.com/docs?lang=es", switchedOff.Header().Get("Location"))
+	assert.Equal(t, http.StatusBadRequest, invalid.Code)
+}
+
+func TestNewServer_DestinationTemplate(t *testing.T) {
+	// Arrange
+	handler := NewServer(config.Config{Port: 8080, Storage: config.StorageMemory}, nil).Handler
+	token := registerUser(t, handler, "plantillas")
+	created := doJSON(t, handler, "POST", "/api/v1/urls", token,
+		`{"url":"https://docs.ejemplo.com/{path}?lang={query.lang|es}","template":true}`)
+	require.Equal(t, http.StatusCreated, created.Code, created.Body.String())
+	var link struct {
+		ShortCode string `json:"short_code"`
+		Template  bool   `json:"template"`
+	}
+	require.NoError(t, json.Unmarshal(created.Body.Bytes(), &link))
+
+	// Act
+	withDefaults := doJSON(t, handler, "GET", "/"+link.ShortCode, "", "")
+	filled := doJSON(t, handler, "GET", "/"+link.ShortCode+"/guia/inicio?lang=en", "", "")
+	invalid := doJSON(t, handler, "POST", "/api/v1/urls", token,
+		`{"url":"https://docs.ejemplo.com/{usuario}","template":true}`)
+
+	// Assert
+	assert.True(t, link.Template)
+	assert.Equal(t, "https://docs.ejemplo.com/?lang=es", withDefaults.Header().Get("Location"))
+	assert.Equal(t, "https://docs.ejemplo.com/guia/inicio?lang=en", filled.Header().Get("Location"))
+	assert.Equal(t, http.StatusBadRequest, invalid.Code)
+	assert.Contains(t, invalid.Body.String(), "invalid_template")
+}
+
+func TestNewServer_DeviceRules(t *testing.T) {
+	// Arrange
+	handler := NewServer(config.Config{Port: 8080, Storage: config.StorageMemory, RedirectMaxAge: time.Hour}, nil).Handler
+	token := registerUser(t, handler, "apps")
+	created := doJSON(t, handler, "POST", "/api/v1/urls", token, `{"url":"https://www.ejemplo.com/app","device_rules":[
+		{"platform":"ios","destination":"https://apps.apple.com/app/id123456789"},
+		{"platform":"android","destination":"https://play.google.com/store/apps/details?id=com.ejemplo"}]}`)
+	require.Equal(t, http.StatusCreated, created.Code, created.Body.String())
+	var link struct {
+		ShortCode   string `json:"short_code"`
+		DeviceRules []struct {
+			Platform string `json:"platform"`
+		} `json:"device_rules"`
+	}
+	require.NoError(t, json.Unmarshal(created.Body.Bytes(), &link))
+	visit := func(userAgent string) *httptest.ResponseRecorder {
+		return doJSONWithHeaders(t, handler, "GET", "/"+link.ShortCode, "", "", map[string]string{"User-Agent": userAgent})
+	}
+
+	// Act
+	iphone := visit("Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X)")
+	android := visit("Mozilla/5.0 (Linux; Android 14; Pixel 8)")
+	desktop := visit("Mozilla/5.0 (X11; Linux x86_64) Firefox/130.0")
+	cleared := doJSON(t, handler, "PATCH", "/api/v1/urls/"+link.ShortCode, token, `{"device_rules":[]}`)
+	afterClearing := visit("Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X)")
+	invalid := doJSON(t, handler, "POST", "/api/v1/urls", token,
+		`{"url":"https://www.ejemplo.com/x","device_rules":[{"platform":"ios","destination":"javascript:alert(1)"}]}`)
+
+	// Assert
+	assert.Len(t, link.DeviceRules, 2)
+	assert.Equal(t, "https://apps.apple.com/app/id123456789", iphone.Header().Get("Location"))
+	assert.Equal(t, "no-store", iphone.Header().Get("Cache-Control"))
+	assert.Equal(t, "https://play.google.com/store/apps/details?id=com.ejemplo", android.Header().Get("Location"))
+	assert.Equal(t, "https://www.ejemplo.com/app", desktop.Header().Get("Location"))
+	require.Equal(t, http.StatusOK, cleared.Code, cleared.Body.String())
+	assert.Contains(t, cleared.Body.String(), `"device_rules":[]`)
+	assert.Equal(t, "https://www.ejemplo.com/app", afterClearing.Header().Get("Location"))
+	assert.Equal(t, http.StatusBadRequest, invalid.Code)
+	assert.Contains(t, invalid.Body.String(), "invalid_device_rule")
+}
+
+func TestNewServer_ExpiredLink(t *testing.T) {
+	// Arrange
+	handler := NewServer(config.Config{Port: 8080, Storage: config.StorageMemory}, nil).Handler
+	token := registerUser(t, handler, "caducidad")
+	created := doJSON(t, handler, "POST", "/api/v1/urls", token, `{"url":"https://www.ejemplo.com/oferta"}`)
+	require.Equal(t, http.StatusCreated, created.Code, created.Body.String())
+	var link struct {
+		ShortCode string `json:"short_code"`
+	}
+	require.NoError(t, json.Unmarshal(created.Body.Bytes(), &link))
+	expired := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+	batch := doJSON(t, handler, "POST", "/api/v1/urls/batch", token,
+		fmt.Sprintf(`{"action":"set-expiry","short_codes":["%s"],"expires_at":"%s"}`, link.ShortCode, expired))
+	require.Equal(t, http.StatusOK, batch.Code, batch.Body.String())
+
+	// Act
+	rr := doJSON(t, handler, "GET", "/"+link.ShortCode, "", "")
+	reshortened := doJSON(t, handler, "POST", "/api/v1/urls", token, `{"url":"https://www.ejemplo.com/oferta"}`)
+
+	// Assert
+	assert.Equal(t, http.StatusGone, rr.Code)
+	assert.Contains(t, rr.Body.String(), "url_ended")
+
+	require.Equal(t, http.StatusCreated, reshortened.Code, reshortened.Body.String())
+	var fresh struct {
+		ShortCode string `json:"short_code"`
+	}
+	require.NoError(t, json.Unmarshal(reshortened.Body.Bytes(), &fresh))
+	assert.NotEqual(t, link.ShortCode, fresh.ShortCode)
+	assert.Equal(t, http.StatusMovedPermanently, doJSON(t, handler, "GET", "/"+fresh.ShortCode, "", "").Code)
+}
+
+func TestNewServer_BulkShortenRejectsSettingsAndLargeBodies(t *testing.T) {
+	// Arrange
+	handler := NewServer(config.Config{Port: 8080, Storage: config.StorageMemory, BulkMaxItems: 2}, nil).Handler
+	token := registerUser(t, handler, "masivo_ajustes")
+	padding := strings.Repeat(" ", 2*8<<10)
+
+	// Act
+	withSettings := doJSON(t, handler, "POST", "/api/v1/urls/bulk", token,
+		`[{"url":"https://www.ejemplo.com/privada","password":"s3creto"}]`)
+	tooLarge := doJSON(t, handler, "POST", "/api/v1/urls/bulk", token, `[`+padding+`{"url":"https://a.com"}]`)
+	csvTooLarge := doJSONWithHeaders(t, handler, "POST", "/api/v1/urls/bulk", token,
+		"https://a.com/"+strings.Repeat("a", 2*8<<10)+"\n", map[string]string{"Content-Type": "text/csv"})
+	var upload bytes.Buffer
+	form := multipart.NewWriter(&upload)
+	file, err := form.CreateFormFile("file", "urls.csv")
+	require.NoError(t, err)
+	_, err = file.Write([]byte("https://a.com/" + strings.Repeat("a", 2*8<<10) + "\n"))
+	require.NoError(t, err)
+	require.NoError(t, form.Close())
+	uploadTooLarge := doJSONWithHeaders(t, handler, "POST", "/api/v1/urls/bulk", token,
+		upload.String(), map[string]string{"Content-Type": form.FormDataContentType()})
+	listed := doJSON(t, handler, "GET", "/api/v1/urls?q=privada", token, "")
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, withSettings.Code)
+	assert.Contains(t, withSettings.Body.String(), "malformed_body")
+	assert.Contains(t, withSettings.Body.String(), "password")
+	assert.Contains(t, listed.Body.String(), `"total":0`)
+	assert.Equal(t, http.StatusBadRequest, tooLarge.Code)
+	assert.Contains(t, tooLarge.Body.String(), `"rule":"max"`)
+	assert.Equal(t, http.StatusBadRequest, csvTooLarge.Code)
+	assert.Contains(t, csvTooLarge.Body.String(), `"rule":"max"`)
+	assert.Equal(t, http.StatusBadRequest, uploadTooLarge.Code)
+	assert.Contains(t, uploadTooLarge.Body.String(), `"rule":"max"`)
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"tiny-url/internal/domain/ports"
+	"tiny-url/internal/health"
+)
+
+// trashPurge elimina periódicamente las URLs que llevan más de retention en la
+// papelera, latiendo en heartbeat tras cada pasada
+type trashPurge struct {
+	urlService ports.URLService
+	retention  time.Duration
+	interval   time.Duration
+	heartbeat  *health.Heartbeat
+	now        func() time.Time
+}
+
+// startTrashPurge arranca la purga de la papelera y registra su latido como
+// comprobación de disponibilidad. Devuelve la función que la detiene. Sin
+// retención o sin intervalo no se purga nada.
+func startTrashPurge(urlService ports.URLService, registry *health.Registry, retention, interval time.Duration) func() {
+	if retention <= 0 || interval <= 0 {
+		return func() {}
+	}
+
+	// Se da margen para una pasada lenta antes de declarar caído el proceso
+	heartbeat := health.NewHeartbeat(2 * interval)
+	registry.Register("trash_purge", heartbeat)
+
+	purge := &trashPurge{
+		urlService: urlService,
+		retention:  retention,
+		interval:   interval,
+		heartbeat:  heartbeat,
+		now:        time.Now,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	heartbeat.Start()
+	go purge.run(ctx)
+
+	return func() {
+		cancel()
+		heartbeat.Stop()
+	}
+}
+
+// run purga la papelera al arrancar y después cada intervalo hasta que ctx termina
+func (p *trashPurge) run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.tick(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick hace una pasada de la purga y late aunque falle. El error ya queda en
+// el log; la base de datos tiene su propia comprobación de disponibilidad.
+func (p *trashPurge) tick(ctx context.Context) {
+	_ = p.purgeOnce(ctx)
+	p.heartbeat.Beat()
+}
+
+// purgeOnce elimina las URLs cuya retención ha vencido
+func (p *trashPurge) purgeOnce(ctx context.Context) error {
+	purged, err := p.urlService.PurgeDeletedURLs(ctx, p.now().Add(-p.retention))
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		log.Printf("Failed to purge deleted URLs: %v", err)
+		return err
+	}
+	if purged > 0 {
+		log.Printf("Purged %d deleted URLs", purged)
+	}
+	return nil
+}
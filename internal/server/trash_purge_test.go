@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"tiny-url/internal/domain/ports/mocks"
+	"tiny-url/internal/health"
+)
+
+func TestTrashPurge_PurgesBeforeRetention(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	urlService := mocks.NewMockURLService(t)
+	purge := &trashPurge{
+		urlService: urlService,
+		retention:  24 * time.Hour,
+		interval:   time.Hour,
+		heartbeat:  health.NewHeartbeat(2 * time.Hour),
+		now:        func() time.Time { return now },
+	}
+	urlService.EXPECT().PurgeDeletedURLs(context.Background(), now.Add(-24*time.Hour)).Return(int64(3), nil)
+
+	// Act
+	err := purge.purgeOnce(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestTrashPurge_ReportsFailures(t *testing.T) {
+	// Arrange
+	urlService := mocks.NewMockURLService(t)
+	purge := &trashPurge{
+		urlService: urlService,
+		retention:  time.Hour,
+		interval:   time.Minute,
+		now:        time.Now,
+	}
+	failure := errors.New("database is locked")
+	urlService.EXPECT().PurgeDeletedURLs(context.Background(), mock.AnythingOfType("time.Time")).Return(int64(0), failure)
+
+	// Act
+	err := purge.purgeOnce(context.Background())
+
+	// Assert
+	assert.Equal(t, failure, err)
+}
+
+func TestStartTrashPurge_DisabledWithoutRetention(t *testing.T) {
+	// Arrange
+	registry := health.NewRegistry(time.Second)
+
+	// Act
+	stop := startTrashPurge(nil, registry, 0, time.Hour)
+	stop()
+
+	// Assert
+	assert.Empty(t, registry.Names())
+}
+
+func TestTrashPurge_FailureKeepsReadiness(t *testing.T) {
+	// Arrange
+	urlService := mocks.NewMockURLService(t)
+	registry := health.NewRegistry(time.Second)
+	heartbeat := health.NewHeartbeat(2 * time.Minute)
+	registry.Register("database", health.CheckerFunc(func(context.Context) error { return nil }))
+	registry.Register("trash_purge", heartbeat)
+	purge := &trashPurge{
+		urlService: urlService,
+		retention:  time.Hour,
+		interval:   time.Minute,
+		heartbeat:  heartbeat,
+		now:        time.Now,
+	}
+	heartbeat.Start()
+	urlService.EXPECT().PurgeDeletedURLs(context.Background(), mock.AnythingOfType("time.Time")).
+		Return(int64(0), errors.New("connection reset by peer"))
+
+	// Act
+	purge.tick(context.Background())
+	report := registry.Run(context.Background())
+
+	// Assert
+	assert.True(t, report.Healthy(), "un fallo pasajero de la purga no retira la réplica")
+}
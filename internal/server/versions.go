@@ -0,0 +1,194 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+
+	"tiny-url/internal/adapters/handlers"
+)
+
+// APIVersion describe un conjunto de rutas publicado bajo /api/<Name>.
+// Varias versiones pueden servirse a la vez mientras los clientes migran.
+type APIVersion struct {
+	// Name es el segmento de la ruta, por ejemplo "v1"
+	Name string
+
+	// Register añade las rutas de la versión al grupo recibido
+	Register func(rg *gin.RouterGroup)
+
+	// Docs es el nombre de la instancia de swag con la documentación de la
+	// versión; vacío si no tiene documentación
+	Docs string
+
+	// DeprecatedAt, si no es cero, marca la versión como obsoleta desde esa fecha
+	DeprecatedAt time.Time
+
+	// SunsetAt, si no es cero, es la fecha prevista para retirar la versión
+	SunsetAt time.Time
+
+	// Successor es la ruta base de la versión que sustituye a esta
+	Successor string
+}
+
+// legacyDeprecatedAt es la fecha en que las rutas sin versión pasaron a ser obsoletas
+var legacyDeprecatedAt = time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+
+// apiVersions devuelve las versiones publicadas de la API. Para añadir una
+// versión nueva basta con registrar aquí su conjunto de manejadores; marcar
+// la anterior con DeprecatedAt avisa a los clientes que la siguen usando.
+func (s *Server) apiVersions() []APIVersion {
+	return []APIVersion{
+		{Name: "v1", Register: s.registerV1, Docs: "v1"},
+	}
+}
+
+// mountAPIVersions monta cada versión bajo /api/<Name>
+func (s *Server) mountAPIVersions(r *gin.Engine, versions []APIVersion) {
+	for _, version := range versions {
+		group := r.Group("/api/" + version.Name)
+		if !version.DeprecatedAt.IsZero() {
+			group.Use(DeprecationMiddleware(version))
+		}
+		version.Register(group)
+	}
+}
+
+// mountLegacyRoutes mantiene las rutas anteriores al versionado (/auth/* y
+// /api/*) sobre los manejadores de v1, anunciando su retirada
+func (s *Server) mountLegacyRoutes(r *gin.Engine) {
+	legacy := APIVersion{
+		Name:         "legacy",
+		DeprecatedAt: legacyDeprecatedAt,
+		SunsetAt:     s.legacySunset,
+		Successor:    "/api/v1",
+	}
+
+	group := r.Group("", DeprecationMiddleware(legacy))
+	s.registerAuthRoutes(group.Group("/auth"))
+	s.registerResourceRoutes(group.Group("/api"))
+}
+
+// mountSwagger sirve la documentación de cada versión en /swagger/<Name>/
+func mountSwagger(r *gin.Engine, versions []APIVersion) {
+	var latest string
+	for _, version := range versions {
+		if version.Docs == "" {
+			continue
+		}
+		r.GET("/swagger/"+version.Name+"/*any",
+			ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.InstanceName(version.Docs)))
+		latest = version.Name
+	}
+
+	// /swagger lleva a la documentación de la versión más reciente
+	if latest != "" {
+		r.GET("/swagger", func(c *gin.Context) {
+			c.Redirect(http.StatusFound, "/swagger/"+latest+"/index.html")
+		})
+	}
+}
+
+// DeprecationMiddleware anuncia que una versión está obsoleta con las cabeceras
+// Deprecation (RFC 9745), Sunset (RFC 8594) y un enlace a la versión sucesora
+func DeprecationMiddleware(version APIVersion) gin.HandlerFunc {
+	deprecation := fmt.Sprintf("@%d", version.DeprecatedAt.Unix())
+	return func(c *gin.Context) {
+		c.Header("Deprecation", deprecation)
+		if !version.SunsetAt.IsZero() {
+			c.Header("Sunset", version.SunsetAt.UTC().Format(http.TimeFormat))
+		}
+		if version.Successor != "" {
+			c.Header("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, version.Successor))
+		}
+		c.Next()
+	}
+}
+
+// registerAuthRoutes añade las rutas públicas de autenticación
+func (s *Server) registerAuthRoutes(auth *gin.RouterGroup) {
+	authHandler := handlers.NewAuthHandler(s.authService)
+
+	auth.POST("/register", authHandler.Register)
+	auth.POST("/login", authHandler.Login)
+}
+
+// registerResourceRoutes añade las rutas protegidas de perfil y URLs
+func (s *Server) registerResourceRoutes(api *gin.RouterGroup) {
+	urlHandler := handlers.NewURLHandler(s.urlService,
+		handlers.WithCursorSecret(s.cursorSecret),
+		handlers.WithBulkLimit(s.bulkMaxItems),
+	)
+	authHandler := handlers.NewAuthHandler(s.authService)
+	tagHandler := handlers.NewTagHandler(s.tagService)
+	folderHandler := handlers.NewFolderHandler(s.folderService)
+
+	// Middleware de autenticación para rutas protegidas
+	authRequired := AuthMiddleware(s.authService)
+
+	// Idioma preferido del usuario autenticado para los mensajes
+	userLanguage := UserLanguageMiddleware(s.authService)
+
+	// Repetición de las modificaciones reintentadas con Idempotency-Key
+	idempotent := IdempotencyMiddleware(s.idempotencyRepo, s.idempotencyWindow, s.idempotencyLease, handlers.BulkMaxBytes(s.bulkMaxItems))
+
+	// Ruta de perfil de usuario (requiere autenticación)
+	api.GET("/profile", authRequired, userLanguage, authHandler.GetUserProfile)
+	api.PATCH("/profile", authRequired, userLanguage, idempotent, authHandler.UpdateProfile)
+
+	// Rutas para URLs (requieren autenticación)
+	urls := api.Group("/urls")
+	urls.Use(authRequired, userLanguage, idempotent) // Aplicar middleware de autenticación a todas las rutas de URLs
+	{
+		// Acortar URL
+		urls.POST("", urlHandler.ShortenURL)
+
+		// Acortar varias URLs a la vez
+		urls.POST("/bulk", urlHandler.BulkShortenURLs)
+
+		// Operar sobre varias URLs a la vez
+		urls.POST("/batch", urlHandler.BatchURLs)
+
+		// Listar todas las URLs acortadas
+		urls.GET("", urlHandler.ListURLs)
+
+		// Listar las URLs de la papelera
+		urls.GET("/trash", urlHandler.TrashURLs)
+
+		// Obtener información de una URL acortada
+		urls.GET("/:shortCode", urlHandler.GetURLInfo)
+
+		// Modificar las etiquetas o la carpeta de una URL
+		urls.PATCH("/:shortCode", urlHandler.UpdateURL)
+
+		// Eliminar una URL acortada
+		urls.DELETE("/:shortCode", urlHandler.DeleteURL)
+
+		// Restaurar una URL de la papelera
+		urls.POST("/:shortCode/restore", urlHandler.RestoreURL)
+	}
+
+	// Etiquetas del usuario (requieren autenticación)
+	tags := api.Group("/tags")
+	tags.Use(authRequired, userLanguage, idempotent)
+	{
+		tags.GET("", tagHandler.ListTags)
+		tags.POST("", tagHandler.CreateTag)
+		tags.PATCH("/:id", tagHandler.RenameTag)
+		tags.DELETE("/:id", tagHandler.DeleteTag)
+	}
+
+	// Carpetas del usuario (requieren autenticación)
+	folders := api.Group("/folders")
+	folders.Use(authRequired, userLanguage, idempotent)
+	{
+		folders.GET("", folderHandler.ListFolders)
+		folders.POST("", folderHandler.CreateFolder)
+		folders.PATCH("/:id", folderHandler.RenameFolder)
+		folders.DELETE("/:id", folderHandler.DeleteFolder)
+	}
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"tiny-url/internal/config"
+)
+
+func TestVersionedRoutes(t *testing.T) {
+	// Arrange
+	sunset := time.Date(2027, time.April, 1, 0, 0, 0, 0, time.UTC)
+	handler := NewServer(config.Config{Port: 8080, Storage: config.StorageMemory, LegacyAPISunset: sunset}, nil).Handler
+	token := registerUser(t, handler, "versiones")
+
+	// Act
+	current := doJSON(t, handler, "GET", "/api/v1/profile", token, "")
+	legacy := doJSON(t, handler, "GET", "/api/profile", token, "")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, current.Code, current.Body.String())
+	assert.Empty(t, current.Header().Get("Deprecation"))
+
+	assert.Equal(t, http.StatusOK, legacy.Code, legacy.Body.String())
+	assert.Equal(t, "@1792281600", legacy.Header().Get("Deprecation"))
+	assert.Equal(t, "Thu, 01 Apr 2027 00:00:00 GMT", legacy.Header().Get("Sunset"))
+	assert.Equal(t, `</api/v1>; rel="successor-version"`, legacy.Header().Get("Link"))
+}
+
+func TestMountAPIVersions_SideBySide(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	ping := func(name string) func(rg *gin.RouterGroup) {
+		return func(rg *gin.RouterGroup) {
+			rg.GET("/ping", func(c *gin.Context) { c.String(http.StatusOK, name) })
+		}
+	}
+	versions := []APIVersion{
+		{Name: "v1", Register: ping("v1"), DeprecatedAt: legacyDeprecatedAt, Successor: "/api/v2"},
+		{Name: "v2", Register: ping("v2")},
+	}
+	(&Server{}).mountAPIVersions(r, versions)
+
+	// Act
+	v1 := doJSON(t, r, "GET", "/api/v1/ping", "", "")
+	v2 := doJSON(t, r, "GET", "/api/v2/ping", "", "")
+
+	// Assert
+	assert.Equal(t, "v1", v1.Body.String())
+	assert.NotEmpty(t, v1.Header().Get("Deprecation"))
+	assert.Equal(t, `</api/v2>; rel="successor-version"`, v1.Header().Get("Link"))
+	assert.Empty(t, v1.Header().Get("Sunset"))
+
+	assert.Equal(t, "v2", v2.Body.String())
+	assert.Empty(t, v2.Header().Get("Deprecation"))
+}
+
+func TestMountSwagger_RedirectsToLatest(t *testing.T) {
+	// Arrange
+	handler := NewServer(config.Config{Port: 8080, Storage: config.StorageMemory}, nil).Handler
+
+	// Act
+	redirect := doJSON(t, handler, "GET", "/swagger", "", "")
+	doc := doJSON(t, handler, "GET", "/swagger/v1/doc.json", "", "")
+
+	// Assert
+	assert.Equal(t, http.StatusFound, redirect.Code)
+	assert.Equal(t, "/swagger/v1/index.html", redirect.Header().Get("Location"))
+	assert.Equal(t, http.StatusOK, doc.Code)
+	assert.Contains(t, doc.Body.String(), "/api/v1")
+}
+
+func TestVersionedRoutes_LegacyListKeepsSuccessorLink(t *testing.T) {
+	// Arrange
+	handler := NewServer(config.Config{Port: 8080, Storage: config.StorageMemory}, nil).Handler
+	token := registerUser(t, handler, "enlaces")
+	for i := 0; i < 3; i++ {
+		rr := doJSON(t, handler, "POST", "/api/urls", token, fmt.Sprintf(`{"url":"https://www.ejemplo.com/%d"}`, i))
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	}
+
+	// Act
+	rr := doJSON(t, handler, "GET", "/api/urls?limit=2", token, "")
+
+	// Assert
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	links := strings.Join(rr.Header().Values("Link"), ", ")
+	assert.Contains(t, links, `</api/v1>; rel="successor-version"`)
+	assert.Contains(t, links, `rel="first"`)
+	assert.Contains(t, links, `rel="next"`)
+}
@@ -14,6 +14,7 @@ import (
 
 	"tiny-url/internal/adapters/handlers"
 	"tiny-url/internal/adapters/repository"
+	"tiny-url/internal/database/migrations"
 	"tiny-url/internal/domain/model"
 	"tiny-url/internal/domain/service"
 
@@ -42,8 +43,8 @@ func setupTestWithTransaction(t *testing.T) (*gorm.DB, *gin.Engine, string, func
 	userRepo := repository.NewUserRepository(tx)
 
 	// Inicializar los servicios
-	urlService := service.NewURLService(urlRepo)
-	authService := service.NewAuthService(userRepo)
+	urlService := service.NewURLService(urlRepo, repository.NewUnitOfWork(tx))
+	authService := service.NewAuthService(userRepo, repository.NewUnitOfWork(tx))
 
 	// Generar datos únicos para el test
 	timestamp := time.Now().UnixNano()
@@ -58,11 +59,11 @@ func setupTestWithTransaction(t *testing.T) (*gorm.DB, *gin.Engine, string, func
 		Password: testPassword,
 	}
 
-	err := userRepo.CreateUser(testUser)
+	err := userRepo.CreateUser(context.Background(), testUser)
 	require.NoError(t, err)
 
 	// Obtener un token para las pruebas
-	testToken, err := authService.Login(testUsername, testPassword)
+	testToken, err := authService.Login(context.Background(), testUsername, testPassword)
 	require.NoError(t, err)
 
 	// Configurar el router para las pruebas
@@ -197,14 +198,22 @@ func TestMain(m *testing.M) {
 		host, port.Port())
 
 	// Configurar la conexión a la base de datos
-	testDB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	testDB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 
-	// Migrar los modelos
-	if err := testDB.AutoMigrate(&model.URL{}, &model.User{}); err != nil {
-		log.Fatalf("Failed to migrate models: %v", err)
+	// Aplicar las migraciones del esquema
+	sqlDB, err := testDB.DB()
+	if err != nil {
+		log.Fatalf("Failed to get database connection: %v", err)
+	}
+	migrator, err := migrations.NewMigrator(sqlDB, migrations.Postgres)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		log.Fatalf("Failed to migrate schema: %v", err)
 	}
 
 	// Ejecutar las pruebas
@@ -271,7 +280,7 @@ func TestAuthHandler_Login(t *testing.T) {
 		Email:    email,
 		Password: password,
 	}
-	err := userRepo.CreateUser(user)
+	err := userRepo.CreateUser(context.Background(), user)
 	require.NoError(t, err)
 
 	// Crear solicitud